@@ -0,0 +1,147 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	"github.com/spf13/cobra"
+)
+
+type ApplyRunner struct {
+	// PrintRunner holds the flags selecting the resources to convert.
+	PrintRunner
+
+	// dryRun determines whether the applied objects are persisted. Value
+	// assigned via --dry-run flag.
+	dryRun string
+
+	// forceConflicts takes ownership of fields managed by other field managers.
+	// Value assigned via --force-conflicts flag.
+	forceConflicts bool
+}
+
+// ApplyGatewayAPIObjects converts the ingress and provider-specific resources,
+// then applies the generated Gateway API objects to the cluster with
+// server-side apply.
+func (ar *ApplyRunner) ApplyGatewayAPIObjects(cmd *cobra.Command, _ []string) error {
+	// The resources are read from the cluster they are applied to, with the
+	// same client.
+	cl, err := i2gw.NewClusterClient()
+	if err != nil {
+		return err
+	}
+	ar.clusterClient = cl
+
+	gatewayResources, err := ar.convert(cmd)
+	if err != nil {
+		return err
+	}
+
+	results := i2gw.ApplyGatewayResources(cmd.Context(), cl, gatewayResources, i2gw.ApplyOptions{
+		DryRun:         i2gw.DryRunStrategy(ar.dryRun),
+		ForceConflicts: ar.forceConflicts,
+	})
+	if err := ar.outputResults(cmd.OutOrStdout(), results); err != nil {
+		return err
+	}
+	return ar.checkNotifications(cmd)
+}
+
+// outputResults prints one line per applied object, and returns an error if
+// any of them could not be applied.
+func (ar *ApplyRunner) outputResults(w io.Writer, results []i2gw.ApplyResult) error {
+	if len(results) == 0 {
+		msg := "No resources found"
+		if ar.namespaceFilter != "" {
+			msg = fmt.Sprintf("%s in %s namespace", msg, ar.namespaceFilter)
+		}
+		fmt.Fprintln(w, msg)
+		return nil
+	}
+
+	var dryRunSuffix string
+	if ar.dryRun != string(i2gw.DryRunNone) {
+		dryRunSuffix = fmt.Sprintf(" (%s dry run)", ar.dryRun)
+	}
+
+	var failed int
+	for _, result := range results {
+		gvk := result.Object.GetObjectKind().GroupVersionKind()
+		name := result.Object.GetName()
+		if result.Object.GetNamespace() != "" {
+			name = fmt.Sprintf("%s/%s", result.Object.GetNamespace(), name)
+		}
+		line := fmt.Sprintf("%s.%s %s %s%s", strings.ToLower(gvk.Kind), gvk.Group, name, result.Type, dryRunSuffix)
+		if result.Err != nil {
+			failed++
+			line = fmt.Sprintf("%s: %v", line, result.Err)
+		}
+		fmt.Fprintln(w, line)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to apply %d of %d objects", failed, len(results))
+	}
+	return nil
+}
+
+func newApplyCommand() *cobra.Command {
+	ar := &ApplyRunner{}
+	dryRunStrategies := make([]string, 0, len(i2gw.DryRunStrategies))
+	for _, strategy := range i2gw.DryRunStrategies {
+		dryRunStrategies = append(dryRunStrategies, string(strategy))
+	}
+
+	// applyCmd represents the apply command. It applies HTTPRoutes and Gateways
+	// generated from Ingress resources to the cluster.
+	var cmd = &cobra.Command{
+		Use:   "apply",
+		Short: "Applies Gateway API objects generated from ingress and provider-specific resources to the cluster.",
+		RunE:  ar.ApplyGatewayAPIObjects,
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			if err := ar.loadConfig(cmd); err != nil {
+				return err
+			}
+			if err := ar.validateInputFlags(); err != nil {
+				return err
+			}
+			if err := ar.validateReportFlags(); err != nil {
+				return err
+			}
+			if !slices.Contains(dryRunStrategies, ar.dryRun) {
+				return fmt.Errorf("%s is not a supported dry run strategy, supported values are %v", ar.dryRun, dryRunStrategies)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&ar.dryRun, "dry-run", string(i2gw.DryRunNone),
+		fmt.Sprintf(`Must be one of: (%s). If client, only compute the results without sending the objects. If server, submit server-side apply requests without persisting the objects.`, strings.Join(dryRunStrategies, ", ")))
+
+	cmd.Flags().BoolVar(&ar.forceConflicts, "force-conflicts", false,
+		fmt.Sprintf(`If true, take ownership of the fields managed by other field managers instead of reporting a conflict. Objects are applied with the %q field manager.`, i2gw.FieldManager))
+
+	ar.addInputFlags(cmd)
+	ar.addReportFlags(cmd)
+	ar.addProvenanceFlags(cmd)
+	return cmd
+}
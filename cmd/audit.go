@@ -0,0 +1,167 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"text/tabwriter"
+
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/providers/common"
+	"github.com/spf13/cobra"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type AuditRunner struct {
+	// PrintRunner holds the flags selecting the resources to audit.
+	PrintRunner
+}
+
+// AuditIngresses reads the Ingresses, then prints the provider annotations
+// which would be dropped by the conversion.
+func (ar *AuditRunner) AuditIngresses(cmd *cobra.Command, _ []string) error {
+	err := ar.initializeNamespaceFilter()
+	if err != nil {
+		return fmt.Errorf("failed to initialize namespace filter: %w", err)
+	}
+
+	ingresses, err := ar.readIngresses(cmd.Context(), cmd.InOrStdin())
+	if err != nil {
+		return err
+	}
+
+	audits := i2gw.AuditIngresses(ingresses, ar.providers)
+	outputAudits(cmd.OutOrStdout(), audits, len(ingresses))
+	return nil
+}
+
+// readIngresses reads the Ingresses from the input files, or from the cluster
+// if there are none.
+func (ar *AuditRunner) readIngresses(ctx context.Context, stdin io.Reader) ([]networkingv1.Ingress, error) {
+	if !ar.readsFromFiles() {
+		cl, err := i2gw.NewClusterClient()
+		if err != nil {
+			return nil, err
+		}
+		var ingressList networkingv1.IngressList
+		if err := cl.List(ctx, &ingressList, client.InNamespace(ar.namespaceFilter)); err != nil {
+			return nil, fmt.Errorf("failed to get ingresses from the cluster: %w", err)
+		}
+		return ingressList.Items, nil
+	}
+
+	inputFiles, inMemoryFiles, err := ar.renderInputs(stdin)
+	if err != nil {
+		return nil, err
+	}
+	filenames, err := i2gw.ExpandInputFiles(inputFiles, inMemoryFiles)
+	if err != nil {
+		return nil, err
+	}
+	files, err := i2gw.ReadInputFiles(filenames, inMemoryFiles)
+	if err != nil {
+		return nil, err
+	}
+	objects, err := common.ReadObjectsFromFiles(&i2gw.ProviderConf{Namespace: ar.namespaceFilter, InputFiles: files}, filenames)
+	if err != nil {
+		return nil, err
+	}
+
+	var ingresses []networkingv1.Ingress
+	for _, obj := range objects {
+		if obj.GroupVersionKind() != networkingv1.SchemeGroupVersion.WithKind("Ingress") {
+			continue
+		}
+		var ingress networkingv1.Ingress
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), &ingress); err != nil {
+			return nil, fmt.Errorf("failed to parse Ingress from %s: %w", common.SourceFile(obj), err)
+		}
+		ingresses = append(ingresses, ingress)
+	}
+	return ingresses, nil
+}
+
+// outputAudits prints the dropped annotations of every Ingress, followed by
+// a summary of how many Ingresses carry each of them.
+func outputAudits(w io.Writer, audits []i2gw.IngressAudit, ingressCount int) {
+	ingressesByAnnotation := make(map[string]int)
+	for _, audit := range audits {
+		fmt.Fprintf(w, "Ingress %s\n", audit.Ingress)
+
+		providers := make([]i2gw.ProviderName, 0, len(audit.DroppedAnnotations))
+		for provider := range audit.DroppedAnnotations {
+			providers = append(providers, provider)
+		}
+		slices.Sort(providers)
+		for _, provider := range providers {
+			for _, key := range audit.DroppedAnnotations[provider] {
+				fmt.Fprintf(w, "  %s (%s)\n", key, provider)
+				ingressesByAnnotation[key]++
+			}
+		}
+	}
+
+	if len(audits) > 0 {
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintf(w, "%d of %d Ingresses have annotations which will be dropped\n", len(audits), ingressCount)
+	if len(ingressesByAnnotation) == 0 {
+		return
+	}
+
+	annotations := make([]string, 0, len(ingressesByAnnotation))
+	for key := range ingressesByAnnotation {
+		annotations = append(annotations, key)
+	}
+	slices.SortFunc(annotations, func(a, b string) int {
+		return cmp.Or(cmp.Compare(ingressesByAnnotation[b], ingressesByAnnotation[a]), cmp.Compare(a, b))
+	})
+
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "  ANNOTATION\tINGRESSES")
+	for _, key := range annotations {
+		fmt.Fprintf(tw, "  %s\t%d\n", key, ingressesByAnnotation[key])
+	}
+	tw.Flush()
+}
+
+func newAuditCommand() *cobra.Command {
+	ar := &AuditRunner{}
+
+	// auditCmd represents the audit command. It reports the annotations of the
+	// Ingress resources which the providers do not convert.
+	var cmd = &cobra.Command{
+		Use:   "audit",
+		Short: "Reports the provider-specific Ingress annotations which are dropped by the conversion.",
+		RunE:  ar.AuditIngresses,
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			if err := ar.loadConfig(cmd); err != nil {
+				return err
+			}
+			return ar.validateInputFlags()
+		},
+	}
+
+	ar.addInputFlags(cmd)
+	return cmd
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func Test_outputAudits(t *testing.T) {
+	audits := []i2gw.IngressAudit{
+		{
+			Ingress:            types.NamespacedName{Namespace: "default", Name: "api"},
+			DroppedAnnotations: map[i2gw.ProviderName][]string{"ingress-nginx": {"nginx.ingress.kubernetes.io/rewrite-target"}},
+		},
+		{
+			Ingress: types.NamespacedName{Namespace: "default", Name: "web"},
+			DroppedAnnotations: map[i2gw.ProviderName][]string{
+				"kong":          {"konghq.com/strip-path"},
+				"ingress-nginx": {"nginx.ingress.kubernetes.io/rewrite-target"},
+			},
+		},
+	}
+
+	expected := `Ingress default/api
+  nginx.ingress.kubernetes.io/rewrite-target (ingress-nginx)
+Ingress default/web
+  nginx.ingress.kubernetes.io/rewrite-target (ingress-nginx)
+  konghq.com/strip-path (kong)
+
+2 of 3 Ingresses have annotations which will be dropped
+  ANNOTATION                                   INGRESSES
+  nginx.ingress.kubernetes.io/rewrite-target   2
+  konghq.com/strip-path                        1
+`
+
+	var buf bytes.Buffer
+	outputAudits(&buf, audits, 3)
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Errorf("Unexpected output (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	"github.com/spf13/cobra"
+)
+
+// loadConfig reads the --config file, if any, and sets the flags of cmd which
+// were not set on the command line to the values of the config.
+func (pr *PrintRunner) loadConfig(cmd *cobra.Command) error {
+	if pr.configFile == "" {
+		return nil
+	}
+	config, err := i2gw.LoadConfig(pr.configFile)
+	if err != nil {
+		return err
+	}
+
+	values := configFlagValues(config)
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		// The flags of other commands, e.g. --output-dir for apply, are
+		// ignored.
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil || flag.Changed {
+			continue
+		}
+		if err := cmd.Flags().Set(name, values[name]); err != nil {
+			return fmt.Errorf("invalid value of --%s in config file %s: %w", name, pr.configFile, err)
+		}
+	}
+
+	pr.gateways = config.Gateways
+	pr.disabledFeatures = make(map[i2gw.ProviderName][]string)
+	for provider, settings := range config.ProviderSettings {
+		if len(settings.DisabledFeatures) > 0 {
+			pr.disabledFeatures[i2gw.ProviderName(provider)] = settings.DisabledFeatures
+		}
+	}
+	return nil
+}
+
+// configFlagValues returns the values of the config which have a flag, by
+// flag name.
+func configFlagValues(config *i2gw.Config) map[string]string {
+	values := map[string]string{
+		"providers":         strings.Join(config.Providers, ","),
+		"output":            config.Output.Format,
+		"output-dir":        config.Output.Dir,
+		"output-dir-layout": config.Output.DirLayout,
+		"report-file":       config.Output.ReportFile,
+		"report-format":     config.Output.ReportFormat,
+		"fail-on":           config.Output.FailOn,
+		"provenance-file":   config.Output.ProvenanceFile,
+	}
+	if config.Output.ProvenanceAnnotations {
+		values["provenance-annotations"] = strconv.FormatBool(true)
+	}
+	for provider, settings := range config.ProviderSettings {
+		for flag, value := range settings.Flags {
+			values[fmt.Sprintf("%s-%s", provider, flag)] = value
+		}
+	}
+	for name, value := range values {
+		if value == "" {
+			delete(values, name)
+		}
+	}
+	return values
+}
+
+// conversionOptions returns the options of the conversion set by the config
+// and the flags.
+func (pr *PrintRunner) conversionOptions() i2gw.ConversionOptions {
+	gateways := pr.gateways
+	if pr.sharedGatewayNamespace != "" {
+		// --shared-gateway-namespace shares the Gateways which are not listed
+		// in the config.
+		gateways = maps.Clone(pr.gateways)
+		if gateways == nil {
+			gateways = make(map[string]i2gw.GatewayConfig)
+		}
+		config := gateways[i2gw.DefaultGatewayConfigKey]
+		config.Namespace = pr.sharedGatewayNamespace
+		config.Shared = true
+		gateways[i2gw.DefaultGatewayConfigKey] = config
+	}
+	return i2gw.ConversionOptions{
+		Gateways:         gateways,
+		DisabledFeatures: pr.disabledFeatures,
+		BestEffort:       pr.bestEffort,
+		Client:           pr.clusterClient,
+	}
+}
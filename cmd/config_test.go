@@ -0,0 +1,112 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	"github.com/spf13/cobra"
+)
+
+func Test_loadConfig(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	config := `apiVersion: ingress2gateway.kubernetes.io/v1alpha1
+kind: Config
+providers: [openapi3]
+gateways:
+  nginx:
+    namespace: infra
+providerSettings:
+  openapi3:
+    flags:
+      backend: web
+      gateway-class-name: from-config
+    disabledFeatures: [someFeature]
+output:
+  reportFormat: sarif
+  failOn: warning
+  provenanceAnnotations: true
+`
+	if err := os.WriteFile(configFile, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	pr := &PrintRunner{}
+	cmd := &cobra.Command{}
+	pr.addInputFlags(cmd)
+	pr.addReportFlags(cmd)
+	pr.addProvenanceFlags(cmd)
+	// The flags set on the command line take precedence over the config.
+	if err := cmd.ParseFlags([]string{"--config", configFile, "--fail-on", "error", "--openapi3-gateway-class-name", "from-flag"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := pr.loadConfig(cmd); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if diff := cmp.Diff([]string{"openapi3"}, pr.providers); diff != "" {
+		t.Errorf("Unexpected providers (-want +got):\n%s", diff)
+	}
+	if pr.failOn != "error" {
+		t.Errorf("Expected --fail-on error, got %s", pr.failOn)
+	}
+	if pr.reportFormat != "sarif" {
+		t.Errorf("Expected --report-format sarif, got %s", pr.reportFormat)
+	}
+	if !pr.provenanceAnnotations {
+		t.Errorf("Expected --provenance-annotations to be set")
+	}
+	expectedFlags := map[string]map[string]string{
+		"openapi3": {"backend": "web", "gateway-class-name": "from-flag", "gateway-tls-secret": ""},
+	}
+	if diff := cmp.Diff(expectedFlags, pr.getProviderSpecificFlags()); diff != "" {
+		t.Errorf("Unexpected provider-specific flags (-want +got):\n%s", diff)
+	}
+	expectedOptions := i2gw.ConversionOptions{
+		Gateways:         map[string]i2gw.GatewayConfig{"nginx": {Namespace: "infra"}},
+		DisabledFeatures: map[i2gw.ProviderName][]string{"openapi3": {"someFeature"}},
+	}
+	if diff := cmp.Diff(expectedOptions, pr.conversionOptions()); diff != "" {
+		t.Errorf("Unexpected conversion options (-want +got):\n%s", diff)
+	}
+}
+
+func Test_conversionOptions(t *testing.T) {
+	pr := &PrintRunner{
+		gateways: map[string]i2gw.GatewayConfig{
+			"nginx":                      {Name: "public"},
+			i2gw.DefaultGatewayConfigKey: {GatewayClassName: "shared-class"},
+		},
+		sharedGatewayNamespace: "infra",
+	}
+	// --shared-gateway-namespace shares the Gateways which are not listed.
+	expectedGateways := map[string]i2gw.GatewayConfig{
+		"nginx":                      {Name: "public"},
+		i2gw.DefaultGatewayConfigKey: {GatewayClassName: "shared-class", Namespace: "infra", Shared: true},
+	}
+	if diff := cmp.Diff(expectedGateways, pr.conversionOptions().Gateways); diff != "" {
+		t.Errorf("Unexpected Gateways (-want +got):\n%s", diff)
+	}
+	// The config is not modified.
+	if pr.gateways[i2gw.DefaultGatewayConfigKey].Shared {
+		t.Errorf("Expected the config not to be modified")
+	}
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	"github.com/spf13/cobra"
+)
+
+type DiffRunner struct {
+	// PrintRunner holds the flags selecting the resources to convert.
+	PrintRunner
+}
+
+// DiffGatewayAPIObjects converts the ingress and provider-specific resources,
+// then prints how the generated Gateway API objects differ from the ones in
+// the cluster.
+func (dr *DiffRunner) DiffGatewayAPIObjects(cmd *cobra.Command, _ []string) error {
+	gatewayResources, err := dr.convert(cmd)
+	if err != nil {
+		return err
+	}
+
+	cl, err := i2gw.NewClusterClient()
+	if err != nil {
+		return err
+	}
+
+	diffs, err := i2gw.DiffGatewayResources(cmd.Context(), cl, dr.namespaceFilter, gatewayResources)
+	if err != nil {
+		return err
+	}
+	if err := outputDiffs(cmd.OutOrStdout(), diffs); err != nil {
+		return err
+	}
+	warnUntracked(cmd.ErrOrStderr(), diffs)
+	return dr.checkNotifications(cmd)
+}
+
+// outputDiffs prints the new, changed and orphaned objects sorted by kind,
+// namespace and name, followed by a summary.
+func outputDiffs(w io.Writer, diffs []i2gw.ObjectDiff) error {
+	slices.SortFunc(diffs, func(a, b i2gw.ObjectDiff) int {
+		return cmp.Or(
+			cmp.Compare(a.Object.GetObjectKind().GroupVersionKind().Kind, b.Object.GetObjectKind().GroupVersionKind().Kind),
+			cmp.Compare(a.Object.GetNamespace(), b.Object.GetNamespace()),
+			cmp.Compare(a.Object.GetName(), b.Object.GetName()),
+		)
+	})
+
+	countByType := make(map[i2gw.ObjectDiffType]int)
+	for _, diff := range diffs {
+		countByType[diff.Type]++
+		if diff.Type == i2gw.ObjectDiffUnchanged {
+			continue
+		}
+
+		name := diff.Object.GetName()
+		if diff.Object.GetNamespace() != "" {
+			name = fmt.Sprintf("%s/%s", diff.Object.GetNamespace(), name)
+		}
+		fmt.Fprintf(w, "%s %s %s\n", diff.Object.GetObjectKind().GroupVersionKind().Kind, name, diff.Type)
+
+		for _, field := range diff.Fields {
+			fmt.Fprintf(w, "  %s\n", field.Path)
+			if field.Live != nil {
+				live, err := json.Marshal(field.Live)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(w, "    - %s\n", live)
+			}
+			if field.Generated != nil {
+				generated, err := json.Marshal(field.Generated)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(w, "    + %s\n", generated)
+			}
+		}
+	}
+
+	fmt.Fprintf(w, "%d new, %d changed, %d unchanged, %d orphaned, %d untracked\n",
+		countByType[i2gw.ObjectDiffNew], countByType[i2gw.ObjectDiffChanged],
+		countByType[i2gw.ObjectDiffUnchanged], countByType[i2gw.ObjectDiffOrphaned],
+		countByType[i2gw.ObjectDiffUntracked])
+	return nil
+}
+
+// warnUntracked warns that orphaned objects can only be detected among the
+// objects with recorded sources, if some have none.
+func warnUntracked(w io.Writer, diffs []i2gw.ObjectDiff) {
+	var untracked int
+	for _, diff := range diffs {
+		if diff.Type == i2gw.ObjectDiffUntracked {
+			untracked++
+		}
+	}
+	if untracked > 0 {
+		fmt.Fprintf(w, "Warning: %d objects are not generated anymore but have no recorded sources, so whether they are orphaned is not known. Apply them with --provenance-annotations to detect orphaned objects.\n", untracked)
+	}
+}
+
+func newDiffCommand() *cobra.Command {
+	dr := &DiffRunner{}
+
+	// diffCmd represents the diff command. It compares HTTPRoutes and Gateways
+	// generated from Ingress resources with the ones in the cluster.
+	var cmd = &cobra.Command{
+		Use:   "diff",
+		Short: "Shows how Gateway API objects generated from ingress and provider-specific resources differ from the ones in the cluster.",
+		RunE:  dr.DiffGatewayAPIObjects,
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			if err := dr.loadConfig(cmd); err != nil {
+				return err
+			}
+			if err := dr.validateInputFlags(); err != nil {
+				return err
+			}
+			return dr.validateReportFlags()
+		},
+	}
+
+	dr.addInputFlags(cmd)
+	dr.addReportFlags(cmd)
+	dr.addProvenanceFlags(cmd)
+	return cmd
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func Test_outputDiffs(t *testing.T) {
+	route := func(name string) *gatewayv1.HTTPRoute {
+		r := &gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name}}
+		r.SetGroupVersionKind(gatewayv1.SchemeGroupVersion.WithKind("HTTPRoute"))
+		return r
+	}
+	gateway := &gatewayv1.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nginx"}}
+	gateway.SetGroupVersionKind(gatewayv1.SchemeGroupVersion.WithKind("Gateway"))
+
+	diffs := []i2gw.ObjectDiff{
+		{Object: route("orphaned"), Type: i2gw.ObjectDiffOrphaned},
+		{Object: route("untracked"), Type: i2gw.ObjectDiffUntracked},
+		{Object: route("unchanged"), Type: i2gw.ObjectDiffUnchanged},
+		{Object: route("changed"), Type: i2gw.ObjectDiffChanged, Fields: []i2gw.FieldDiff{
+			{Path: "spec.rules[0].matches[0].path.value", Live: "/old", Generated: "/"},
+			{Path: "spec.hostnames", Generated: []interface{}{"example.com"}},
+		}},
+		{Object: gateway, Type: i2gw.ObjectDiffNew},
+	}
+
+	var out bytes.Buffer
+	if err := outputDiffs(&out, diffs); err != nil {
+		t.Fatalf("Expected no error but got %v", err)
+	}
+
+	expected := `Gateway default/nginx new
+HTTPRoute default/changed changed
+  spec.rules[0].matches[0].path.value
+    - "/old"
+    + "/"
+  spec.hostnames
+    + ["example.com"]
+HTTPRoute default/orphaned orphaned
+HTTPRoute default/untracked untracked
+1 new, 1 changed, 1 unchanged, 1 orphaned, 1 untracked
+`
+	if diff := cmp.Diff(expected, out.String()); diff != "" {
+		t.Errorf("Unexpected output (-want +got):\n%s", diff)
+	}
+}
+
+func Test_warnUntracked(t *testing.T) {
+	route := &gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "untracked"}}
+
+	var out bytes.Buffer
+	warnUntracked(&out, []i2gw.ObjectDiff{{Object: route, Type: i2gw.ObjectDiffOrphaned}})
+	if out.Len() != 0 {
+		t.Errorf("Expected no warning without untracked objects, got %q", out.String())
+	}
+
+	warnUntracked(&out, []i2gw.ObjectDiff{{Object: route, Type: i2gw.ObjectDiffUntracked}})
+	if !strings.Contains(out.String(), "1 objects are not generated anymore but have no recorded sources") {
+		t.Errorf("Expected a warning about the untracked object, got %q", out.String())
+	}
+}
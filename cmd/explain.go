@@ -0,0 +1,189 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/intermediate"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+)
+
+type ExplainRunner struct {
+	// PrintRunner holds the flags selecting the resources to convert.
+	PrintRunner
+}
+
+// ExplainObject converts the resources in the namespace of the object given
+// as argument, then prints what the object contributed to every stage of the
+// conversion.
+func (er *ExplainRunner) ExplainObject(cmd *cobra.Command, args []string) error {
+	source, err := parseObjectRef(args[0])
+	if err != nil {
+		return err
+	}
+	er.namespaceFilter = source.Namespace
+
+	inputFiles, inMemoryFiles, err := er.renderInputs(cmd.InOrStdin())
+	if err != nil {
+		return err
+	}
+
+	explainer := i2gw.NewExplainer(source)
+	opts := er.conversionOptions()
+	opts.Tracer = explainer
+	opts.InputFiles = inMemoryFiles
+	_, _, err = i2gw.ToGatewayAPIResourcesWithOptions(cmd.Context(), er.namespaceFilter, inputFiles, er.providers, er.getProviderSpecificFlags(), opts)
+	var convErr *i2gw.ConversionError
+	if err != nil && !errors.As(err, &convErr) {
+		return err
+	}
+	// The stages run before a conversion error are still worth showing.
+	if outputErr := outputExplanation(cmd.OutOrStdout(), source, explainer.Stages()); outputErr != nil {
+		return outputErr
+	}
+	return err
+}
+
+// parseObjectRef parses an object given as kind/namespace/name.
+func parseObjectRef(s string) (intermediate.ObjectRef, error) {
+	parts := strings.Split(s, "/")
+	if len(parts) != 3 || slices.Contains(parts, "") {
+		return intermediate.ObjectRef{}, fmt.Errorf("invalid object %q, expected <kind>/<namespace>/<name>", s)
+	}
+	return intermediate.ObjectRef{Kind: parts[0], Namespace: parts[1], Name: parts[2]}, nil
+}
+
+// outputExplanation prints the stages of every provider, sorted by provider
+// name. The first stage and the generated resources are printed in full, and
+// the other stages as the changes they made.
+func outputExplanation(w io.Writer, source intermediate.ObjectRef, stagesByProvider map[i2gw.ProviderName][]i2gw.ExplainStage) error {
+	providers := make([]i2gw.ProviderName, 0, len(stagesByProvider))
+	for provider := range stagesByProvider {
+		providers = append(providers, provider)
+	}
+	slices.Sort(providers)
+
+	for _, provider := range providers {
+		stages := stagesByProvider[provider]
+		fmt.Fprintf(w, "Provider %s\n", provider)
+		if !slices.ContainsFunc(stages, func(s i2gw.ExplainStage) bool { return len(s.Objects) > 0 }) {
+			fmt.Fprintf(w, "  no objects converted from %s\n", source)
+			continue
+		}
+
+		for _, stage := range stages {
+			fmt.Fprintf(w, "  %s\n", stage.Stage)
+			if stage.First || stage.Stage == i2gw.TraceStageToGatewayResources {
+				if len(stage.Objects) == 0 {
+					fmt.Fprintln(w, "    no objects")
+				}
+				for _, obj := range stage.Objects {
+					if err := outputExplainObject(w, obj); err != nil {
+						return err
+					}
+				}
+				continue
+			}
+
+			if len(stage.Added) == 0 && len(stage.Removed) == 0 && len(stage.Changes) == 0 {
+				fmt.Fprintln(w, "    no changes")
+				continue
+			}
+			for _, obj := range stage.Objects {
+				if slices.Contains(stage.Added, obj.Ref) {
+					fmt.Fprintf(w, "    %s added\n", obj.Ref)
+					if err := outputExplainObject(w, obj); err != nil {
+						return err
+					}
+					continue
+				}
+				changes, ok := stage.Changes[obj.Ref]
+				if !ok {
+					continue
+				}
+				fmt.Fprintf(w, "    %s changed\n", obj.Ref)
+				for _, change := range changes {
+					fmt.Fprintf(w, "      %s\n", change.Path)
+					if change.Before != nil {
+						before, err := json.Marshal(change.Before)
+						if err != nil {
+							return err
+						}
+						fmt.Fprintf(w, "        - %s\n", before)
+					}
+					if change.After != nil {
+						after, err := json.Marshal(change.After)
+						if err != nil {
+							return err
+						}
+						fmt.Fprintf(w, "        + %s\n", after)
+					}
+				}
+			}
+			for _, ref := range stage.Removed {
+				fmt.Fprintf(w, "    %s removed\n", ref)
+			}
+		}
+	}
+	return nil
+}
+
+// outputExplainObject prints obj as YAML, indented below the stage.
+func outputExplainObject(w io.Writer, obj i2gw.ExplainObject) error {
+	data, err := yaml.Marshal(obj.Object.Object)
+	if err != nil {
+		return fmt.Errorf("failed to print %s: %w", obj.Ref, err)
+	}
+	fmt.Fprintf(w, "    %s\n", obj.Ref)
+	for _, line := range strings.Split(strings.TrimSuffix(string(data), "\n"), "\n") {
+		fmt.Fprintf(w, "      %s\n", line)
+	}
+	return nil
+}
+
+func newExplainCommand() *cobra.Command {
+	er := &ExplainRunner{}
+
+	// explainCmd represents the explain command. It traces an object through
+	// the conversion of every provider.
+	var cmd = &cobra.Command{
+		Use:   "explain <kind>/<namespace>/<name>",
+		Short: "Shows what an ingress or provider-specific resource contributes to every stage of the conversion.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  er.ExplainObject,
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			if err := er.loadConfig(cmd); err != nil {
+				return err
+			}
+			return er.validateInputFlags()
+		},
+	}
+
+	er.addInputFlags(cmd)
+	// The namespace is the one of the explained object.
+	_ = cmd.Flags().MarkHidden("namespace")
+	_ = cmd.Flags().MarkHidden("all-namespaces")
+	return cmd
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/intermediate"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func Test_parseObjectRef(t *testing.T) {
+	testCases := []struct {
+		name        string
+		input       string
+		expected    intermediate.ObjectRef
+		expectedErr bool
+	}{
+		{
+			name:     "kind, namespace and name",
+			input:    "Ingress/default/foo",
+			expected: intermediate.ObjectRef{Kind: "Ingress", Namespace: "default", Name: "foo"},
+		},
+		{
+			name:        "missing namespace",
+			input:       "Ingress/foo",
+			expectedErr: true,
+		},
+		{
+			name:        "empty name",
+			input:       "Ingress/default/",
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ref, err := parseObjectRef(tc.input)
+			if tc.expectedErr != (err != nil) {
+				t.Fatalf("Expected error: %t, got %v", tc.expectedErr, err)
+			}
+			if ref != tc.expected {
+				t.Errorf("Expected %v, got %v", tc.expected, ref)
+			}
+		})
+	}
+}
+
+func Test_outputExplanation(t *testing.T) {
+	source := intermediate.ObjectRef{Kind: "Ingress", Namespace: "default", Name: "foo"}
+	routeRef := intermediate.ObjectRef{Kind: "HTTPRoute", Namespace: "default", Name: "foo-route"}
+	route := i2gw.ExplainObject{
+		Ref: routeRef,
+		Object: &unstructured.Unstructured{Object: map[string]interface{}{
+			"kind":     "HTTPRoute",
+			"metadata": map[string]interface{}{"name": "foo-route", "namespace": "default"},
+		}},
+	}
+
+	stagesByProvider := map[i2gw.ProviderName][]i2gw.ExplainStage{
+		"kong": {
+			{Stage: i2gw.TraceStageCommonToIR, Objects: []i2gw.ExplainObject{route}, First: true},
+			{
+				Stage:   "kong.methodMatchingFeature",
+				Objects: []i2gw.ExplainObject{route},
+				Changes: map[intermediate.ObjectRef][]i2gw.StageChange{
+					routeRef: {{Path: "spec.rules[0].matches[0].method", After: "GET"}},
+				},
+			},
+			{Stage: i2gw.TraceStageToIR, Objects: []i2gw.ExplainObject{route}},
+			{Stage: i2gw.TraceStageToGatewayResources, Objects: []i2gw.ExplainObject{route}},
+		},
+		"apisix": {
+			{Stage: i2gw.TraceStageCommonToIR, First: true},
+		},
+	}
+
+	expected := `Provider apisix
+  no objects converted from Ingress/default/foo
+Provider kong
+  common.ToIR
+    HTTPRoute/default/foo-route
+      kind: HTTPRoute
+      metadata:
+        name: foo-route
+        namespace: default
+  kong.methodMatchingFeature
+    HTTPRoute/default/foo-route changed
+      spec.rules[0].matches[0].method
+        + "GET"
+  ToIR
+    no changes
+  ToGatewayResources
+    HTTPRoute/default/foo-route
+      kind: HTTPRoute
+      metadata:
+        name: foo-route
+        namespace: default
+`
+
+	var buf bytes.Buffer
+	if err := outputExplanation(&buf, source, stagesByProvider); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Errorf("Unexpected output (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,351 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/intermediate"
+	"k8s.io/cli-runtime/pkg/printers"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// outputDirLayoutNamespace writes objects to <namespace>/<kind>-<name>.yaml.
+	outputDirLayoutNamespace = "namespace"
+	// outputDirLayoutKind writes objects to <kind>/<namespace>/<name>.yaml.
+	outputDirLayoutKind = "kind"
+	// outputDirLayoutSource writes objects to
+	// <namespace>/<source-kind>-<source-name>/<kind>-<name>.yaml, grouping them
+	// by the Ingress they were converted from. Objects built from several
+	// sources, or from none that is known, go to <namespace>/_shared.
+	outputDirLayoutSource = "source"
+
+	// clusterScopedDir holds cluster-scoped objects, e.g. GatewayClasses.
+	// Underscores are not valid in Kubernetes names, so it cannot clash with
+	// a namespace or object directory.
+	clusterScopedDir = "_cluster"
+	// sharedSourceDir holds objects that cannot be attributed to a single
+	// source in the source layout.
+	sharedSourceDir = "_shared"
+
+	kustomizationFileName = "kustomization.yaml"
+	// outputManifestFileName lists the files written to the output directory,
+	// so that reruns only ever remove files ingress2gateway wrote itself.
+	outputManifestFileName = ".ingress2gateway-files.yaml"
+)
+
+var outputDirLayouts = []string{outputDirLayoutNamespace, outputDirLayoutKind, outputDirLayoutSource}
+
+// outputManifest is the list of the files, relative to the output directory,
+// written by the last run.
+type outputManifest struct {
+	Files []string `json:"files"`
+}
+
+// outputDirWriter writes every generated object to its own file below dir,
+// along with a kustomization.yaml index in each directory.
+type outputDirWriter struct {
+	dir          string
+	layout       string
+	outputFormat string
+}
+
+// write writes the objects and returns how many were written. The files
+// written by a previous run, as listed by its manifest, which are not
+// generated anymore are removed, so reruns produce clean diffs. Files written
+// by hand are never removed, and the kustomization.yaml indexes written by
+// hand keep their content, with the generated files added to their resources.
+func (w *outputDirWriter) write(gatewayResources []i2gw.GatewayResources) (int, error) {
+	objects := i2gw.ToObjects(gatewayResources)
+	sources := i2gw.MergeSources(gatewayResources)
+
+	files := make(map[string]client.Object, len(objects))
+	for _, obj := range objects {
+		filePath := w.filePath(obj, sources)
+		if existing, ok := files[filePath]; ok {
+			return 0, fmt.Errorf("%s %s/%s and %s %s/%s would both be written to %s",
+				existing.GetObjectKind().GroupVersionKind().Kind, existing.GetNamespace(), existing.GetName(),
+				obj.GetObjectKind().GroupVersionKind().Kind, obj.GetNamespace(), obj.GetName(), filePath)
+		}
+		files[filePath] = obj
+	}
+
+	previous, err := readOutputManifest(w.dir)
+	if err != nil {
+		return 0, err
+	}
+	index := kustomizationIndex(files)
+	written := make([]string, 0, len(files)+len(index))
+	for filePath := range files {
+		written = append(written, filePath)
+	}
+	// The kustomization.yaml indexes written by hand are updated, and are
+	// left out of the manifest so that they are never overwritten or removed.
+	userIndexes := make(map[string]bool)
+	for dir := range index {
+		indexPath := path.Join(dir, kustomizationFileName)
+		if !slices.Contains(previous, indexPath) && fileExists(filepath.Join(w.dir, filepath.FromSlash(indexPath))) {
+			userIndexes[dir] = true
+			continue
+		}
+		written = append(written, indexPath)
+	}
+	slices.Sort(written)
+
+	if err := pruneOutputDir(w.dir, previous, written); err != nil {
+		return 0, fmt.Errorf("failed to prune stale files from %s: %w", w.dir, err)
+	}
+
+	for filePath, obj := range files {
+		if err := w.writeObject(filePath, obj); err != nil {
+			return 0, err
+		}
+	}
+
+	for dir, resources := range index {
+		var stale []string
+		if userIndexes[dir] {
+			stale = staleResources(dir, previous, written)
+		}
+		if err := writeKustomization(filepath.Join(w.dir, filepath.FromSlash(dir)), resources, !userIndexes[dir], stale); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := writeOutputManifest(w.dir, written); err != nil {
+		return 0, err
+	}
+	return len(files), nil
+}
+
+// filePath returns the slash-separated path, relative to the output
+// directory, of the file obj is written to.
+func (w *outputDirWriter) filePath(obj client.Object, sources map[intermediate.ObjectRef][]intermediate.ObjectRef) string {
+	kind := strings.ToLower(obj.GetObjectKind().GroupVersionKind().Kind)
+	ext := ".yaml"
+	if w.outputFormat == "json" {
+		ext = ".json"
+	}
+	fileName := fmt.Sprintf("%s-%s%s", kind, obj.GetName(), ext)
+
+	switch w.layout {
+	case outputDirLayoutKind:
+		if obj.GetNamespace() == "" {
+			return path.Join(kind, obj.GetName()+ext)
+		}
+		return path.Join(kind, obj.GetNamespace(), obj.GetName()+ext)
+	case outputDirLayoutSource:
+		if obj.GetNamespace() == "" {
+			return path.Join(clusterScopedDir, fileName)
+		}
+		ref := intermediate.ObjectRef{
+			Kind:      obj.GetObjectKind().GroupVersionKind().Kind,
+			Namespace: obj.GetNamespace(),
+			Name:      obj.GetName(),
+		}
+		if objSources := sources[ref]; len(objSources) == 1 && objSources[0].Namespace == obj.GetNamespace() {
+			sourceDir := fmt.Sprintf("%s-%s", strings.ToLower(objSources[0].Kind), objSources[0].Name)
+			return path.Join(obj.GetNamespace(), sourceDir, fileName)
+		}
+		return path.Join(obj.GetNamespace(), sharedSourceDir, fileName)
+	default:
+		if obj.GetNamespace() == "" {
+			return path.Join(clusterScopedDir, fileName)
+		}
+		return path.Join(obj.GetNamespace(), fileName)
+	}
+}
+
+func (w *outputDirWriter) writeObject(filePath string, obj client.Object) error {
+	fullPath := filepath.Join(w.dir, filepath.FromSlash(filePath))
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", fullPath, err)
+	}
+
+	f, err := os.Create(fullPath)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", fullPath, err)
+	}
+	defer f.Close()
+
+	// Printers keep track of the objects they printed already, so every file
+	// needs its own printer to avoid leading document separators.
+	var printer printers.ResourcePrinter = &printers.YAMLPrinter{}
+	if w.outputFormat == "json" {
+		printer = &printers.JSONPrinter{}
+	}
+	if err := printer.PrintObj(obj, f); err != nil {
+		return fmt.Errorf("failed to write %s: %w", fullPath, err)
+	}
+	return f.Close()
+}
+
+// kustomizationIndex returns, for every directory of the output tree, the
+// sorted files and subdirectories its kustomization.yaml has to reference.
+// The root directory is keyed by ".".
+func kustomizationIndex(files map[string]client.Object) map[string][]string {
+	index := make(map[string][]string)
+	for filePath := range files {
+		child := filePath
+		for dir := path.Dir(filePath); ; dir = path.Dir(dir) {
+			name := path.Base(child)
+			if !slices.Contains(index[dir], name) {
+				index[dir] = append(index[dir], name)
+			}
+			if dir == "." {
+				break
+			}
+			child = dir
+		}
+	}
+	for _, resources := range index {
+		slices.Sort(resources)
+	}
+	return index
+}
+
+// writeKustomization writes the kustomization.yaml of dir referencing
+// resources. An index which was not written by ingress2gateway, i.e. owned is
+// false, is updated instead: the resources are added to its own ones, stale
+// ones are removed from them and its other fields are kept.
+func writeKustomization(dir string, resources []string, owned bool, stale []string) error {
+	kustomizationPath := filepath.Join(dir, kustomizationFileName)
+	k := map[string]interface{}{
+		"apiVersion": "kustomize.config.k8s.io/v1beta1",
+		"kind":       "Kustomization",
+	}
+	if content, err := os.ReadFile(kustomizationPath); err == nil && !owned {
+		if err := yaml.Unmarshal(content, &k); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", kustomizationPath, err)
+		}
+		existing, _ := k["resources"].([]interface{})
+		var merged []string
+		for _, resource := range existing {
+			if resource, ok := resource.(string); ok && !slices.Contains(stale, resource) && !slices.Contains(resources, resource) {
+				merged = append(merged, resource)
+			}
+		}
+		resources = append(merged, resources...)
+	} else if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to read %s: %w", kustomizationPath, err)
+	}
+	k["resources"] = resources
+
+	content, err := yaml.Marshal(k)
+	if err != nil {
+		return fmt.Errorf("failed to build kustomization for %s: %w", dir, err)
+	}
+	if err := os.WriteFile(kustomizationPath, content, 0o644); err != nil {
+		return fmt.Errorf("failed to write kustomization for %s: %w", dir, err)
+	}
+	return nil
+}
+
+// staleResources returns the entries of the kustomization.yaml of dir which
+// point at files or directories of the previous run not written anymore.
+func staleResources(dir string, previous, written []string) []string {
+	var stale []string
+	for _, filePath := range previous {
+		if slices.Contains(written, filePath) {
+			continue
+		}
+		rel := strings.TrimPrefix(filePath, dir+"/")
+		if dir == "." {
+			rel = filePath
+		} else if rel == filePath {
+			continue
+		}
+		name, _, _ := strings.Cut(rel, "/")
+		if !slices.Contains(stale, name) && !slices.ContainsFunc(written, func(w string) bool {
+			return w == path.Join(dir, name) || strings.HasPrefix(w, path.Join(dir, name)+"/")
+		}) {
+			stale = append(stale, name)
+		}
+	}
+	return stale
+}
+
+func fileExists(name string) bool {
+	_, err := os.Stat(name)
+	return err == nil
+}
+
+func readOutputManifest(dir string) ([]string, error) {
+	manifestPath := filepath.Join(dir, outputManifestFileName)
+	content, err := os.ReadFile(manifestPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", manifestPath, err)
+	}
+	var manifest outputManifest
+	if err := yaml.Unmarshal(content, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", manifestPath, err)
+	}
+	return manifest.Files, nil
+}
+
+func writeOutputManifest(dir string, files []string) error {
+	content, err := yaml.Marshal(outputManifest{Files: files})
+	if err != nil {
+		return fmt.Errorf("failed to build the manifest of %s: %w", dir, err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, outputManifestFileName), content, 0o644); err != nil {
+		return fmt.Errorf("failed to write the manifest of %s: %w", dir, err)
+	}
+	return nil
+}
+
+// pruneOutputDir removes the files of the previous run, as listed by its
+// manifest, which are not written anymore, together with the directories
+// left empty. Files not listed by the manifest are never touched.
+func pruneOutputDir(dir string, previous, written []string) error {
+	for _, filePath := range previous {
+		if slices.Contains(written, filePath) {
+			continue
+		}
+		cleaned := path.Clean(filePath)
+		if path.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+			continue
+		}
+		fullPath := filepath.Join(dir, filepath.FromSlash(cleaned))
+		if err := os.Remove(fullPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		for parent := filepath.Dir(fullPath); parent != filepath.Clean(dir); parent = filepath.Dir(parent) {
+			entries, err := os.ReadDir(parent)
+			if err != nil || len(entries) > 0 {
+				break
+			}
+			if err := os.Remove(parent); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
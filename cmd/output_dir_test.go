@@ -0,0 +1,303 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/intermediate"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func testOutputDirResources() []i2gw.GatewayResources {
+	ingressRef := func(name string) intermediate.ObjectRef {
+		return intermediate.ObjectRef{Kind: "Ingress", Namespace: "default", Name: name}
+	}
+	return []i2gw.GatewayResources{{
+		GatewayClasses: map[types.NamespacedName]gatewayv1.GatewayClass{
+			{Name: "nginx"}: {ObjectMeta: metav1.ObjectMeta{Name: "nginx"}},
+		},
+		Gateways: map[types.NamespacedName]gatewayv1.Gateway{
+			{Namespace: "default", Name: "nginx"}: {ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nginx"}},
+		},
+		HTTPRoutes: map[types.NamespacedName]gatewayv1.HTTPRoute{
+			{Namespace: "default", Name: "foo-example-com"}: {ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo-example-com"}},
+			{Namespace: "default", Name: "bar-example-com"}: {ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "bar-example-com"}},
+		},
+		Sources: map[intermediate.ObjectRef][]intermediate.ObjectRef{
+			{Kind: "Gateway", Namespace: "default", Name: "nginx"}:             {ingressRef("bar"), ingressRef("foo")},
+			{Kind: "HTTPRoute", Namespace: "default", Name: "foo-example-com"}: {ingressRef("foo")},
+			{Kind: "HTTPRoute", Namespace: "default", Name: "bar-example-com"}: {ingressRef("bar")},
+		},
+	}}
+}
+
+func Test_outputDirWriter(t *testing.T) {
+	testCases := []struct {
+		name          string
+		layout        string
+		outputFormat  string
+		expectedFiles []string
+	}{
+		{
+			name:   "namespace layout",
+			layout: outputDirLayoutNamespace,
+			expectedFiles: []string{
+				outputManifestFileName,
+				"_cluster/gatewayclass-nginx.yaml",
+				"_cluster/kustomization.yaml",
+				"default/gateway-nginx.yaml",
+				"default/httproute-bar-example-com.yaml",
+				"default/httproute-foo-example-com.yaml",
+				"default/kustomization.yaml",
+				"kustomization.yaml",
+			},
+		},
+		{
+			name:   "kind layout",
+			layout: outputDirLayoutKind,
+			expectedFiles: []string{
+				outputManifestFileName,
+				"gateway/default/kustomization.yaml",
+				"gateway/default/nginx.yaml",
+				"gateway/kustomization.yaml",
+				"gatewayclass/kustomization.yaml",
+				"gatewayclass/nginx.yaml",
+				"httproute/default/bar-example-com.yaml",
+				"httproute/default/foo-example-com.yaml",
+				"httproute/default/kustomization.yaml",
+				"httproute/kustomization.yaml",
+				"kustomization.yaml",
+			},
+		},
+		{
+			name:   "source layout",
+			layout: outputDirLayoutSource,
+			expectedFiles: []string{
+				outputManifestFileName,
+				"_cluster/gatewayclass-nginx.yaml",
+				"_cluster/kustomization.yaml",
+				"default/_shared/gateway-nginx.yaml",
+				"default/_shared/kustomization.yaml",
+				"default/ingress-bar/httproute-bar-example-com.yaml",
+				"default/ingress-bar/kustomization.yaml",
+				"default/ingress-foo/httproute-foo-example-com.yaml",
+				"default/ingress-foo/kustomization.yaml",
+				"default/kustomization.yaml",
+				"kustomization.yaml",
+			},
+		},
+		{
+			name:         "json output",
+			layout:       outputDirLayoutNamespace,
+			outputFormat: "json",
+			expectedFiles: []string{
+				outputManifestFileName,
+				"_cluster/gatewayclass-nginx.json",
+				"_cluster/kustomization.yaml",
+				"default/gateway-nginx.json",
+				"default/httproute-bar-example-com.json",
+				"default/httproute-foo-example-com.json",
+				"default/kustomization.yaml",
+				"kustomization.yaml",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writer := outputDirWriter{dir: dir, layout: tc.layout, outputFormat: tc.outputFormat}
+
+			count, err := writer.write(testOutputDirResources())
+			if err != nil {
+				t.Fatalf("Expected no error but got %v", err)
+			}
+			if count != 4 {
+				t.Errorf("Expected 4 resources to be written, got %d", count)
+			}
+
+			if diff := cmp.Diff(tc.expectedFiles, listFiles(t, dir)); diff != "" {
+				t.Errorf("Unexpected files written (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_outputDirWriter_content(t *testing.T) {
+	dir := t.TempDir()
+	writer := outputDirWriter{dir: dir, layout: outputDirLayoutNamespace}
+	if _, err := writer.write(testOutputDirResources()); err != nil {
+		t.Fatalf("Expected no error but got %v", err)
+	}
+
+	kustomization, err := os.ReadFile(filepath.Join(dir, "default", kustomizationFileName))
+	if err != nil {
+		t.Fatalf("Expected no error but got %v", err)
+	}
+	expectedKustomization := `apiVersion: kustomize.config.k8s.io/v1beta1
+kind: Kustomization
+resources:
+- gateway-nginx.yaml
+- httproute-bar-example-com.yaml
+- httproute-foo-example-com.yaml
+`
+	if diff := cmp.Diff(expectedKustomization, string(kustomization)); diff != "" {
+		t.Errorf("Unexpected kustomization (-want +got):\n%s", diff)
+	}
+
+	gateway, err := os.ReadFile(filepath.Join(dir, "default", "gateway-nginx.yaml"))
+	if err != nil {
+		t.Fatalf("Expected no error but got %v", err)
+	}
+	for _, expected := range []string{"kind: Gateway", i2gw.GeneratorAnnotationKey} {
+		if !strings.Contains(string(gateway), expected) {
+			t.Errorf("Expected Gateway file to contain %q, got:\n%s", expected, gateway)
+		}
+	}
+	if strings.HasPrefix(string(gateway), "---") {
+		t.Errorf("Expected Gateway file not to start with a document separator, got:\n%s", gateway)
+	}
+}
+
+func Test_outputDirWriter_rerun(t *testing.T) {
+	dir := t.TempDir()
+	writer := outputDirWriter{dir: dir, layout: outputDirLayoutSource}
+	if _, err := writer.write(testOutputDirResources()); err != nil {
+		t.Fatalf("Expected no error but got %v", err)
+	}
+	// Files not written by ingress2gateway must survive reruns.
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("hand written"), 0o644); err != nil {
+		t.Fatalf("Expected no error but got %v", err)
+	}
+
+	resources := testOutputDirResources()
+	delete(resources[0].HTTPRoutes, types.NamespacedName{Namespace: "default", Name: "bar-example-com"})
+	if _, err := writer.write(resources); err != nil {
+		t.Fatalf("Expected no error but got %v", err)
+	}
+
+	expectedFiles := []string{
+		outputManifestFileName,
+		"README.md",
+		"_cluster/gatewayclass-nginx.yaml",
+		"_cluster/kustomization.yaml",
+		"default/_shared/gateway-nginx.yaml",
+		"default/_shared/kustomization.yaml",
+		"default/ingress-foo/httproute-foo-example-com.yaml",
+		"default/ingress-foo/kustomization.yaml",
+		"default/kustomization.yaml",
+		"kustomization.yaml",
+	}
+	if diff := cmp.Diff(expectedFiles, listFiles(t, dir)); diff != "" {
+		t.Errorf("Unexpected files after rerun (-want +got):\n%s", diff)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "default", "ingress-bar")); !os.IsNotExist(err) {
+		t.Errorf("Expected stale directory default/ingress-bar to be removed, got %v", err)
+	}
+}
+
+func Test_outputDirWriter_userFiles(t *testing.T) {
+	dir := t.TempDir()
+	// A file written by hand and listed by a kustomization.yaml written by
+	// hand, e.g. in a GitOps repository, before the first run.
+	if err := os.MkdirAll(filepath.Join(dir, "default"), 0o755); err != nil {
+		t.Fatalf("Expected no error but got %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "default", "custom.yaml"), []byte("hand written"), 0o644); err != nil {
+		t.Fatalf("Expected no error but got %v", err)
+	}
+	userKustomization := `apiVersion: kustomize.config.k8s.io/v1beta1
+kind: Kustomization
+namePrefix: team-
+resources:
+- custom.yaml
+`
+	if err := os.WriteFile(filepath.Join(dir, "default", kustomizationFileName), []byte(userKustomization), 0o644); err != nil {
+		t.Fatalf("Expected no error but got %v", err)
+	}
+
+	writer := outputDirWriter{dir: dir, layout: outputDirLayoutNamespace}
+	if _, err := writer.write(testOutputDirResources()); err != nil {
+		t.Fatalf("Expected no error but got %v", err)
+	}
+	resources := testOutputDirResources()
+	delete(resources[0].HTTPRoutes, types.NamespacedName{Namespace: "default", Name: "bar-example-com"})
+	if _, err := writer.write(resources); err != nil {
+		t.Fatalf("Expected no error but got %v", err)
+	}
+
+	expectedFiles := []string{
+		outputManifestFileName,
+		"_cluster/gatewayclass-nginx.yaml",
+		"_cluster/kustomization.yaml",
+		"default/custom.yaml",
+		"default/gateway-nginx.yaml",
+		"default/httproute-foo-example-com.yaml",
+		"default/kustomization.yaml",
+		"kustomization.yaml",
+	}
+	if diff := cmp.Diff(expectedFiles, listFiles(t, dir)); diff != "" {
+		t.Errorf("Unexpected files after rerun (-want +got):\n%s", diff)
+	}
+
+	kustomization, err := os.ReadFile(filepath.Join(dir, "default", kustomizationFileName))
+	if err != nil {
+		t.Fatalf("Expected no error but got %v", err)
+	}
+	expectedKustomization := `apiVersion: kustomize.config.k8s.io/v1beta1
+kind: Kustomization
+namePrefix: team-
+resources:
+- custom.yaml
+- gateway-nginx.yaml
+- httproute-foo-example-com.yaml
+`
+	if diff := cmp.Diff(expectedKustomization, string(kustomization)); diff != "" {
+		t.Errorf("Unexpected kustomization (-want +got):\n%s", diff)
+	}
+}
+
+func listFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	var files []string
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to list files in %s: %v", dir, err)
+	}
+	slices.Sort(files)
+	return files
+}
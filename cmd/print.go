@@ -17,19 +17,26 @@ limitations under the License.
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"maps"
 	"os"
 	"slices"
 	"strings"
 
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
 	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/serializer/json"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/cli-runtime/pkg/printers"
 	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	// Call init function for the providers
 	_ "github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/providers/apisix"
@@ -38,9 +45,6 @@ import (
 	_ "github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/providers/istio"
 	_ "github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/providers/kong"
 	_ "github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/providers/openapi3"
-
-	// Call init for notifications
-	_ "github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
 )
 
 type PrintRunner struct {
@@ -48,8 +52,18 @@ type PrintRunner struct {
 	// Defaults to YAML.
 	outputFormat string
 
-	// The path to the input yaml config file. Value assigned via --input-file flag
-	inputFile string
+	// The paths to the input yaml config files, directories or glob patterns.
+	// Value assigned via --input-file flag
+	inputFiles []string
+
+	// The kustomization directory rendered as input. Value assigned via
+	// --input-kustomize flag
+	inputKustomize string
+
+	// The Helm chart directory rendered as input, with the values files.
+	// Values assigned via --input-helm-chart and --values flags
+	inputHelmChart  string
+	helmValuesFiles []string
 
 	// The namespace used to query Gateway API objects. Value assigned via
 	// --namespace/-n flag.
@@ -71,6 +85,71 @@ type PrintRunner struct {
 
 	// Provider specific flags --<provider>-<flag>.
 	providerSpecificFlags map[string]*string
+
+	// outputDir is the directory the resources are written to, one file per
+	// object, instead of stdout. Value assigned via --output-dir flag.
+	outputDir string
+
+	// outputDirLayout determines how the files are organized in outputDir.
+	// Value assigned via --output-dir-layout flag.
+	outputDirLayout string
+
+	// reportFile is the file the notifications and conversion errors are
+	// written to, instead of printing the notification tables to stderr.
+	// Value assigned via --report-file flag.
+	reportFile string
+
+	// reportFormat is the format of reportFile. Value assigned via
+	// --report-format flag.
+	reportFormat string
+
+	// failOn is the severity of the notifications making the command exit
+	// with exitCodeNotified. Value assigned via --fail-on flag.
+	failOn string
+
+	// provenanceAnnotations indicates whether the generated objects are
+	// annotated with the objects and the rules they were converted from.
+	// Value assigned via --provenance-annotations flag.
+	provenanceAnnotations bool
+
+	// provenanceFile is the file the provenance of the generated objects is
+	// written to. Value assigned via --provenance-file flag.
+	provenanceFile string
+
+	// configFile is the declarative configuration of the conversion. The
+	// flags set on the command line take precedence over it. Value assigned
+	// via --config flag.
+	configFile string
+
+	// gateways configures the generated Gateways, by generated name. Set by
+	// the config file.
+	gateways map[string]i2gw.GatewayConfig
+
+	// sharedGatewayNamespace is the namespace of the single Gateway generated
+	// per ingress class, instead of one per namespace. Value assigned via
+	// --shared-gateway-namespace flag.
+	sharedGatewayNamespace string
+
+	// disabledFeatures lists the features which are not converted, by
+	// provider. Set by the config file.
+	disabledFeatures map[i2gw.ProviderName][]string
+
+	// bestEffort indicates whether the resources which can not be converted
+	// are skipped, instead of failing the conversion. Value assigned via
+	// --best-effort flag.
+	bestEffort bool
+
+	// notificationAggr collects the notifications of the conversion run by
+	// convert.
+	notificationAggr *notifications.NotificationAggregator
+
+	// skippedErr holds the errors of the resources skipped by the conversion
+	// run by convert with --best-effort.
+	skippedErr *i2gw.ConversionError
+
+	// clusterClient, if set, is the client the resources are read with
+	// instead of a client of the current kubeconfig context.
+	clusterClient client.Client
 }
 
 // PrintGatewayAPIObjects performs necessary steps to digest and print
@@ -82,141 +161,213 @@ func (pr *PrintRunner) PrintGatewayAPIObjects(cmd *cobra.Command, _ []string) er
 	if err != nil {
 		return fmt.Errorf("failed to initialize resrouce printer: %w", err)
 	}
-	err = pr.initializeNamespaceFilter()
-	if err != nil {
-		return fmt.Errorf("failed to initialize namespace filter: %w", err)
-	}
-
-	gatewayResources, notificationTablesMap, err := i2gw.ToGatewayAPIResources(cmd.Context(), pr.namespaceFilter, pr.inputFile, pr.providers, pr.getProviderSpecificFlags())
+	gatewayResources, err := pr.convert(cmd)
 	if err != nil {
 		return err
 	}
 
-	for _, table := range notificationTablesMap {
-		fmt.Println(table)
+	if pr.outputDir != "" {
+		if err := pr.outputResultToDir(gatewayResources); err != nil {
+			return err
+		}
+	} else {
+		pr.outputResult(gatewayResources)
 	}
 
-	pr.outputResult(gatewayResources)
-
-	return nil
+	return pr.checkNotifications(cmd)
 }
 
-func (pr *PrintRunner) outputResult(gatewayResources []i2gw.GatewayResources) {
-	resourceCount := 0
-
-	for _, r := range gatewayResources {
-		resourceCount += len(r.GatewayClasses)
-		for _, gatewayClass := range r.GatewayClasses {
-			gatewayClass := gatewayClass
-			err := pr.resourcePrinter.PrintObj(&gatewayClass, os.Stdout)
-			if err != nil {
-				fmt.Printf("# Error printing %s GatewayClass: %v\n", gatewayClass.Name, err)
-			}
+// checkNotifications returns an exitError if resources were skipped by the
+// conversion, or if notifications at or above the --fail-on severity were
+// raised during the conversion.
+func (pr *PrintRunner) checkNotifications(cmd *cobra.Command) error {
+	if pr.skippedErr != nil {
+		// The resources which could be converted were output, the usage is
+		// not relevant.
+		cmd.SilenceUsage = true
+		return &exitError{
+			code: exitCodeSkipped,
+			err:  fmt.Errorf("skipped the resources which could not be converted:%w", pr.skippedErr),
 		}
 	}
+	if pr.failOn == "" {
+		return nil
+	}
+	failOn := notifications.MessageType(strings.ToUpper(pr.failOn))
 
-	for _, r := range gatewayResources {
-		resourceCount += len(r.Gateways)
-		for _, gateway := range r.Gateways {
-			gateway := gateway
-			if gateway.Annotations == nil {
-				gateway.Annotations = make(map[string]string)
-			}
-			gateway.Annotations[i2gw.GeneratorAnnotationKey] = fmt.Sprintf("ingress2gateway-%s", i2gw.CurrentVersion)
-			err := pr.resourcePrinter.PrintObj(&gateway, os.Stdout)
-			if err != nil {
-				fmt.Printf("# Error printing %s Gateway: %v\n", gateway.Name, err)
+	var count int
+	for _, msgs := range pr.notificationAggr.GetNotifications() {
+		for _, n := range msgs {
+			if n.Type.AtLeast(failOn) {
+				count++
 			}
 		}
 	}
+	if count == 0 {
+		return nil
+	}
 
-	for _, r := range gatewayResources {
-		resourceCount += len(r.HTTPRoutes)
-		for _, httpRoute := range r.HTTPRoutes {
-			httpRoute := httpRoute
-			if httpRoute.Annotations == nil {
-				httpRoute.Annotations = make(map[string]string)
-			}
-			httpRoute.Annotations[i2gw.GeneratorAnnotationKey] = fmt.Sprintf("ingress2gateway-%s", i2gw.CurrentVersion)
-			err := pr.resourcePrinter.PrintObj(&httpRoute, os.Stdout)
-			if err != nil {
-				fmt.Printf("# Error printing %s HTTPRoute: %v\n", httpRoute.Name, err)
-			}
+	// The notifications were already reported, the usage is not relevant.
+	cmd.SilenceUsage = true
+	return &exitError{
+		code: exitCodeNotified,
+		err:  fmt.Errorf("the conversion raised %d notifications of severity %s or higher", count, failOn),
+	}
+}
+
+// convert reads the resources selected by the input flags, converts them, and
+// prints the notifications raised during the conversion.
+func (pr *PrintRunner) convert(cmd *cobra.Command) ([]i2gw.GatewayResources, error) {
+	err := pr.initializeNamespaceFilter()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize namespace filter: %w", err)
+	}
+
+	inputFiles, inMemoryFiles, err := pr.renderInputs(cmd.InOrStdin())
+	if err != nil {
+		return nil, err
+	}
+
+	pr.notificationAggr = notifications.NewNotificationAggregator()
+	opts := pr.conversionOptions()
+	opts.Notifications = pr.notificationAggr
+	opts.InputFiles = inMemoryFiles
+	gatewayResources, notificationTablesMap, err := i2gw.ToGatewayAPIResourcesWithOptions(cmd.Context(), pr.namespaceFilter, inputFiles, pr.providers, pr.getProviderSpecificFlags(), opts)
+	if pr.reportFile != "" {
+		if reportErr := pr.writeReport(err); reportErr != nil {
+			return nil, reportErr
+		}
+	}
+	pr.skippedErr = nil
+	if err != nil {
+		// With --best-effort, the resources which could be converted are
+		// output, and the errors reported by checkNotifications.
+		if !pr.bestEffort || !errors.As(err, &pr.skippedErr) {
+			return nil, err
 		}
 	}
 
-	for _, r := range gatewayResources {
-		resourceCount += len(r.TLSRoutes)
-		for _, tlsRoute := range r.TLSRoutes {
-			tlsRoute := tlsRoute
-			if tlsRoute.Annotations == nil {
-				tlsRoute.Annotations = make(map[string]string)
-			}
-			tlsRoute.Annotations[i2gw.GeneratorAnnotationKey] = fmt.Sprintf("ingress2gateway-%s", i2gw.CurrentVersion)
-			err := pr.resourcePrinter.PrintObj(&tlsRoute, os.Stdout)
-			if err != nil {
-				fmt.Printf("# Error printing %s TLSRoute: %v\n", tlsRoute.Name, err)
-			}
+	if pr.reportFile == "" {
+		providers := make([]string, 0, len(notificationTablesMap))
+		for provider := range notificationTablesMap {
+			providers = append(providers, provider)
+		}
+		slices.Sort(providers)
+		for _, provider := range providers {
+			fmt.Fprintln(cmd.ErrOrStderr(), notificationTablesMap[provider])
 		}
 	}
 
-	for _, r := range gatewayResources {
-		resourceCount += len(r.TCPRoutes)
-		for _, tcpRoute := range r.TCPRoutes {
-			tcpRoute := tcpRoute
-			if tcpRoute.Annotations == nil {
-				tcpRoute.Annotations = make(map[string]string)
-			}
-			tcpRoute.Annotations[i2gw.GeneratorAnnotationKey] = fmt.Sprintf("ingress2gateway-%s", i2gw.CurrentVersion)
-			err := pr.resourcePrinter.PrintObj(&tcpRoute, os.Stdout)
-			if err != nil {
-				fmt.Printf("# Error printing %s TCPRoute: %v\n", tcpRoute.Name, err)
-			}
+	if pr.provenanceAnnotations {
+		if err := i2gw.AddProvenanceAnnotations(gatewayResources); err != nil {
+			return nil, fmt.Errorf("failed to add provenance annotations: %w", err)
+		}
+	}
+	if pr.provenanceFile != "" {
+		if err := writeProvenance(pr.provenanceFile, i2gw.Provenance(gatewayResources)); err != nil {
+			return nil, err
 		}
 	}
+	return gatewayResources, nil
+}
 
-	for _, r := range gatewayResources {
-		resourceCount += len(r.UDPRoutes)
-		for _, udpRoute := range r.UDPRoutes {
-			udpRoute := udpRoute
-			if udpRoute.Annotations == nil {
-				udpRoute.Annotations = make(map[string]string)
-			}
-			udpRoute.Annotations[i2gw.GeneratorAnnotationKey] = fmt.Sprintf("ingress2gateway-%s", i2gw.CurrentVersion)
-			err := pr.resourcePrinter.PrintObj(&udpRoute, os.Stdout)
-			if err != nil {
-				fmt.Printf("# Error printing %s UDPRoute: %v\n", udpRoute.Name, err)
-			}
+// writeReport writes the notifications and the errors of the conversion
+// which returned conversionErr to the report file.
+func (pr *PrintRunner) writeReport(conversionErr error) error {
+	errsByProvider := make(map[string]field.ErrorList)
+	var convErr *i2gw.ConversionError
+	if errors.As(conversionErr, &convErr) {
+		for provider, errs := range convErr.Errors {
+			errsByProvider[string(provider)] = errs
 		}
 	}
+	report := notifications.NewReport(pr.notificationAggr.GetNotifications(), errsByProvider)
 
-	for _, r := range gatewayResources {
-		resourceCount += len(r.ReferenceGrants)
-		for _, referenceGrant := range r.ReferenceGrants {
-			referenceGrant := referenceGrant
-			if referenceGrant.Annotations == nil {
-				referenceGrant.Annotations = make(map[string]string)
-			}
-			referenceGrant.Annotations[i2gw.GeneratorAnnotationKey] = fmt.Sprintf("ingress2gateway-%s", i2gw.CurrentVersion)
-			err := pr.resourcePrinter.PrintObj(&referenceGrant, os.Stdout)
-			if err != nil {
-				fmt.Printf("# Error printing %s ReferenceGrant: %v\n", referenceGrant.Name, err)
-			}
+	f, err := os.Create(pr.reportFile)
+	if err != nil {
+		return fmt.Errorf("failed to create report file: %w", err)
+	}
+	defer f.Close()
+	if err := report.Write(f, notifications.ReportFormat(pr.reportFormat), i2gw.CurrentVersion); err != nil {
+		return fmt.Errorf("failed to write report to %s: %w", pr.reportFile, err)
+	}
+	return f.Close()
+}
+
+// renderInputs renders the kustomization and the Helm chart to convert, if
+// any, and returns them along with the input files. The files read in memory,
+// such as stdin and the rendered manifests, are returned by name.
+func (pr *PrintRunner) renderInputs(stdin io.Reader) ([]string, map[string][]byte, error) {
+	inputFiles := slices.Clone(pr.inputFiles)
+	inMemoryFiles := make(map[string][]byte)
+	if err := i2gw.ReadStdinInputFile(inputFiles, stdin, inMemoryFiles); err != nil {
+		return nil, nil, err
+	}
+	if pr.inputKustomize != "" {
+		rendered, err := i2gw.RenderKustomization(pr.inputKustomize)
+		if err != nil {
+			return nil, nil, err
+		}
+		inputFiles = append(inputFiles, i2gw.InputFileNames(rendered)...)
+		maps.Copy(inMemoryFiles, rendered)
+	}
+	if pr.inputHelmChart != "" {
+		rendered, err := i2gw.RenderHelmChart(pr.inputHelmChart, pr.helmValuesFiles, pr.namespaceFilter)
+		if err != nil {
+			return nil, nil, err
 		}
+		inputFiles = append(inputFiles, i2gw.InputFileNames(rendered)...)
+		maps.Copy(inMemoryFiles, rendered)
 	}
+	return inputFiles, inMemoryFiles, nil
+}
 
-	for _, r := range gatewayResources {
-		resourceCount += len(r.GatewayExtensions)
-		for _, gatewayExtension := range r.GatewayExtensions {
-			gatewayExtension := gatewayExtension
+// readsFromFiles returns whether the resources are read from input files
+// instead of the cluster.
+func (pr *PrintRunner) readsFromFiles() bool {
+	return len(pr.inputFiles) > 0 || pr.inputKustomize != "" || pr.inputHelmChart != ""
+}
+
+func (pr *PrintRunner) outputResultToDir(gatewayResources []i2gw.GatewayResources) error {
+	writer := outputDirWriter{
+		dir:          pr.outputDir,
+		layout:       pr.outputDirLayout,
+		outputFormat: pr.outputFormat,
+	}
+	resourceCount, err := writer.write(gatewayResources)
+	if err != nil {
+		return fmt.Errorf("failed to write resources to %s: %w", pr.outputDir, err)
+	}
+
+	if resourceCount == 0 {
+		msg := "No resources found"
+		if pr.namespaceFilter != "" {
+			msg = fmt.Sprintf("%s in %s namespace", msg, pr.namespaceFilter)
+		}
+		fmt.Println(msg)
+	}
+	return nil
+}
+
+// outputResult prints the objects in the order of i2gw.ToObjects, so that the
+// output of identical inputs is identical.
+func (pr *PrintRunner) outputResult(gatewayResources []i2gw.GatewayResources) {
+	objects := i2gw.ToObjects(gatewayResources)
+
+	for _, obj := range objects {
+		if gatewayExtension, ok := obj.(*unstructured.Unstructured); ok {
 			fmt.Println("---")
-			if err := PrintUnstructuredAsYaml(&gatewayExtension); err != nil {
+			if err := PrintUnstructuredAsYaml(gatewayExtension); err != nil {
 				fmt.Printf("# Error printing %s gatewayExtension: %v\n", gatewayExtension.GetName(), err)
 			}
+			continue
+		}
+		if err := pr.resourcePrinter.PrintObj(obj, os.Stdout); err != nil {
+			fmt.Printf("# Error printing %s %s: %v\n", obj.GetName(), obj.GetObjectKind().GroupVersionKind().Kind, err)
 		}
 	}
 
-	if resourceCount == 0 {
+	if len(objects) == 0 {
 		msg := "No resources found"
 		if pr.namespaceFilter != "" {
 			msg = fmt.Sprintf("%s in %s namespace", msg, pr.namespaceFilter)
@@ -256,7 +407,7 @@ func (pr *PrintRunner) initializeNamespaceFilter() error {
 	// If namespace flag is not specified, try to use the default namespace from the cluster
 	if pr.namespace == "" {
 		ns, err := getNamespaceInCurrentContext()
-		if err != nil && pr.inputFile == "" {
+		if err != nil && !pr.readsFromFiles() {
 			// When asked to read from the cluster, but getting the current namespace
 			// failed for whatever reason - do not process the request.
 			return err
@@ -282,10 +433,18 @@ func newPrintCommand() *cobra.Command {
 		Use:   "print",
 		Short: "Prints Gateway API objects generated from ingress and provider-specific resources.",
 		RunE:  pr.PrintGatewayAPIObjects,
-		PreRunE: func(_ *cobra.Command, _ []string) error {
-			openAPIExist := slices.Contains(pr.providers, "openapi3")
-			if openAPIExist && len(pr.providers) != 1 {
-				return fmt.Errorf("openapi3 must be the only provider when specified")
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			if err := pr.loadConfig(cmd); err != nil {
+				return err
+			}
+			if err := pr.validateInputFlags(); err != nil {
+				return err
+			}
+			if err := pr.validateReportFlags(); err != nil {
+				return err
+			}
+			if !slices.Contains(outputDirLayouts, pr.outputDirLayout) {
+				return fmt.Errorf("%s is not a supported output directory layout, supported values are %v", pr.outputDirLayout, outputDirLayouts)
 			}
 			return nil
 		},
@@ -294,8 +453,32 @@ func newPrintCommand() *cobra.Command {
 	cmd.Flags().StringVarP(&pr.outputFormat, "output", "o", "yaml",
 		fmt.Sprintf(`Output format. One of: (%s).`, strings.Join(allowedFormats, ", ")))
 
-	cmd.Flags().StringVar(&pr.inputFile, "input-file", "",
-		`Path to the manifest file. When set, the tool will read ingresses from the file instead of reading from the cluster. Supported files are yaml and json.`)
+	cmd.Flags().StringVar(&pr.outputDir, "output-dir", "",
+		`If present, the resources are written to this directory, one file per object, instead of stdout. A kustomization.yaml indexing the files is written to every directory.`)
+
+	cmd.Flags().StringVar(&pr.outputDirLayout, "output-dir-layout", outputDirLayoutNamespace,
+		fmt.Sprintf(`Layout of the files written to --output-dir. One of: (%s).`, strings.Join(outputDirLayouts, ", ")))
+
+	pr.addInputFlags(cmd)
+	pr.addReportFlags(cmd)
+	pr.addProvenanceFlags(cmd)
+	return cmd
+}
+
+// addInputFlags adds the flags selecting the resources to convert, which are
+// shared by all the commands reading resources.
+func (pr *PrintRunner) addInputFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVar(&pr.inputFiles, "input-file", nil,
+		`Path to a manifest file, a directory or a glob pattern, or - to read from stdin. Can be repeated. When set, the tool will read ingresses from the files instead of reading from the cluster. Directories are read recursively. Supported files are yaml and json.`)
+
+	cmd.Flags().StringVar(&pr.inputKustomize, "input-kustomize", "",
+		`Path to a kustomization directory. When set, the tool will build the kustomization and read ingresses from the built manifests instead of reading from the cluster. Remote bases are not supported.`)
+
+	cmd.Flags().StringVar(&pr.inputHelmChart, "input-helm-chart", "",
+		`Path to a Helm chart directory or archive. When set, the tool will render the chart templates and read ingresses from the rendered manifests instead of reading from the cluster. The chart is rendered as a release named after the chart, in the namespace of the request.`)
+
+	cmd.Flags().StringArrayVar(&pr.helmValuesFiles, "values", nil,
+		`Path to a values file used to render --input-helm-chart. Can be repeated, later files take precedence.`)
 
 	cmd.Flags().StringVarP(&pr.namespace, "namespace", "n", "",
 		`If present, the namespace scope for this CLI request.`)
@@ -315,9 +498,79 @@ if specified with --namespace.`)
 		}
 	}
 
-	_ = cmd.MarkFlagRequired("providers")
+	cmd.Flags().StringVar(&pr.configFile, "config", "",
+		fmt.Sprintf(`Path to a configuration file of kind %s and apiVersion %s. The flags set on the command line take precedence over the file.`, i2gw.ConfigKind, i2gw.ConfigAPIVersion))
+
+	cmd.Flags().BoolVar(&pr.bestEffort, "best-effort", false,
+		fmt.Sprintf(`If true, the Ingresses, rules and paths which can not be converted are skipped, instead of failing the conversion. The other resources are output, and the command exits with code %d after reporting the errors.`, exitCodeSkipped))
+
+	cmd.Flags().StringVar(&pr.sharedGatewayNamespace, "shared-gateway-namespace", "",
+		`If present, a single Gateway is generated per ingress class in this namespace, instead of one per namespace. Its listeners only allow the routes of the namespaces they were generated from, and ReferenceGrants are generated for the TLS Secrets.`)
+
 	cmd.MarkFlagsMutuallyExclusive("namespace", "all-namespaces")
-	return cmd
+}
+
+// addReportFlags adds the flags determining how the conversion is reported,
+// which are shared by all the commands running a conversion.
+func (pr *PrintRunner) addReportFlags(cmd *cobra.Command) {
+	reportFormats := make([]string, 0, len(notifications.ReportFormats))
+	for _, format := range notifications.ReportFormats {
+		reportFormats = append(reportFormats, string(format))
+	}
+	cmd.Flags().StringVar(&pr.reportFile, "report-file", "",
+		`If present, the notifications and conversion errors are written to this file instead of printing the notification tables to stderr.`)
+
+	cmd.Flags().StringVar(&pr.reportFormat, "report-format", string(notifications.JSONReport),
+		fmt.Sprintf(`Format of --report-file. One of: (%s).`, strings.Join(reportFormats, ", ")))
+
+	failOnValues := make([]string, 0, len(notifications.MessageTypes))
+	for _, mType := range notifications.MessageTypes {
+		failOnValues = append(failOnValues, strings.ToLower(string(mType)))
+	}
+	cmd.Flags().StringVar(&pr.failOn, "fail-on", "",
+		fmt.Sprintf(`If present, exit with code %d when notifications of this severity or higher are raised, even though the resources were converted. One of: (%s).`, exitCodeNotified, strings.Join(failOnValues, ", ")))
+}
+
+// addProvenanceFlags adds the flags recording the objects the generated
+// objects were converted from.
+func (pr *PrintRunner) addProvenanceFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&pr.provenanceAnnotations, "provenance-annotations", false,
+		fmt.Sprintf(`If present, the generated objects are annotated with the objects they were converted from (%s), and routes with the Ingress rule and path of each of their rules (%s).`, i2gw.SourcesAnnotationKey, i2gw.RuleSourcesAnnotationKey))
+
+	cmd.Flags().StringVar(&pr.provenanceFile, "provenance-file", "",
+		`If present, the objects and the Ingress rules and paths every generated object was converted from are written to this file, as JSON if it has a .json extension and as YAML otherwise.`)
+}
+
+// validateInputFlags checks that the requested providers and inputs can be
+// used together.
+func (pr *PrintRunner) validateInputFlags() error {
+	if len(pr.providers) == 0 {
+		return fmt.Errorf("no providers specified, set --providers or the providers of the --config file")
+	}
+	openAPIExist := slices.Contains(pr.providers, "openapi3")
+	if openAPIExist && len(pr.providers) != 1 {
+		return fmt.Errorf("openapi3 must be the only provider when specified")
+	}
+	if len(pr.helmValuesFiles) > 0 && pr.inputHelmChart == "" {
+		return fmt.Errorf("--values can only be used with --input-helm-chart")
+	}
+	if pr.sharedGatewayNamespace != "" {
+		if msgs := validation.IsDNS1123Label(pr.sharedGatewayNamespace); len(msgs) > 0 {
+			return fmt.Errorf("invalid --shared-gateway-namespace %s: %s", pr.sharedGatewayNamespace, strings.Join(msgs, ", "))
+		}
+	}
+	return nil
+}
+
+// validateReportFlags checks the values of the flags added by addReportFlags.
+func (pr *PrintRunner) validateReportFlags() error {
+	if pr.failOn != "" && !slices.Contains(notifications.MessageTypes, notifications.MessageType(strings.ToUpper(pr.failOn))) {
+		return fmt.Errorf("%s is not a supported severity, supported values are error, warning and info", pr.failOn)
+	}
+	if !slices.Contains(notifications.ReportFormats, notifications.ReportFormat(pr.reportFormat)) {
+		return fmt.Errorf("%s is not a supported report format, supported values are %v", pr.reportFormat, notifications.ReportFormats)
+	}
+	return nil
 }
 
 // getNamespaceInCurrentContext returns the namespace in the current active context of the user.
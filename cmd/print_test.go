@@ -17,6 +17,7 @@ limitations under the License.
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -25,6 +26,9 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
+	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/printers"
 )
 
@@ -294,3 +298,62 @@ func Test_getProviderSpecificFlags(t *testing.T) {
 		})
 	}
 }
+
+func Test_checkNotifications(t *testing.T) {
+	raised := map[string][]notifications.Notification{
+		"provider": {
+			{Type: notifications.InfoNotification, Message: "info"},
+			{Type: notifications.WarningNotification, Message: "warning"},
+		},
+	}
+
+	testCases := []struct {
+		name         string
+		failOn       string
+		skippedErr   *i2gw.ConversionError
+		expectedCode int
+	}{
+		{
+			name:   "no fail-on",
+			failOn: "",
+		},
+		{
+			name:   "no notification of fail-on severity",
+			failOn: "error",
+		},
+		{
+			name:         "notifications of fail-on severity",
+			failOn:       "warning",
+			expectedCode: exitCodeNotified,
+		},
+		{
+			name:         "notifications more severe than fail-on",
+			failOn:       "info",
+			expectedCode: exitCodeNotified,
+		},
+		{
+			name:         "skipped resources",
+			failOn:       "warning",
+			skippedErr:   &i2gw.ConversionError{},
+			expectedCode: exitCodeSkipped,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := PrintRunner{failOn: tc.failOn, skippedErr: tc.skippedErr, notificationAggr: &notifications.NotificationAggregator{Notifications: raised}}
+			err := pr.checkNotifications(&cobra.Command{})
+
+			var code int
+			var exitErr *exitError
+			if errors.As(err, &exitErr) {
+				code = exitErr.code
+			} else if err != nil {
+				t.Fatalf("Expected an exitError but got %v", err)
+			}
+			if code != tc.expectedCode {
+				t.Errorf("Expected exit code %d but got %d", tc.expectedCode, code)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	"sigs.k8s.io/yaml"
+)
+
+// writeProvenance writes the provenance of the generated objects to
+// filename, as JSON if it has a .json extension and as YAML otherwise.
+func writeProvenance(filename string, provenance []i2gw.ObjectProvenance) error {
+	if provenance == nil {
+		provenance = []i2gw.ObjectProvenance{}
+	}
+	var data []byte
+	var err error
+	if strings.EqualFold(filepath.Ext(filename), ".json") {
+		data, err = json.MarshalIndent(provenance, "", "  ")
+	} else {
+		data, err = yaml.Marshal(provenance)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode provenance: %w", err)
+	}
+	if err := os.WriteFile(filename, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write provenance to %s: %w", filename, err)
+	}
+	return nil
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package cmd
 
 import (
+	"errors"
 	"os"
 
 	"github.com/spf13/cobra"
@@ -48,8 +49,45 @@ func getKubeconfig() {
 func Execute() {
 	rootCmd := newRootCmd()
 	rootCmd.AddCommand(newPrintCommand())
+	rootCmd.AddCommand(newApplyCommand())
+	rootCmd.AddCommand(newDiffCommand())
+	rootCmd.AddCommand(newAuditCommand())
+	rootCmd.AddCommand(newExplainCommand())
+	rootCmd.AddCommand(newSyncCommand())
+	rootCmd.AddCommand(newServeCommand())
 	err := rootCmd.Execute()
+	var exitErr *exitError
+	if errors.As(err, &exitErr) {
+		os.Exit(exitErr.code)
+	}
 	if err != nil {
-		os.Exit(1)
+		os.Exit(exitCodeFailed)
 	}
 }
+
+const (
+	// exitCodeFailed is returned when the command failed.
+	exitCodeFailed = 1
+	// exitCodeNotified is returned when the resources were converted, but
+	// notifications at or above the --fail-on severity were raised.
+	exitCodeNotified = 2
+	// exitCodeSkipped is returned when the resources were converted with
+	// --best-effort, but some of them could not be converted and were
+	// skipped.
+	exitCodeSkipped = 3
+)
+
+// exitError is returned by commands which completed, but must exit with a
+// specific code.
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error {
+	return e.err
+}
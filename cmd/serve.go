@@ -0,0 +1,84 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	"github.com/spf13/cobra"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
+)
+
+// shutdownTimeout is how long the in-flight conversions are given to finish
+// when the server is stopped.
+const shutdownTimeout = 30 * time.Second
+
+type ServeRunner struct {
+	// address is the address the HTTP server listens on.
+	address string
+}
+
+// Serve runs an HTTP server converting the manifests POSTed to
+// i2gw.ConvertPath, until the command is interrupted.
+func (sr *ServeRunner) Serve(_ *cobra.Command, _ []string) error {
+	server := &http.Server{
+		Addr:              sr.address,
+		Handler:           i2gw.NewConversionHandler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx := signals.SetupSignalHandler()
+	serveErr := make(chan error, 1)
+	go func() {
+		klog.Infof("Serving conversions on %s%s", sr.address, i2gw.ConvertPath)
+		serveErr <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		return fmt.Errorf("failed to serve: %w", err)
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to shut down the server: %w", err)
+	}
+	return nil
+}
+
+func newServeCommand() *cobra.Command {
+	sr := &ServeRunner{}
+
+	// serveCmd represents the serve command. It exposes the conversion as an
+	// HTTP API.
+	var cmd = &cobra.Command{
+		Use:   "serve",
+		Short: "Serves the conversion of ingress and provider-specific manifests to Gateway API objects over HTTP.",
+		RunE:  sr.Serve,
+	}
+
+	cmd.Flags().StringVar(&sr.address, "address", ":8080",
+		`The address the HTTP server listens on.`)
+	return cmd
+}
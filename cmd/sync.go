@@ -0,0 +1,154 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
+	"github.com/spf13/cobra"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+)
+
+type SyncRunner struct {
+	// ApplyRunner holds the flags selecting the resources to convert and how
+	// the generated objects are applied.
+	ApplyRunner
+}
+
+// SyncGatewayAPIObjects runs a controller watching the ingress and
+// provider-specific resources, which converts them and applies the generated
+// Gateway API objects on every change, until the command is interrupted.
+func (sr *SyncRunner) SyncGatewayAPIObjects(cmd *cobra.Command, _ []string) error {
+	if err := sr.initializeNamespaceFilter(); err != nil {
+		return fmt.Errorf("failed to initialize namespace filter: %w", err)
+	}
+	log.SetLogger(klog.NewKlogr())
+
+	restConfig, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get client config: %w", err)
+	}
+	cacheOpts := cache.Options{}
+	if sr.namespaceFilter != "" {
+		cacheOpts.DefaultNamespaces = map[string]cache.Config{sr.namespaceFilter: {}}
+	}
+	mgr, err := manager.New(restConfig, manager.Options{
+		Cache:   cacheOpts,
+		Metrics: metricsserver.Options{BindAddress: "0"},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create manager: %w", err)
+	}
+
+	// The notifications are logged, as there is no output to print them
+	// along with.
+	conversionOptions := sr.conversionOptions()
+	conversionOptions.NotificationConsumers = []notifications.Consumer{notifications.KlogConsumer{}}
+	reconciler := &i2gw.SyncReconciler{
+		Client:                mgr.GetClient(),
+		Namespace:             sr.namespaceFilter,
+		Providers:             sr.providers,
+		ProviderSpecificFlags: sr.getProviderSpecificFlags(),
+		ConversionOptions:     conversionOptions,
+		ApplyOptions: i2gw.ApplyOptions{
+			DryRun:         i2gw.DryRunStrategy(sr.dryRun),
+			ForceConflicts: sr.forceConflicts,
+		},
+		ProvenanceAnnotations: sr.provenanceAnnotations,
+		OnSync: func(results []i2gw.ApplyResult) {
+			// Only the objects which changed are printed, as every sync
+			// applies all the generated objects.
+			var changed []i2gw.ApplyResult
+			for _, result := range results {
+				if result.Type != i2gw.ApplyResultUnchanged {
+					changed = append(changed, result)
+				}
+			}
+			if len(changed) > 0 {
+				// The failures are retried by the reconciler.
+				_ = sr.outputResults(cmd.OutOrStdout(), changed)
+			}
+		},
+	}
+	if err := reconciler.SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("failed to set up the sync controller: %w", err)
+	}
+	return mgr.Start(signals.SetupSignalHandler())
+}
+
+// validateSyncFlags checks that the resources are read from the cluster,
+// which is the only input sync can watch.
+func (sr *SyncRunner) validateSyncFlags() error {
+	if sr.readsFromFiles() {
+		return fmt.Errorf("sync reads the resources from the cluster, --input-file, --input-kustomize and --input-helm-chart are not supported")
+	}
+	if sr.provenanceFile != "" {
+		return fmt.Errorf("--provenance-file is not supported by sync, use --provenance-annotations")
+	}
+	return nil
+}
+
+func newSyncCommand() *cobra.Command {
+	sr := &SyncRunner{}
+	dryRunStrategies := make([]string, 0, len(i2gw.DryRunStrategies))
+	for _, strategy := range i2gw.DryRunStrategies {
+		dryRunStrategies = append(dryRunStrategies, string(strategy))
+	}
+
+	// syncCmd represents the sync command. It keeps the Gateway API objects
+	// generated from Ingress resources in sync with them.
+	var cmd = &cobra.Command{
+		Use:   "sync",
+		Short: "Continuously applies Gateway API objects generated from ingress and provider-specific resources to the cluster.",
+		RunE:  sr.SyncGatewayAPIObjects,
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			if err := sr.loadConfig(cmd); err != nil {
+				return err
+			}
+			if err := sr.validateInputFlags(); err != nil {
+				return err
+			}
+			if err := sr.validateSyncFlags(); err != nil {
+				return err
+			}
+			if !slices.Contains(dryRunStrategies, sr.dryRun) {
+				return fmt.Errorf("%s is not a supported dry run strategy, supported values are %v", sr.dryRun, dryRunStrategies)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&sr.dryRun, "dry-run", string(i2gw.DryRunNone),
+		fmt.Sprintf(`Must be one of: (%s). If client, only compute the results without sending the objects. If server, submit server-side apply requests without persisting the objects.`, strings.Join(dryRunStrategies, ", ")))
+
+	cmd.Flags().BoolVar(&sr.forceConflicts, "force-conflicts", false,
+		fmt.Sprintf(`If true, take ownership of the fields managed by other field managers instead of reporting a conflict. Objects are applied with the %q field manager.`, i2gw.FieldManager))
+
+	sr.addInputFlags(cmd)
+	sr.addProvenanceFlags(cmd)
+	return cmd
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package i2gw
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// FieldManager is the field manager owning the fields of the objects applied
+// by ingress2gateway.
+const FieldManager = "ingress2gateway"
+
+// UnmanagedAnnotationKey opts a live object out of the objects applied by
+// ingress2gateway when set to "true", so that it can be owned manually.
+const UnmanagedAnnotationKey = "ingress2gateway.kubernetes.io/unmanaged"
+
+// DryRunStrategy determines whether the objects are persisted when applied.
+type DryRunStrategy string
+
+const (
+	// DryRunNone persists the applied objects.
+	DryRunNone DryRunStrategy = "none"
+	// DryRunClient computes the results locally, without sending the objects
+	// to the cluster. Field ownership conflicts cannot be detected.
+	DryRunClient DryRunStrategy = "client"
+	// DryRunServer sends the objects to the cluster, which runs the apply
+	// without persisting the result.
+	DryRunServer DryRunStrategy = "server"
+)
+
+// DryRunStrategies lists the supported DryRunStrategy values.
+var DryRunStrategies = []DryRunStrategy{DryRunNone, DryRunClient, DryRunServer}
+
+// ApplyResultType describes what applying an object did, or would do.
+type ApplyResultType string
+
+const (
+	ApplyResultCreated    ApplyResultType = "created"
+	ApplyResultConfigured ApplyResultType = "configured"
+	ApplyResultUnchanged  ApplyResultType = "unchanged"
+	// ApplyResultSkipped is reported when the live object is annotated with
+	// UnmanagedAnnotationKey.
+	ApplyResultSkipped ApplyResultType = "skipped"
+	// ApplyResultConflict is reported when another field manager owns fields
+	// ingress2gateway tries to set, and ownership was not forced.
+	ApplyResultConflict ApplyResultType = "conflict"
+	ApplyResultFailed   ApplyResultType = "failed"
+)
+
+// ApplyResult is the outcome of applying a single object.
+type ApplyResult struct {
+	Object client.Object
+	Type   ApplyResultType
+	// Err is set when Type is ApplyResultConflict or ApplyResultFailed.
+	Err error
+}
+
+// ApplyOptions configures ApplyGatewayResources.
+type ApplyOptions struct {
+	DryRun DryRunStrategy
+	// ForceConflicts takes ownership of fields owned by other field managers
+	// instead of reporting a conflict.
+	ForceConflicts bool
+}
+
+// ApplyGatewayResources applies every generated object with server-side apply
+// under the FieldManager field manager, and returns one result per object.
+// Failing to apply an object does not prevent the others from being applied.
+// The objects annotated with UnmanagedAnnotationKey in the cluster are skipped.
+func ApplyGatewayResources(ctx context.Context, cl client.Client, gatewayResources []GatewayResources, opts ApplyOptions) []ApplyResult {
+	var results []ApplyResult
+	for _, obj := range ToObjects(gatewayResources) {
+		results = append(results, applyObject(ctx, cl, obj, opts))
+	}
+	return results
+}
+
+func applyObject(ctx context.Context, cl client.Client, obj client.Object, opts ApplyOptions) ApplyResult {
+	desired, err := toApplyConfiguration(obj)
+	if err != nil {
+		return ApplyResult{Object: obj, Type: ApplyResultFailed, Err: err}
+	}
+
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(desired.GroupVersionKind())
+	err = cl.Get(ctx, client.ObjectKeyFromObject(desired), live)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return ApplyResult{Object: obj, Type: ApplyResultFailed, Err: fmt.Errorf("failed to get live object: %w", err)}
+	}
+	exists := err == nil
+	if exists && live.GetAnnotations()[UnmanagedAnnotationKey] == "true" {
+		return ApplyResult{Object: obj, Type: ApplyResultSkipped}
+	}
+
+	if opts.DryRun == DryRunClient {
+		switch {
+		case !exists:
+			return ApplyResult{Object: obj, Type: ApplyResultCreated}
+		case isApplied(desired, live):
+			return ApplyResult{Object: obj, Type: ApplyResultUnchanged}
+		default:
+			return ApplyResult{Object: obj, Type: ApplyResultConfigured}
+		}
+	}
+
+	patchOpts := []client.PatchOption{client.FieldOwner(FieldManager)}
+	if opts.ForceConflicts {
+		patchOpts = append(patchOpts, client.ForceOwnership)
+	}
+	if opts.DryRun == DryRunServer {
+		patchOpts = append(patchOpts, client.DryRunAll)
+	}
+	applied := desired.DeepCopy()
+	if err := cl.Patch(ctx, applied, client.Apply, patchOpts...); err != nil {
+		if apierrors.IsConflict(err) {
+			return ApplyResult{Object: obj, Type: ApplyResultConflict, Err: err}
+		}
+		return ApplyResult{Object: obj, Type: ApplyResultFailed, Err: fmt.Errorf("failed to apply: %w", err)}
+	}
+
+	switch {
+	case !exists:
+		return ApplyResult{Object: obj, Type: ApplyResultCreated}
+	case isApplied(applied, live):
+		return ApplyResult{Object: obj, Type: ApplyResultUnchanged}
+	default:
+		return ApplyResult{Object: obj, Type: ApplyResultConfigured}
+	}
+}
+
+// toApplyConfiguration converts obj into the unstructured object sent as an
+// apply patch. The status and fields set by the API server are dropped, so
+// that ingress2gateway does not claim ownership over them.
+func toApplyConfiguration(obj client.Object) (*unstructured.Unstructured, error) {
+	u, err := CastToUnstructured(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert object to unstructured: %w", err)
+	}
+	u.SetGroupVersionKind(obj.GetObjectKind().GroupVersionKind())
+	unstructured.RemoveNestedField(u.Object, "status")
+	unstructured.RemoveNestedField(u.Object, "metadata", "creationTimestamp")
+	unstructured.RemoveNestedField(u.Object, "metadata", "resourceVersion")
+	unstructured.RemoveNestedField(u.Object, "metadata", "managedFields")
+	return u, nil
+}
+
+// isApplied returns whether every field set in desired is set to the same
+// value in live.
+func isApplied(desired, live *unstructured.Unstructured) bool {
+	return len(diffObjects(desired, live)) == 0
+}
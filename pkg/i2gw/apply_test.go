@@ -0,0 +1,213 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package i2gw
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// applyPatchInterceptor emulates server-side apply, which the fake client does
+// not support, by creating or updating the object. Applying an object named
+// conflictingName fails with a conflict unless ownership is forced.
+func applyPatchInterceptor(conflictingName string) interceptor.Funcs {
+	return interceptor.Funcs{
+		Patch: func(ctx context.Context, cl client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			if patch.Type() != types.ApplyPatchType {
+				return cl.Patch(ctx, obj, patch, opts...)
+			}
+			patchOpts := &client.PatchOptions{}
+			patchOpts.ApplyOptions(opts)
+			if patchOpts.FieldManager != FieldManager {
+				return apierrors.NewBadRequest("unexpected field manager " + patchOpts.FieldManager)
+			}
+			if obj.GetName() == conflictingName && (patchOpts.Force == nil || !*patchOpts.Force) {
+				return apierrors.NewConflict(schema.GroupResource{Group: gatewayv1.GroupName, Resource: "httproutes"}, obj.GetName(), nil)
+			}
+			if slices.Contains(patchOpts.DryRun, metav1.DryRunAll) {
+				return nil
+			}
+			live := obj.DeepCopyObject().(client.Object)
+			if err := cl.Get(ctx, client.ObjectKeyFromObject(obj), live); err != nil {
+				if apierrors.IsNotFound(err) {
+					return cl.Create(ctx, obj)
+				}
+				return err
+			}
+			obj.SetResourceVersion(live.GetResourceVersion())
+			return cl.Update(ctx, obj)
+		},
+	}
+}
+
+func Test_ApplyGatewayResources(t *testing.T) {
+	pathPrefix := gatewayv1.PathMatchPathPrefix
+	newRoute := func(name, path string) gatewayv1.HTTPRoute {
+		route := gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Spec: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: []gatewayv1.ParentReference{{Name: "nginx"}},
+				},
+				Rules: []gatewayv1.HTTPRouteRule{{
+					Matches: []gatewayv1.HTTPRouteMatch{{
+						Path: &gatewayv1.HTTPPathMatch{Type: &pathPrefix, Value: &path},
+					}},
+				}},
+			},
+		}
+		route.SetGroupVersionKind(gatewayv1.SchemeGroupVersion.WithKind("HTTPRoute"))
+		return route
+	}
+	liveRoute := func(name, path string) *gatewayv1.HTTPRoute {
+		route := newRoute(name, path)
+		route.Annotations = map[string]string{GeneratorAnnotationKey: GeneratorAnnotationValue()}
+		return &route
+	}
+
+	gatewayResources := []GatewayResources{{
+		HTTPRoutes: map[types.NamespacedName]gatewayv1.HTTPRoute{
+			{Namespace: "default", Name: "new"}:       newRoute("new", "/"),
+			{Namespace: "default", Name: "unchanged"}: newRoute("unchanged", "/"),
+			{Namespace: "default", Name: "changed"}:   newRoute("changed", "/"),
+			{Namespace: "default", Name: "conflict"}:  newRoute("conflict", "/"),
+			{Namespace: "default", Name: "unmanaged"}: newRoute("unmanaged", "/"),
+		},
+	}}
+	unmanagedRoute := liveRoute("unmanaged", "/old")
+	unmanagedRoute.Annotations[UnmanagedAnnotationKey] = "true"
+	liveObjects := []client.Object{
+		liveRoute("unchanged", "/"),
+		liveRoute("changed", "/old"),
+		liveRoute("conflict", "/old"),
+		unmanagedRoute,
+	}
+
+	testCases := []struct {
+		name            string
+		opts            ApplyOptions
+		expectedResults map[string]ApplyResultType
+		expectedPaths   map[string]string
+	}{
+		{
+			name: "apply",
+			opts: ApplyOptions{DryRun: DryRunNone},
+			expectedResults: map[string]ApplyResultType{
+				"new":       ApplyResultCreated,
+				"unchanged": ApplyResultUnchanged,
+				"changed":   ApplyResultConfigured,
+				"conflict":  ApplyResultConflict,
+				"unmanaged": ApplyResultSkipped,
+			},
+			expectedPaths: map[string]string{"new": "/", "unchanged": "/", "changed": "/", "conflict": "/old", "unmanaged": "/old"},
+		},
+		{
+			name: "apply with forced conflicts",
+			opts: ApplyOptions{DryRun: DryRunNone, ForceConflicts: true},
+			expectedResults: map[string]ApplyResultType{
+				"new":       ApplyResultCreated,
+				"unchanged": ApplyResultUnchanged,
+				"changed":   ApplyResultConfigured,
+				"conflict":  ApplyResultConfigured,
+				"unmanaged": ApplyResultSkipped,
+			},
+			expectedPaths: map[string]string{"new": "/", "unchanged": "/", "changed": "/", "conflict": "/", "unmanaged": "/old"},
+		},
+		{
+			name: "client dry run",
+			opts: ApplyOptions{DryRun: DryRunClient},
+			expectedResults: map[string]ApplyResultType{
+				"new":       ApplyResultCreated,
+				"unchanged": ApplyResultUnchanged,
+				"changed":   ApplyResultConfigured,
+				"conflict":  ApplyResultConfigured,
+				"unmanaged": ApplyResultSkipped,
+			},
+			expectedPaths: map[string]string{"unchanged": "/", "changed": "/old", "conflict": "/old", "unmanaged": "/old"},
+		},
+		{
+			name: "server dry run",
+			opts: ApplyOptions{DryRun: DryRunServer},
+			expectedResults: map[string]ApplyResultType{
+				"new":       ApplyResultCreated,
+				"unchanged": ApplyResultUnchanged,
+				"changed":   ApplyResultConfigured,
+				"conflict":  ApplyResultConflict,
+				"unmanaged": ApplyResultSkipped,
+			},
+			expectedPaths: map[string]string{"unchanged": "/", "changed": "/old", "conflict": "/old", "unmanaged": "/old"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			if err := gatewayv1.Install(scheme); err != nil {
+				t.Fatal(err)
+			}
+			var objects []client.Object
+			for _, obj := range liveObjects {
+				objects = append(objects, obj.DeepCopyObject().(client.Object))
+			}
+			cl := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(objects...).
+				WithInterceptorFuncs(applyPatchInterceptor("conflict")).
+				Build()
+
+			results := ApplyGatewayResources(context.Background(), cl, gatewayResources, tc.opts)
+
+			gotResults := make(map[string]ApplyResultType)
+			for _, result := range results {
+				gotResults[result.Object.GetName()] = result.Type
+				if (result.Err != nil) != (result.Type == ApplyResultConflict) {
+					t.Errorf("Unexpected error for %s with result %s: %v", result.Object.GetName(), result.Type, result.Err)
+				}
+			}
+			if diff := cmp.Diff(tc.expectedResults, gotResults); diff != "" {
+				t.Errorf("Unexpected apply results (-want +got):\n%s", diff)
+			}
+
+			routes := &gatewayv1.HTTPRouteList{}
+			if err := cl.List(context.Background(), routes); err != nil {
+				t.Fatal(err)
+			}
+			gotPaths := make(map[string]string)
+			for _, route := range routes.Items {
+				gotPaths[route.Name] = *route.Spec.Rules[0].Matches[0].Path.Value
+				if route.Annotations[GeneratorAnnotationKey] != GeneratorAnnotationValue() {
+					t.Errorf("Expected HTTPRoute %s to carry the generator annotation, got %v", route.Name, route.Annotations)
+				}
+			}
+			if diff := cmp.Diff(tc.expectedPaths, gotPaths); diff != "" {
+				t.Errorf("Unexpected HTTPRoute paths in the cluster (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package i2gw
+
+import (
+	"cmp"
+	"slices"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// IngressAudit lists the provider annotations of an Ingress which are dropped
+// during the conversion.
+type IngressAudit struct {
+	Ingress types.NamespacedName
+	// DroppedAnnotations holds the sorted annotation keys of every provider
+	// which are not converted.
+	DroppedAnnotations map[ProviderName][]string
+}
+
+// AuditIngresses returns the audit of every Ingress carrying annotations of
+// the providers which are not converted, sorted by namespace and name.
+// Providers which did not register their annotations are ignored.
+func AuditIngresses(ingresses []networkingv1.Ingress, providers []string) []IngressAudit {
+	providerAnnotations := GetProviderAnnotations()
+
+	var audits []IngressAudit
+	for _, ingress := range ingresses {
+		audit := IngressAudit{
+			Ingress:            types.NamespacedName{Namespace: ingress.Namespace, Name: ingress.Name},
+			DroppedAnnotations: make(map[ProviderName][]string),
+		}
+		for _, provider := range providers {
+			annotations, ok := providerAnnotations[ProviderName(provider)]
+			if !ok {
+				continue
+			}
+			for key := range ingress.Annotations {
+				if annotations.IsProviderAnnotation(key) && !annotations.IsConverted(key) {
+					audit.DroppedAnnotations[ProviderName(provider)] = append(audit.DroppedAnnotations[ProviderName(provider)], key)
+				}
+			}
+			slices.Sort(audit.DroppedAnnotations[ProviderName(provider)])
+		}
+		if len(audit.DroppedAnnotations) > 0 {
+			audits = append(audits, audit)
+		}
+	}
+
+	slices.SortFunc(audits, func(a, b IngressAudit) int {
+		return cmp.Or(
+			cmp.Compare(a.Ingress.Namespace, b.Ingress.Namespace),
+			cmp.Compare(a.Ingress.Name, b.Ingress.Name),
+		)
+	})
+	return audits
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package i2gw
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func Test_AuditIngresses(t *testing.T) {
+	RegisterProviderAnnotations("audit-provider", ProviderAnnotations{
+		Prefixes:    []string{"audit.example.com/"},
+		Keys:        []string{"audit.example.com/converted"},
+		KeyPrefixes: []string{"audit.example.com/headers."},
+	})
+	ingress := func(name string, annotations map[string]string) networkingv1.Ingress {
+		return networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, Annotations: annotations}}
+	}
+
+	ingresses := []networkingv1.Ingress{
+		ingress("web", map[string]string{
+			"audit.example.com/converted":   "true",
+			"audit.example.com/headers.foo": "bar",
+			"audit.example.com/timeout":     "5s",
+			"audit.example.com/rewrite":     "/",
+			"other.example.com/timeout":     "5s",
+		}),
+		ingress("api", map[string]string{"audit.example.com/timeout": "5s"}),
+		ingress("clean", map[string]string{"audit.example.com/converted": "true"}),
+	}
+
+	expected := []IngressAudit{
+		{
+			Ingress:            types.NamespacedName{Namespace: "default", Name: "api"},
+			DroppedAnnotations: map[ProviderName][]string{"audit-provider": {"audit.example.com/timeout"}},
+		},
+		{
+			Ingress:            types.NamespacedName{Namespace: "default", Name: "web"},
+			DroppedAnnotations: map[ProviderName][]string{"audit-provider": {"audit.example.com/rewrite", "audit.example.com/timeout"}},
+		},
+	}
+	got := AuditIngresses(ingresses, []string{"audit-provider", "unregistered-provider"})
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("Unexpected audits (-want +got):\n%s", diff)
+	}
+}
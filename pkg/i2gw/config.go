@@ -0,0 +1,198 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package i2gw
+
+import (
+	"fmt"
+	"os"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// DefaultGatewayConfigKey is the key of the Config gateways applying to
+	// the Gateways which are not listed.
+	DefaultGatewayConfigKey = "*"
+
+	// ConfigAPIVersion is the version of the Config schema.
+	ConfigAPIVersion = "ingress2gateway.kubernetes.io/v1alpha1"
+	// ConfigKind is the kind of the Config.
+	ConfigKind = "Config"
+)
+
+// Config is the declarative configuration of a conversion, read from the
+// --config file. The command-line flags take precedence over it.
+type Config struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+
+	// Providers lists the providers to convert the resources of.
+	Providers []string `json:"providers,omitempty"`
+
+	// Gateways configures the generated Gateways, by the name they are
+	// generated with, i.e. the ingress class for the Ingress providers, or by
+	// DefaultGatewayConfigKey for all the others.
+	Gateways map[string]GatewayConfig `json:"gateways,omitempty"`
+
+	// ProviderSettings configures the providers, by provider name.
+	ProviderSettings map[string]ProviderSettings `json:"providerSettings,omitempty"`
+
+	// Output configures how the generated resources are output.
+	Output OutputConfig `json:"output,omitempty"`
+}
+
+// GatewayConfig configures a generated Gateway. The routes attached to the
+// Gateway are updated accordingly.
+type GatewayConfig struct {
+	// Name is the name of the Gateway, instead of the generated one.
+	Name string `json:"name,omitempty"`
+	// GatewayClassName is the GatewayClass of the Gateway, instead of the
+	// ingress class.
+	GatewayClassName string `json:"gatewayClassName,omitempty"`
+	// Namespace is the namespace the Gateway is placed in, instead of the one
+	// of its routes. The listeners of the Gateway then only allow the routes
+	// of the original namespace, and ReferenceGrants are generated for the
+	// TLS Secrets, which stay in the original namespace.
+	Namespace string `json:"namespace,omitempty"`
+	// Shared merges the Gateways generated with this name in every namespace
+	// into a single Gateway in Namespace, instead of one per namespace. The
+	// listeners of the same name are merged, and allow the routes of every
+	// namespace they were generated in.
+	Shared bool `json:"shared,omitempty"`
+}
+
+// ProviderSettings configures a provider.
+type ProviderSettings struct {
+	// Flags holds the values of the provider-specific flags, by flag name
+	// without the provider prefix.
+	Flags map[string]string `json:"flags,omitempty"`
+	// DisabledFeatures lists the features which are not converted, by the
+	// name of their feature parser, e.g. canaryFeature.
+	DisabledFeatures []string `json:"disabledFeatures,omitempty"`
+}
+
+// OutputConfig configures the output of the conversion. Each field
+// corresponds to the command-line flag of the same name.
+type OutputConfig struct {
+	Format                string `json:"format,omitempty"`
+	Dir                   string `json:"dir,omitempty"`
+	DirLayout             string `json:"dirLayout,omitempty"`
+	ReportFile            string `json:"reportFile,omitempty"`
+	ReportFormat          string `json:"reportFormat,omitempty"`
+	FailOn                string `json:"failOn,omitempty"`
+	ProvenanceAnnotations bool   `json:"provenanceAnnotations,omitempty"`
+	ProvenanceFile        string `json:"provenanceFile,omitempty"`
+}
+
+// LoadConfig reads and validates the Config in filename. Unknown fields are
+// rejected.
+func LoadConfig(filename string) (*Config, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	var config Config
+	if err := yaml.UnmarshalStrict(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", filename, err)
+	}
+	if errs := config.Validate(); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid config file %s: %w", filename, errs.ToAggregate())
+	}
+	return &config, nil
+}
+
+// Validate checks the version of the Config, that the providers and their
+// flags exist, and that the Gateway settings are valid object names.
+func (c *Config) Validate() field.ErrorList {
+	var errs field.ErrorList
+	if c.APIVersion != ConfigAPIVersion {
+		errs = append(errs, field.NotSupported(field.NewPath("apiVersion"), c.APIVersion, []string{ConfigAPIVersion}))
+	}
+	if c.Kind != ConfigKind {
+		errs = append(errs, field.NotSupported(field.NewPath("kind"), c.Kind, []string{ConfigKind}))
+	}
+
+	supportedProviders := GetSupportedProviders()
+	for i, provider := range c.Providers {
+		if _, ok := ProviderConstructorByName[ProviderName(provider)]; !ok {
+			errs = append(errs, field.NotSupported(field.NewPath("providers").Index(i), provider, supportedProviders))
+		}
+	}
+
+	gatewaysPath := field.NewPath("gateways")
+	for _, name := range sortedKeys(c.Gateways) {
+		gateway := c.Gateways[name]
+		gatewayPath := gatewaysPath.Key(name)
+		if gateway.Name != "" {
+			for _, msg := range validation.IsDNS1123Subdomain(gateway.Name) {
+				errs = append(errs, field.Invalid(gatewayPath.Child("name"), gateway.Name, msg))
+			}
+		}
+		if gateway.GatewayClassName != "" {
+			for _, msg := range validation.IsDNS1123Subdomain(gateway.GatewayClassName) {
+				errs = append(errs, field.Invalid(gatewayPath.Child("gatewayClassName"), gateway.GatewayClassName, msg))
+			}
+		}
+		if gateway.Namespace != "" {
+			for _, msg := range validation.IsDNS1123Label(gateway.Namespace) {
+				errs = append(errs, field.Invalid(gatewayPath.Child("namespace"), gateway.Namespace, msg))
+			}
+		}
+		if gateway.Shared && gateway.Namespace == "" {
+			errs = append(errs, field.Required(gatewayPath.Child("namespace"), "shared Gateways must be placed in a namespace"))
+		}
+		if name == DefaultGatewayConfigKey && gateway.Name != "" {
+			errs = append(errs, field.Forbidden(gatewayPath.Child("name"), "the Gateways of every name cannot be renamed to the same name"))
+		}
+	}
+
+	flagDefinitions := GetProviderSpecificFlagDefinitions()
+	settingsPath := field.NewPath("providerSettings")
+	for _, provider := range sortedKeys(c.ProviderSettings) {
+		settings := c.ProviderSettings[provider]
+		providerPath := settingsPath.Key(provider)
+		if _, ok := ProviderConstructorByName[ProviderName(provider)]; !ok {
+			errs = append(errs, field.NotSupported(providerPath, provider, supportedProviders))
+			continue
+		}
+		for _, flag := range sortedKeys(settings.Flags) {
+			if _, ok := flagDefinitions[ProviderName(provider)][flag]; !ok {
+				errs = append(errs, field.NotFound(providerPath.Child("flags").Key(flag), flag))
+			}
+		}
+		for i, feature := range settings.DisabledFeatures {
+			if feature == "" {
+				errs = append(errs, field.Required(providerPath.Child("disabledFeatures").Index(i), "feature name must not be empty"))
+			}
+		}
+	}
+	return errs
+}
+
+// sortedKeys returns the keys of m in a sorted order, so that the errors are
+// reported in the same order on every run.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package i2gw
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_LoadConfig(t *testing.T) {
+	ProviderConstructorByName["config-provider"] = func(*ProviderConf) Provider { return nil }
+	t.Cleanup(func() { delete(ProviderConstructorByName, "config-provider") })
+	RegisterProviderSpecificFlag("config-provider", ProviderSpecificFlag{Name: "backend"})
+
+	testCases := []struct {
+		name           string
+		content        string
+		expectedConfig *Config
+		expectedErr    string
+	}{
+		{
+			name: "valid config",
+			content: `apiVersion: ingress2gateway.kubernetes.io/v1alpha1
+kind: Config
+providers: [config-provider]
+gateways:
+  nginx:
+    name: public
+    gatewayClassName: nginx-gateway
+    namespace: infra
+providerSettings:
+  config-provider:
+    flags:
+      backend: web
+    disabledFeatures: [canaryFeature]
+output:
+  format: json
+  provenanceAnnotations: true
+`,
+			expectedConfig: &Config{
+				APIVersion: ConfigAPIVersion,
+				Kind:       ConfigKind,
+				Providers:  []string{"config-provider"},
+				Gateways: map[string]GatewayConfig{
+					"nginx": {Name: "public", GatewayClassName: "nginx-gateway", Namespace: "infra"},
+				},
+				ProviderSettings: map[string]ProviderSettings{
+					"config-provider": {
+						Flags:            map[string]string{"backend": "web"},
+						DisabledFeatures: []string{"canaryFeature"},
+					},
+				},
+				Output: OutputConfig{Format: "json", ProvenanceAnnotations: true},
+			},
+		},
+		{
+			name: "unknown field",
+			content: `apiVersion: ingress2gateway.kubernetes.io/v1alpha1
+kind: Config
+provider: [config-provider]
+`,
+			expectedErr: `unknown field "provider"`,
+		},
+		{
+			name: "unsupported version",
+			content: `apiVersion: ingress2gateway.kubernetes.io/v1
+kind: Config
+`,
+			expectedErr: `apiVersion: Unsupported value: "ingress2gateway.kubernetes.io/v1"`,
+		},
+		{
+			name: "unknown provider flag",
+			content: `apiVersion: ingress2gateway.kubernetes.io/v1alpha1
+kind: Config
+providerSettings:
+  config-provider:
+    flags:
+      gateway: web
+`,
+			expectedErr: `providerSettings[config-provider].flags[gateway]: Not found`,
+		},
+		{
+			name: "invalid gateway name",
+			content: `apiVersion: ingress2gateway.kubernetes.io/v1alpha1
+kind: Config
+gateways:
+  nginx:
+    name: Public
+`,
+			expectedErr: `gateways[nginx].name: Invalid value: "Public"`,
+		},
+		{
+			name: "shared Gateway without namespace",
+			content: `apiVersion: ingress2gateway.kubernetes.io/v1alpha1
+kind: Config
+gateways:
+  "*":
+    shared: true
+`,
+			expectedErr: `gateways[*].namespace: Required value`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(filename, []byte(tc.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			config, err := LoadConfig(filename)
+			if tc.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedErr) {
+					t.Fatalf("Expected error containing %q, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expectedConfig, config); diff != "" {
+				t.Errorf("Unexpected config (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
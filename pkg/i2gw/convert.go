@@ -0,0 +1,194 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package i2gw
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ConvertRequest selects the resources converted by Convert and how they are
+// converted. The resources are read from the Manifests and the Objects, or
+// else from Options.Client. One of them must be set: Convert never reads the
+// kubeconfig.
+type ConvertRequest struct {
+	// Providers are the names of the providers which convert the resources.
+	Providers []string
+	// Namespace, if set, restricts the conversion to the resources of the
+	// namespace.
+	Namespace string
+	// ProviderSpecificFlags holds the provider-specific flags, by provider
+	// and flag name. The flags which are not set take their default value.
+	ProviderSpecificFlags map[string]map[string]string
+	// Manifests are read in order, as the input files of
+	// ToGatewayAPIResources.
+	Manifests []Manifest
+	// Objects are read after the Manifests, as a manifest named "objects".
+	Objects []*unstructured.Unstructured
+	// Options tune the conversion. Options.Client, if set, is read instead of
+	// the cluster of the current kubeconfig context, and can not be set
+	// along with Manifests or Objects.
+	Options ConversionOptions
+}
+
+// Manifest is a multi-document YAML or JSON manifest read by Convert.
+type Manifest struct {
+	// Name identifies the manifest in the notifications, as the path of an
+	// input file does. It defaults to manifest-<index>.
+	Name   string
+	Reader io.Reader
+}
+
+// ConvertResult holds the outcome of Convert.
+type ConvertResult struct {
+	// GatewayResources holds the resources generated by every provider, in
+	// the order of the provider names.
+	GatewayResources []GatewayResources
+	// Notifications holds the notifications of the conversion, by provider.
+	Notifications map[string][]notifications.Notification
+}
+
+// Convert converts the resources selected by req to Gateway API resources.
+// Unlike ToGatewayAPIResources, it does not use any process-wide state: the
+// manifests are read in memory, the notifications are collected for this
+// conversion only, and no client is created. It is safe to call Convert
+// concurrently.
+//
+// If providers failed to convert some of the resources, Convert returns the
+// result along with a *ConversionError holding the errors by provider. With
+// Options.BestEffort, the GatewayResources of these providers hold what could
+// be converted. Otherwise, they are incomplete and should not be used. Other
+// errors are returned with a nil result.
+func Convert(ctx context.Context, req ConvertRequest) (*ConvertResult, error) {
+	inputFiles, filenames, err := readManifests(req.Manifests, req.Objects)
+	if err != nil {
+		return nil, err
+	}
+
+	var cl client.Client
+	switch {
+	case req.Options.Client != nil && len(filenames) > 0:
+		return nil, fmt.Errorf("the resources are read either from manifests and objects, or from a client, not both")
+	case req.Options.Client != nil:
+		cl = client.NewNamespacedClient(req.Options.Client, req.Namespace)
+	case len(filenames) == 0:
+		return nil, fmt.Errorf("no manifests, objects or client to read the resources from")
+	}
+
+	if req.Options.Notifications == nil {
+		req.Options.Notifications = notifications.NewNotificationAggregator(req.Options.NotificationConsumers...)
+	}
+	conf := &ProviderConf{
+		Client:                cl,
+		Namespace:             req.Namespace,
+		ProviderSpecificFlags: withDefaultProviderSpecificFlags(req.Providers, req.ProviderSpecificFlags),
+		DisabledFeatures:      req.Options.DisabledFeatures,
+		Tracer:                req.Options.Tracer,
+		Notifications:         req.Options.Notifications,
+		BestEffort:            req.Options.BestEffort,
+		InputFiles:            inputFiles,
+	}
+	gatewayResources, err := convert(ctx, conf, req.Providers, filenames, req.Options.Gateways)
+	var conversionErr *ConversionError
+	if err != nil && !errors.As(err, &conversionErr) {
+		return nil, err
+	}
+	return &ConvertResult{
+		GatewayResources: gatewayResources,
+		Notifications:    req.Options.Notifications.GetNotifications(),
+	}, err
+}
+
+// objectsManifestName is the name of the manifest holding the Objects of a
+// ConvertRequest.
+const objectsManifestName = "objects"
+
+// readManifests reads the manifests and the objects in memory, by name, and
+// returns their names in order.
+func readManifests(manifests []Manifest, objects []*unstructured.Unstructured) (map[string][]byte, []string, error) {
+	inputFiles := make(map[string][]byte)
+	var filenames []string
+	add := func(name string, data []byte) error {
+		if _, ok := inputFiles[name]; ok {
+			return fmt.Errorf("duplicate manifest name %s", name)
+		}
+		inputFiles[name] = data
+		filenames = append(filenames, name)
+		return nil
+	}
+
+	for i, manifest := range manifests {
+		name := manifest.Name
+		if name == "" {
+			name = fmt.Sprintf("manifest-%d", i)
+		}
+		data, err := io.ReadAll(manifest.Reader)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read manifest %s: %w", name, err)
+		}
+		if err := add(name, data); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if len(objects) > 0 {
+		items := make([]any, 0, len(objects))
+		for _, obj := range objects {
+			items = append(items, obj.Object)
+		}
+		data, err := json.Marshal(map[string]any{"apiVersion": "v1", "kind": "List", "items": items})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to encode objects: %w", err)
+		}
+		if err := add(objectsManifestName, data); err != nil {
+			return nil, nil, err
+		}
+	}
+	return inputFiles, filenames, nil
+}
+
+// withDefaultProviderSpecificFlags returns the flags of the providers, where
+// the flags which are not set take their default value.
+func withDefaultProviderSpecificFlags(providers []string, flags map[string]map[string]string) map[string]map[string]string {
+	definitions := GetProviderSpecificFlagDefinitions()
+	withDefaults := make(map[string]map[string]string, len(flags))
+	for provider, providerFlags := range flags {
+		withDefaults[provider] = make(map[string]string, len(providerFlags))
+		for name, value := range providerFlags {
+			withDefaults[provider][name] = value
+		}
+	}
+	for _, provider := range providers {
+		for name, flag := range definitions[ProviderName(provider)] {
+			if _, ok := withDefaults[provider][name]; ok {
+				continue
+			}
+			if withDefaults[provider] == nil {
+				withDefaults[provider] = make(map[string]string)
+			}
+			withDefaults[provider][name] = flag.DefaultValue
+		}
+	}
+	return withDefaults
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package i2gw
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_Convert(t *testing.T) {
+	ProviderConstructorByName["serve-provider"] = func(conf *ProviderConf) Provider { return &serveProvider{conf: conf} }
+	t.Cleanup(func() { delete(ProviderConstructorByName, "serve-provider") })
+
+	scheme := runtime.NewScheme()
+	if err := networkingv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	ingress := func(namespace, name string) *networkingv1.Ingress {
+		return &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	}
+	ingressObject := &unstructured.Unstructured{}
+	ingressObject.SetAPIVersion("networking.k8s.io/v1")
+	ingressObject.SetKind("Ingress")
+	ingressObject.SetNamespace("default")
+	ingressObject.SetName("baz")
+
+	testCases := []struct {
+		name                  string
+		req                   ConvertRequest
+		expectedRoutes        []string
+		expectedNotifications []string
+		expectedConversionErr bool
+		expectedErr           string
+	}{
+		{
+			name: "manifests",
+			req: ConvertRequest{
+				Manifests: []Manifest{
+					{Name: "foo.yaml", Reader: strings.NewReader(ingressManifest("default", "foo"))},
+					{Reader: strings.NewReader(ingressManifest("other", "bar"))},
+				},
+			},
+			expectedRoutes:        []string{"default/foo", "other/bar"},
+			expectedNotifications: []string{"converted foo (foo.yaml)", "converted bar (manifest-1)"},
+		},
+		{
+			name: "manifests and objects in a namespace",
+			req: ConvertRequest{
+				Namespace: "default",
+				Manifests: []Manifest{
+					{Reader: strings.NewReader(ingressManifest("default", "foo") + "---\n" + ingressManifest("other", "bar"))},
+				},
+				Objects: []*unstructured.Unstructured{ingressObject},
+			},
+			expectedRoutes:        []string{"default/baz", "default/foo"},
+			expectedNotifications: []string{"converted foo (manifest-0)", "converted baz (objects)"},
+		},
+		{
+			name: "client",
+			req: ConvertRequest{
+				Namespace: "default",
+				Options: ConversionOptions{
+					Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(ingress("default", "foo"), ingress("other", "bar")).Build(),
+				},
+			},
+			expectedRoutes:        []string{"default/foo"},
+			expectedNotifications: []string{"converted foo"},
+		},
+		{
+			name: "conversion error",
+			req: ConvertRequest{
+				Manifests: []Manifest{{Reader: strings.NewReader(ingressManifest("default", "invalid"))}},
+			},
+			expectedRoutes:        []string{},
+			expectedConversionErr: true,
+		},
+		{
+			name: "manifests and client",
+			req: ConvertRequest{
+				Manifests: []Manifest{{Reader: strings.NewReader(ingressManifest("default", "foo"))}},
+				Options:   ConversionOptions{Client: fake.NewClientBuilder().WithScheme(scheme).Build()},
+			},
+			expectedErr: "either from manifests and objects, or from a client",
+		},
+		{
+			name:        "no resources",
+			req:         ConvertRequest{},
+			expectedErr: "no manifests, objects or client",
+		},
+		{
+			name: "duplicate manifest names",
+			req: ConvertRequest{
+				Manifests: []Manifest{
+					{Name: "foo.yaml", Reader: strings.NewReader(ingressManifest("default", "foo"))},
+					{Name: "foo.yaml", Reader: strings.NewReader(ingressManifest("default", "bar"))},
+				},
+			},
+			expectedErr: "duplicate manifest name foo.yaml",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.req.Providers = []string{"serve-provider"}
+			result, err := Convert(context.Background(), tc.req)
+
+			if tc.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedErr) {
+					t.Fatalf("Expected error containing %q, got %v", tc.expectedErr, err)
+				}
+				if result != nil {
+					t.Errorf("Expected no result, got %v", result)
+				}
+				return
+			}
+			var conversionErr *ConversionError
+			if gotConversionErr := errors.As(err, &conversionErr); gotConversionErr != tc.expectedConversionErr || (err != nil && !gotConversionErr) {
+				t.Fatalf("Expected conversion error: %v, got %v", tc.expectedConversionErr, err)
+			}
+
+			gotRoutes := []string{}
+			for _, gatewayResources := range result.GatewayResources {
+				for key := range gatewayResources.HTTPRoutes {
+					gotRoutes = append(gotRoutes, key.String())
+				}
+			}
+			slices.Sort(gotRoutes)
+			if diff := cmp.Diff(tc.expectedRoutes, gotRoutes); diff != "" {
+				t.Errorf("Unexpected routes (-want +got):\n%s", diff)
+			}
+
+			var gotNotifications []string
+			for _, n := range result.Notifications["serve-provider"] {
+				notification := n.Message
+				for _, obj := range n.CallingObjects {
+					if sourceFile := obj.GetAnnotations()[notifications.SourceFileAnnotationKey]; sourceFile != "" {
+						notification += " (" + sourceFile + ")"
+					}
+				}
+				gotNotifications = append(gotNotifications, notification)
+			}
+			if diff := cmp.Diff(tc.expectedNotifications, gotNotifications); diff != "" {
+				t.Errorf("Unexpected notifications (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_withDefaultProviderSpecificFlags(t *testing.T) {
+	RegisterProviderSpecificFlag("convert-provider", ProviderSpecificFlag{Name: "set", DefaultValue: "default"})
+	RegisterProviderSpecificFlag("convert-provider", ProviderSpecificFlag{Name: "unset", DefaultValue: "default"})
+
+	flags := map[string]map[string]string{"convert-provider": {"set": "value"}}
+	got := withDefaultProviderSpecificFlags([]string{"convert-provider"}, flags)
+
+	expected := map[string]map[string]string{"convert-provider": {"set": "value", "unset": "default"}}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("Unexpected flags (-want +got):\n%s", diff)
+	}
+	if _, ok := flags["convert-provider"]["unset"]; ok {
+		t.Errorf("Expected the flags of the request to be left unchanged")
+	}
+}
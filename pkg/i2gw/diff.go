@@ -0,0 +1,291 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package i2gw
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/intermediate"
+	networkingv1 "k8s.io/api/networking/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ObjectDiffType describes how a generated object compares to the live one.
+type ObjectDiffType string
+
+const (
+	// ObjectDiffNew is reported for generated objects missing from the cluster.
+	ObjectDiffNew ObjectDiffType = "new"
+	// ObjectDiffChanged is reported for generated objects whose fields differ
+	// from the live ones.
+	ObjectDiffChanged ObjectDiffType = "changed"
+	// ObjectDiffUnchanged is reported for generated objects matching the live
+	// ones.
+	ObjectDiffUnchanged ObjectDiffType = "unchanged"
+	// ObjectDiffOrphaned is reported for live objects carrying the generator
+	// annotation that are not generated anymore because all the objects they
+	// were converted from, as recorded by their SourcesAnnotationKey
+	// annotation, were deleted.
+	ObjectDiffOrphaned ObjectDiffType = "orphaned"
+	// ObjectDiffUntracked is reported for live objects carrying the generator
+	// annotation that are not generated anymore but have no recorded sources,
+	// e.g. generated without --provenance-annotations. They may be orphaned,
+	// or generated from other providers or inputs.
+	ObjectDiffUntracked ObjectDiffType = "untracked"
+)
+
+// ObjectDiff is the result of comparing a generated object with the
+// cluster.
+type ObjectDiff struct {
+	// Object is the generated object, or the live one when orphaned.
+	Object client.Object
+	Type   ObjectDiffType
+	// Fields lists the differing fields when Type is ObjectDiffChanged.
+	Fields []FieldDiff
+}
+
+// FieldDiff is a field whose generated value differs from the live one.
+type FieldDiff struct {
+	// Path is the path of the field, e.g. spec.rules[0].matches.
+	Path string
+	// Live and Generated hold the values of the field, nil when the field is
+	// not set.
+	Live      interface{}
+	Generated interface{}
+}
+
+// diffedFields are the fields compared by DiffGatewayResources. The status
+// and the metadata set by the API server are ignored.
+var diffedFields = [][]string{{"metadata", "labels"}, {"metadata", "annotations"}, {"spec"}}
+
+// DiffGatewayResources compares the generated objects with the live objects
+// in the cluster, and reports the live objects carrying the generator
+// annotation in namespace (all namespaces if empty) that are not generated
+// anymore and whose sources are all missing from both the converted inputs
+// and the cluster. The live objects without recorded sources, e.g. generated
+// without --provenance-annotations, are reported as untracked, as they may
+// come from other providers or inputs. Fields only set in the live objects are
+// considered defaulted and ignored, as is the value of the generator
+// annotation.
+func DiffGatewayResources(ctx context.Context, cl client.Reader, namespace string, gatewayResources []GatewayResources) ([]ObjectDiff, error) {
+	var diffs []ObjectDiff
+	generated := make(map[string]bool)
+	inputSources := make(map[intermediate.ObjectRef]bool)
+	for _, sources := range MergeSources(gatewayResources) {
+		for _, source := range sources {
+			inputSources[source] = true
+		}
+	}
+
+	for _, obj := range ToObjects(gatewayResources) {
+		generated[objectKey(obj)] = true
+
+		desired, err := toApplyConfiguration(obj)
+		if err != nil {
+			return nil, err
+		}
+		live := &unstructured.Unstructured{}
+		live.SetGroupVersionKind(desired.GroupVersionKind())
+		if err := cl.Get(ctx, client.ObjectKeyFromObject(desired), live); err != nil {
+			if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+				diffs = append(diffs, ObjectDiff{Object: obj, Type: ObjectDiffNew})
+				continue
+			}
+			return nil, fmt.Errorf("failed to get live %s %s: %w", desired.GetKind(), client.ObjectKeyFromObject(desired), err)
+		}
+
+		// The annotation value changes with every release, which is not worth
+		// reporting.
+		unstructured.RemoveNestedField(desired.Object, "metadata", "annotations", GeneratorAnnotationKey)
+		fields := diffObjects(desired, live)
+		if len(fields) == 0 {
+			diffs = append(diffs, ObjectDiff{Object: obj, Type: ObjectDiffUnchanged})
+		} else {
+			diffs = append(diffs, ObjectDiff{Object: obj, Type: ObjectDiffChanged, Fields: fields})
+		}
+	}
+
+	for _, gvk := range GeneratedGVKs {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err := cl.List(ctx, list, client.InNamespace(namespace)); err != nil {
+			if meta.IsNoMatchError(err) || apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to list %s: %w", gvk.Kind, err)
+		}
+		for i := range list.Items {
+			live := &list.Items[i]
+			if _, ok := live.GetAnnotations()[GeneratorAnnotationKey]; !ok || generated[objectKey(live)] {
+				continue
+			}
+			live.SetGroupVersionKind(gvk)
+			if _, ok := live.GetAnnotations()[SourcesAnnotationKey]; !ok {
+				diffs = append(diffs, ObjectDiff{Object: live, Type: ObjectDiffUntracked})
+				continue
+			}
+			orphaned, err := sourcesDeleted(ctx, cl, live, inputSources)
+			if err != nil {
+				return nil, err
+			}
+			if !orphaned {
+				continue
+			}
+			diffs = append(diffs, ObjectDiff{Object: live, Type: ObjectDiffOrphaned})
+		}
+	}
+
+	return diffs, nil
+}
+
+// sourcesDeleted returns whether all the sources recorded on the live object
+// are missing from the inputs and from the cluster. The objects with sources
+// of unknown kinds are not considered orphaned.
+func sourcesDeleted(ctx context.Context, cl client.Reader, live client.Object, inputSources map[intermediate.ObjectRef]bool) (bool, error) {
+	sources := parseSourcesAnnotation(live.GetAnnotations()[SourcesAnnotationKey])
+	if len(sources) == 0 {
+		return false, nil
+	}
+	for _, source := range sources {
+		if inputSources[source] {
+			return false, nil
+		}
+		gvk, ok := sourceGVK(source.Kind)
+		if !ok {
+			return false, nil
+		}
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(gvk)
+		err := cl.Get(ctx, types.NamespacedName{Namespace: source.Namespace, Name: source.Name}, obj)
+		if err == nil {
+			return false, nil
+		}
+		if !apierrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+			return false, fmt.Errorf("failed to get source %s: %w", source, err)
+		}
+	}
+	return true, nil
+}
+
+// sourceGVK returns the kind of the source objects named kind, looked up in
+// the kinds registered by the providers.
+func sourceGVK(kind string) (schema.GroupVersionKind, bool) {
+	if gvk, ok := providerResourceKinds.find(kind); ok {
+		return gvk, true
+	}
+	if kind == "Ingress" {
+		return networkingv1.SchemeGroupVersion.WithKind(kind), true
+	}
+	return schema.GroupVersionKind{}, false
+}
+
+func objectKey(obj client.Object) string {
+	return fmt.Sprintf("%s/%s/%s", obj.GetObjectKind().GroupVersionKind().GroupKind(), obj.GetNamespace(), obj.GetName())
+}
+
+// diffObjects returns the fields of desired that are not set to the same
+// value in live.
+func diffObjects(desired, live *unstructured.Unstructured) []FieldDiff {
+	var diffs []FieldDiff
+	for _, fields := range diffedFields {
+		desiredValue, found, _ := unstructured.NestedFieldNoCopy(desired.Object, fields...)
+		if !found {
+			continue
+		}
+		liveValue, _, _ := unstructured.NestedFieldNoCopy(live.Object, fields...)
+		diffs = append(diffs, diffFields(strings.Join(fields, "."), desiredValue, liveValue)...)
+	}
+	return diffs
+}
+
+// diffFields walks desired and returns every field whose value differs in
+// live. Maps are compared key by key, ignoring keys only present in live, and
+// lists element by element, as lists are replaced as a whole when applied.
+func diffFields(path string, desired, live interface{}) []FieldDiff {
+	switch desiredValue := desired.(type) {
+	case map[string]interface{}:
+		liveValue, ok := live.(map[string]interface{})
+		if !ok {
+			if len(desiredValue) == 0 && live == nil {
+				return nil
+			}
+			return []FieldDiff{{Path: path, Live: live, Generated: desired}}
+		}
+		keys := make([]string, 0, len(desiredValue))
+		for key := range desiredValue {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		var diffs []FieldDiff
+		for _, key := range keys {
+			diffs = append(diffs, diffFields(fmt.Sprintf("%s.%s", path, key), desiredValue[key], liveValue[key])...)
+		}
+		return diffs
+	case []interface{}:
+		liveValue, ok := live.([]interface{})
+		if !ok {
+			if len(desiredValue) == 0 && live == nil {
+				return nil
+			}
+			return []FieldDiff{{Path: path, Live: live, Generated: desired}}
+		}
+		var diffs []FieldDiff
+		for i := 0; i < max(len(desiredValue), len(liveValue)); i++ {
+			elementPath := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= len(liveValue):
+				diffs = append(diffs, FieldDiff{Path: elementPath, Generated: desiredValue[i]})
+			case i >= len(desiredValue):
+				diffs = append(diffs, FieldDiff{Path: elementPath, Live: liveValue[i]})
+			default:
+				diffs = append(diffs, diffFields(elementPath, desiredValue[i], liveValue[i])...)
+			}
+		}
+		return diffs
+	case nil:
+		return nil
+	default:
+		if apiequality.Semantic.DeepEqual(desired, live) {
+			return nil
+		}
+		return []FieldDiff{{Path: path, Live: live, Generated: desired}}
+	}
+}
+
+// GeneratedGVKs lists the kinds of the objects carrying the generator
+// annotation.
+var GeneratedGVKs = []schema.GroupVersionKind{
+	gatewayGVK("v1", "Gateway"),
+	gatewayGVK("v1", "HTTPRoute"),
+	gatewayGVK("v1alpha2", "TLSRoute"),
+	gatewayGVK("v1alpha2", "TCPRoute"),
+	gatewayGVK("v1alpha2", "UDPRoute"),
+	gatewayGVK("v1beta1", "ReferenceGrant"),
+}
+
+func gatewayGVK(version, kind string) schema.GroupVersionKind {
+	return schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: version, Kind: kind}
+}
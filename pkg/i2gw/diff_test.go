@@ -0,0 +1,174 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package i2gw
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/intermediate"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func Test_DiffGatewayResources(t *testing.T) {
+	pathPrefix := gatewayv1.PathMatchPathPrefix
+	route := func(name, path string, annotated bool) *gatewayv1.HTTPRoute {
+		r := &gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Spec: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: []gatewayv1.ParentReference{{Name: "nginx"}},
+				},
+				Rules: []gatewayv1.HTTPRouteRule{{
+					Matches: []gatewayv1.HTTPRouteMatch{{
+						Path: &gatewayv1.HTTPPathMatch{Type: &pathPrefix, Value: &path},
+					}},
+				}},
+			},
+		}
+		r.SetGroupVersionKind(gatewayv1.SchemeGroupVersion.WithKind("HTTPRoute"))
+		if annotated {
+			r.Annotations = map[string]string{GeneratorAnnotationKey: "ingress2gateway-0.1.0"}
+		}
+		return r
+	}
+	// defaulted sets the fields the API server defaults, and a status.
+	defaulted := func(r *gatewayv1.HTTPRoute) *gatewayv1.HTTPRoute {
+		group := gatewayv1.Group(gatewayv1.GroupName)
+		kind := gatewayv1.Kind("Gateway")
+		r.Spec.ParentRefs[0].Group = &group
+		r.Spec.ParentRefs[0].Kind = &kind
+		r.Spec.Rules[0].BackendRefs = nil
+		r.Status.Parents = []gatewayv1.RouteParentStatus{{ControllerName: "example.com/controller"}}
+		return r
+	}
+
+	// withSources records the sources of a live route.
+	withSources := func(r *gatewayv1.HTTPRoute, sources string) *gatewayv1.HTTPRoute {
+		r.Annotations[SourcesAnnotationKey] = sources
+		return r
+	}
+
+	gatewayResources := []GatewayResources{{
+		HTTPRoutes: map[types.NamespacedName]gatewayv1.HTTPRoute{
+			{Namespace: "default", Name: "new"}:       *route("new", "/", false),
+			{Namespace: "default", Name: "unchanged"}: *route("unchanged", "/", false),
+			{Namespace: "default", Name: "changed"}:   *route("changed", "/", false),
+		},
+		Sources: map[intermediate.ObjectRef][]intermediate.ObjectRef{
+			{Kind: "HTTPRoute", Namespace: "default", Name: "new"}: {{Kind: "Ingress", Namespace: "default", Name: "input"}},
+		},
+	}}
+
+	scheme := runtime.NewScheme()
+	if err := gatewayv1.Install(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := networkingv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		defaulted(route("unchanged", "/", true)),
+		defaulted(route("changed", "/old", true)),
+		withSources(route("orphaned", "/", true), "Ingress/default/deleted"),
+		// The routes whose sources still exist, in the inputs or in the
+		// cluster, or whose sources are of unknown kinds, are not orphaned.
+		withSources(route("input-source", "/", true), "Ingress/default/deleted,Ingress/default/input"),
+		withSources(route("live-source", "/", true), "Ingress/default/live"),
+		withSources(route("unknown-source-kind", "/", true), "Unknown/default/deleted"),
+		route("no-sources", "/", true),
+		route("hand-written", "/", false),
+		&networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "live"}},
+	).Build()
+
+	diffs, err := DiffGatewayResources(context.Background(), cl, "default", gatewayResources)
+	if err != nil {
+		t.Fatalf("Expected no error but got %v", err)
+	}
+
+	gotTypes := make(map[string]ObjectDiffType)
+	gotFields := make(map[string][]FieldDiff)
+	for _, diff := range diffs {
+		gotTypes[diff.Object.GetName()] = diff.Type
+		if len(diff.Fields) > 0 {
+			gotFields[diff.Object.GetName()] = diff.Fields
+		}
+	}
+
+	expectedTypes := map[string]ObjectDiffType{
+		"new":        ObjectDiffNew,
+		"unchanged":  ObjectDiffUnchanged,
+		"changed":    ObjectDiffChanged,
+		"orphaned":   ObjectDiffOrphaned,
+		"no-sources": ObjectDiffUntracked,
+	}
+	if diff := cmp.Diff(expectedTypes, gotTypes); diff != "" {
+		t.Errorf("Unexpected diff types (-want +got):\n%s", diff)
+	}
+
+	expectedFields := map[string][]FieldDiff{
+		"changed": {{Path: "spec.rules[0].matches[0].path.value", Live: "/old", Generated: "/"}},
+	}
+	if diff := cmp.Diff(expectedFields, gotFields); diff != "" {
+		t.Errorf("Unexpected field diffs (-want +got):\n%s", diff)
+	}
+}
+
+func Test_diffFields(t *testing.T) {
+	testCases := []struct {
+		name     string
+		desired  interface{}
+		live     interface{}
+		expected []FieldDiff
+	}{
+		{
+			name:    "fields only set in live are ignored",
+			desired: map[string]interface{}{"a": "x"},
+			live:    map[string]interface{}{"a": "x", "b": "y"},
+		},
+		{
+			name:     "field missing in live",
+			desired:  map[string]interface{}{"a": "x", "b": map[string]interface{}{"c": int64(1)}},
+			live:     map[string]interface{}{"a": "x"},
+			expected: []FieldDiff{{Path: "spec.b", Generated: map[string]interface{}{"c": int64(1)}}},
+		},
+		{
+			name:    "lists are compared element by element",
+			desired: map[string]interface{}{"l": []interface{}{"x", "y"}},
+			live:    map[string]interface{}{"l": []interface{}{"x", "z", "w"}},
+			expected: []FieldDiff{
+				{Path: "spec.l[1]", Live: "z", Generated: "y"},
+				{Path: "spec.l[2]", Live: "w"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := diffFields("spec", tc.desired, tc.live)
+			if diff := cmp.Diff(tc.expected, got); diff != "" {
+				t.Errorf("Unexpected field diffs (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,277 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package i2gw
+
+import (
+	"cmp"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/intermediate"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ExplainStage is the contribution of the explained object to a stage of the
+// conversion of a provider.
+type ExplainStage struct {
+	Stage string
+	// Objects holds the objects built from the explained object after the
+	// stage, sorted by kind, namespace and name. The objects of the IR stages
+	// hold their provider-specific IR in the providerSpecificIR field.
+	Objects []ExplainObject
+	// Added and Removed list the objects built from the explained object
+	// which were added or removed by the stage. Both are empty for the first
+	// stage of a provider.
+	Added   []intermediate.ObjectRef
+	Removed []intermediate.ObjectRef
+	// Changes holds the fields of the other objects which were changed by the
+	// stage.
+	Changes map[intermediate.ObjectRef][]StageChange
+	// First is true for the first stage traced for the provider.
+	First bool
+}
+
+// ExplainObject is an object built from the explained object.
+type ExplainObject struct {
+	Ref    intermediate.ObjectRef
+	Object *unstructured.Unstructured
+}
+
+// StageChange is a field whose value was changed by a stage.
+type StageChange struct {
+	// Path is the path of the field, e.g. spec.rules[0].matches.
+	Path string
+	// Before and After hold the values of the field, nil when the field is
+	// not set.
+	Before interface{}
+	After  interface{}
+}
+
+// Explainer is a Tracer recording the contribution of an object to every
+// stage of the conversion, as listed in the sources of the generated objects.
+type Explainer struct {
+	source intermediate.ObjectRef
+	stages map[ProviderName][]ExplainStage
+}
+
+// NewExplainer returns an Explainer of source. Its kind is matched
+// case-insensitively.
+func NewExplainer(source intermediate.ObjectRef) *Explainer {
+	return &Explainer{
+		source: source,
+		stages: make(map[ProviderName][]ExplainStage),
+	}
+}
+
+// Stages returns the stages traced for every provider, in order.
+func (e *Explainer) Stages() map[ProviderName][]ExplainStage {
+	return e.stages
+}
+
+// TraceIR implements Tracer.
+func (e *Explainer) TraceIR(provider ProviderName, stage string, ir intermediate.IR) {
+	objects := make(map[intermediate.ObjectRef]interface{})
+	for key, gatewayContext := range ir.Gateways {
+		objects[objectRef("Gateway", key)] = explainContext{&gatewayContext.Gateway, gatewayContext.ProviderSpecificIR}
+	}
+	for key, httpRouteContext := range ir.HTTPRoutes {
+		objects[objectRef("HTTPRoute", key)] = explainContext{&httpRouteContext.HTTPRoute, httpRouteContext.ProviderSpecificIR}
+	}
+	for key, tlsRoute := range ir.TLSRoutes {
+		objects[objectRef("TLSRoute", key)] = &tlsRoute
+	}
+	for key, tcpRoute := range ir.TCPRoutes {
+		objects[objectRef("TCPRoute", key)] = &tcpRoute
+	}
+	for key, udpRoute := range ir.UDPRoutes {
+		objects[objectRef("UDPRoute", key)] = &udpRoute
+	}
+	for key, referenceGrant := range ir.ReferenceGrants {
+		objects[objectRef("ReferenceGrant", key)] = &referenceGrant
+	}
+	e.addStage(provider, stage, objects, ir.Sources)
+}
+
+// TraceGatewayResources implements Tracer.
+func (e *Explainer) TraceGatewayResources(provider ProviderName, gatewayResources GatewayResources) {
+	objects := make(map[intermediate.ObjectRef]interface{})
+	for key, gateway := range gatewayResources.Gateways {
+		objects[objectRef("Gateway", key)] = &gateway
+	}
+	for key, httpRoute := range gatewayResources.HTTPRoutes {
+		objects[objectRef("HTTPRoute", key)] = &httpRoute
+	}
+	for key, tlsRoute := range gatewayResources.TLSRoutes {
+		objects[objectRef("TLSRoute", key)] = &tlsRoute
+	}
+	for key, tcpRoute := range gatewayResources.TCPRoutes {
+		objects[objectRef("TCPRoute", key)] = &tcpRoute
+	}
+	for key, udpRoute := range gatewayResources.UDPRoutes {
+		objects[objectRef("UDPRoute", key)] = &udpRoute
+	}
+	for key, referenceGrant := range gatewayResources.ReferenceGrants {
+		objects[objectRef("ReferenceGrant", key)] = &referenceGrant
+	}
+	e.addStage(provider, TraceStageToGatewayResources, objects, gatewayResources.Sources)
+}
+
+// explainContext is an IR object along with its provider-specific IR.
+type explainContext struct {
+	object             runtime.Object
+	providerSpecificIR interface{}
+}
+
+func objectRef(kind string, key types.NamespacedName) intermediate.ObjectRef {
+	return intermediate.ObjectRef{Kind: kind, Namespace: key.Namespace, Name: key.Name}
+}
+
+// addStage records the objects built from the explained object, and how they
+// changed since the previous stage of the provider.
+func (e *Explainer) addStage(provider ProviderName, stage string, objects map[intermediate.ObjectRef]interface{}, sources map[intermediate.ObjectRef][]intermediate.ObjectRef) {
+	explainStage := ExplainStage{
+		Stage:   stage,
+		Changes: make(map[intermediate.ObjectRef][]StageChange),
+		First:   len(e.stages[provider]) == 0,
+	}
+	for ref, obj := range objects {
+		if !slices.ContainsFunc(sources[ref], e.isSource) {
+			continue
+		}
+		u, err := toExplainObject(ref, obj)
+		if err != nil {
+			continue
+		}
+		explainStage.Objects = append(explainStage.Objects, ExplainObject{Ref: ref, Object: u})
+	}
+	slices.SortFunc(explainStage.Objects, func(a, b ExplainObject) int {
+		return compareRefs(a.Ref, b.Ref)
+	})
+
+	if !explainStage.First {
+		previous := e.stages[provider][len(e.stages[provider])-1]
+		for _, obj := range explainStage.Objects {
+			i := slices.IndexFunc(previous.Objects, func(o ExplainObject) bool { return o.Ref == obj.Ref })
+			if i < 0 {
+				explainStage.Added = append(explainStage.Added, obj.Ref)
+				continue
+			}
+			if changes := diffStageObjects(previous.Objects[i].Object, obj.Object); len(changes) > 0 {
+				explainStage.Changes[obj.Ref] = changes
+			}
+		}
+		for _, obj := range previous.Objects {
+			if !slices.ContainsFunc(explainStage.Objects, func(o ExplainObject) bool { return o.Ref == obj.Ref }) {
+				explainStage.Removed = append(explainStage.Removed, obj.Ref)
+			}
+		}
+	}
+
+	e.stages[provider] = append(e.stages[provider], explainStage)
+}
+
+func (e *Explainer) isSource(ref intermediate.ObjectRef) bool {
+	return strings.EqualFold(ref.Kind, e.source.Kind) && ref.Namespace == e.source.Namespace && ref.Name == e.source.Name
+}
+
+func compareRefs(a, b intermediate.ObjectRef) int {
+	return cmp.Or(
+		cmp.Compare(a.Kind, b.Kind),
+		cmp.Compare(a.Namespace, b.Namespace),
+		cmp.Compare(a.Name, b.Name),
+	)
+}
+
+// toExplainObject copies obj to an unstructured object, without its status
+// and creation timestamp, which are never set by the conversion.
+func toExplainObject(ref intermediate.ObjectRef, obj interface{}) (*unstructured.Unstructured, error) {
+	var providerSpecificIR interface{}
+	if c, ok := obj.(explainContext); ok {
+		obj = c.object
+		providerSpecificIR = c.providerSpecificIR
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	u := &unstructured.Unstructured{Object: content}
+	if u.GetKind() == "" {
+		u.SetKind(ref.Kind)
+	}
+	unstructured.RemoveNestedField(u.Object, "status")
+	unstructured.RemoveNestedField(u.Object, "metadata", "creationTimestamp")
+
+	if providerSpecificIR != nil {
+		// The provider-specific IR types are not Kubernetes types, so they are
+		// converted through JSON, keeping the providers which set them.
+		data, err := json.Marshal(providerSpecificIR)
+		if err != nil {
+			return nil, err
+		}
+		var irByProvider map[string]interface{}
+		if err := json.Unmarshal(data, &irByProvider); err != nil {
+			return nil, err
+		}
+		for provider, ir := range irByProvider {
+			if ir == nil {
+				delete(irByProvider, provider)
+			}
+		}
+		if len(irByProvider) > 0 {
+			u.Object["providerSpecificIR"] = irByProvider
+		}
+	}
+	return u, nil
+}
+
+// diffStageObjects returns the fields changed between before and after,
+// including the fields which were removed.
+func diffStageObjects(before, after *unstructured.Unstructured) []StageChange {
+	keys := make(map[string]bool)
+	for key := range before.Object {
+		keys[key] = true
+	}
+	for key := range after.Object {
+		keys[key] = true
+	}
+	sortedKeys := make([]string, 0, len(keys))
+	for key := range keys {
+		sortedKeys = append(sortedKeys, key)
+	}
+	slices.Sort(sortedKeys)
+
+	var changes []StageChange
+	for _, key := range sortedKeys {
+		// diffFields ignores the map keys missing from desired, so the fields
+		// removed by the stage are found by diffing in the other direction.
+		paths := make(map[string]bool)
+		for _, d := range diffFields(key, after.Object[key], before.Object[key]) {
+			changes = append(changes, StageChange{Path: d.Path, Before: d.Live, After: d.Generated})
+			paths[d.Path] = true
+		}
+		for _, d := range diffFields(key, before.Object[key], after.Object[key]) {
+			if d.Live == nil && !paths[d.Path] {
+				changes = append(changes, StageChange{Path: d.Path, Before: d.Generated})
+			}
+		}
+	}
+	return changes
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package i2gw
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/intermediate"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+type testTracer struct {
+	stages []string
+}
+
+func (t *testTracer) TraceIR(provider ProviderName, stage string, _ intermediate.IR) {
+	t.stages = append(t.stages, string(provider)+" "+stage)
+}
+
+func (t *testTracer) TraceGatewayResources(provider ProviderName, _ GatewayResources) {
+	t.stages = append(t.stages, string(provider)+" "+TraceStageToGatewayResources)
+}
+
+func addHostnameFeature(_ *ProviderConf, _ []networkingv1.Ingress, ir *intermediate.IR) field.ErrorList {
+	for key, httpRouteContext := range ir.HTTPRoutes {
+		httpRouteContext.Spec.Hostnames = append(httpRouteContext.Spec.Hostnames, "example.com")
+		ir.HTTPRoutes[key] = httpRouteContext
+	}
+	return nil
+}
+
+func failingFeature(_ *ProviderConf, _ []networkingv1.Ingress, _ *intermediate.IR) field.ErrorList {
+	return field.ErrorList{field.Invalid(field.NewPath("spec"), nil, "invalid")}
+}
+
+func Test_ParseFeatures(t *testing.T) {
+	tracer := &testTracer{}
+	conf := &ProviderConf{Tracer: tracer}
+	ir := intermediate.IR{HTTPRoutes: map[types.NamespacedName]intermediate.HTTPRouteContext{
+		{Namespace: "default", Name: "route"}: {},
+	}}
+
+	errs := ParseFeatures(conf, "test", []FeatureParser{addHostnameFeature, failingFeature}, nil, &ir)
+	if len(errs) != 1 {
+		t.Errorf("Expected 1 error, got %v", errs)
+	}
+	expectedStages := []string{"test i2gw.addHostnameFeature", "test i2gw.failingFeature"}
+	if diff := cmp.Diff(expectedStages, tracer.stages); diff != "" {
+		t.Errorf("Unexpected stages (-want +got):\n%s", diff)
+	}
+
+	// A nil conf or tracer must not be traced.
+	if errs := ParseFeatures(nil, "test", []FeatureParser{addHostnameFeature}, nil, &ir); len(errs) != 0 {
+		t.Errorf("Expected no errors, got %v", errs)
+	}
+
+	// Disabled features are skipped, and unknown ones are reported.
+	tracer = &testTracer{}
+	conf = &ProviderConf{Tracer: tracer, DisabledFeatures: map[ProviderName][]string{"test": {"failingFeature", "unknownFeature"}}}
+	errs = ParseFeatures(conf, "test", []FeatureParser{addHostnameFeature, failingFeature}, nil, &ir)
+	expectedErrs := field.ErrorList{field.NotSupported(field.NewPath("providerSettings").Key("test").Child("disabledFeatures").Index(1), "unknownFeature", []string{"addHostnameFeature", "failingFeature"})}
+	if diff := cmp.Diff(expectedErrs, errs); diff != "" {
+		t.Errorf("Unexpected errors (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"test i2gw.addHostnameFeature"}, tracer.stages); diff != "" {
+		t.Errorf("Unexpected stages (-want +got):\n%s", diff)
+	}
+}
+
+func Test_Explainer(t *testing.T) {
+	ingressRef := intermediate.ObjectRef{Kind: "Ingress", Namespace: "default", Name: "foo"}
+	routeRef := intermediate.ObjectRef{Kind: "HTTPRoute", Namespace: "default", Name: "foo-route"}
+	otherRouteRef := intermediate.ObjectRef{Kind: "HTTPRoute", Namespace: "default", Name: "bar-route"}
+	routeKey := types.NamespacedName{Namespace: "default", Name: "foo-route"}
+	otherRouteKey := types.NamespacedName{Namespace: "default", Name: "bar-route"}
+
+	route := func(name string, hostnames ...gatewayv1.Hostname) gatewayv1.HTTPRoute {
+		return gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Spec:       gatewayv1.HTTPRouteSpec{Hostnames: hostnames},
+		}
+	}
+	sources := map[intermediate.ObjectRef][]intermediate.ObjectRef{
+		routeRef:      {ingressRef},
+		otherRouteRef: {{Kind: "Ingress", Namespace: "default", Name: "bar"}},
+	}
+
+	// The kind of the explained object is matched case-insensitively.
+	explainer := NewExplainer(intermediate.ObjectRef{Kind: "ingress", Namespace: "default", Name: "foo"})
+	explainer.TraceIR("test", TraceStageCommonToIR, intermediate.IR{
+		HTTPRoutes: map[types.NamespacedName]intermediate.HTTPRouteContext{
+			routeKey:      {HTTPRoute: route("foo-route", "a.com", "b.com")},
+			otherRouteKey: {HTTPRoute: route("bar-route")},
+		},
+		Sources: sources,
+	})
+	explainer.TraceIR("test", "feature", intermediate.IR{
+		HTTPRoutes: map[types.NamespacedName]intermediate.HTTPRouteContext{
+			routeKey: {
+				HTTPRoute: route("foo-route", "c.com"),
+				ProviderSpecificIR: intermediate.ProviderSpecificHTTPRouteIR{
+					Gce: &intermediate.GceHTTPRouteIR{},
+				},
+			},
+			otherRouteKey: {HTTPRoute: route("bar-route", "d.com")},
+		},
+		Sources: sources,
+	})
+	explainer.TraceGatewayResources("test", GatewayResources{Sources: sources})
+
+	stages := explainer.Stages()["test"]
+	if len(stages) != 3 {
+		t.Fatalf("Expected 3 stages, got %d", len(stages))
+	}
+
+	if !stages[0].First || len(stages[0].Objects) != 1 || stages[0].Objects[0].Ref != routeRef {
+		t.Errorf("Expected the first stage to hold %s, got %+v", routeRef, stages[0])
+	}
+	if _, found := stages[0].Objects[0].Object.Object["status"]; found {
+		t.Errorf("Expected the status to be removed, got %v", stages[0].Objects[0].Object.Object)
+	}
+
+	expectedChanges := map[intermediate.ObjectRef][]StageChange{
+		routeRef: {
+			{Path: "providerSpecificIR", After: map[string]interface{}{"Gce": map[string]interface{}{}}},
+			{Path: "spec.hostnames[0]", Before: "a.com", After: "c.com"},
+			{Path: "spec.hostnames[1]", Before: "b.com"},
+		},
+	}
+	if diff := cmp.Diff(expectedChanges, stages[1].Changes); diff != "" {
+		t.Errorf("Unexpected changes (-want +got):\n%s", diff)
+	}
+	if stages[1].First || len(stages[1].Added) != 0 || len(stages[1].Removed) != 0 {
+		t.Errorf("Expected no added or removed objects, got %+v", stages[1])
+	}
+
+	if stages[2].Stage != TraceStageToGatewayResources || len(stages[2].Objects) != 0 {
+		t.Errorf("Expected no generated objects, got %+v", stages[2])
+	}
+	if diff := cmp.Diff([]intermediate.ObjectRef{routeRef}, stages[2].Removed); diff != "" {
+		t.Errorf("Unexpected removed objects (-want +got):\n%s", diff)
+	}
+}
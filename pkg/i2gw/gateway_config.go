@@ -0,0 +1,345 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package i2gw
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/intermediate"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/naming"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+// namespaceNameLabel is the label set by Kubernetes on every namespace to its
+// name, which the listeners of moved and shared Gateways select the routes by.
+const namespaceNameLabel = "kubernetes.io/metadata.name"
+
+// ApplyGatewayConfigs renames the Gateways of gatewayResources, changes their
+// GatewayClass and moves them to another namespace as configured in gateways,
+// by generated name, or by DefaultGatewayConfigKey for the Gateways which are
+// not listed. Shared Gateways moved to the same namespace and name are merged.
+// The routes, sources and Gateway extensions referencing the Gateways are
+// updated accordingly.
+func ApplyGatewayConfigs(gatewayResources *GatewayResources, gateways map[string]GatewayConfig) field.ErrorList {
+	if len(gateways) == 0 || len(gatewayResources.Gateways) == 0 {
+		return nil
+	}
+
+	var errs field.ErrorList
+	keys := make([]types.NamespacedName, 0, len(gatewayResources.Gateways))
+	for key := range gatewayResources.Gateways {
+		keys = append(keys, key)
+	}
+	// The Gateways which are not configured are placed first, so that a
+	// conflict is reported on the configured Gateway.
+	slices.SortFunc(keys, func(a, b types.NamespacedName) int {
+		_, aConfigured := gatewayConfig(gateways, a.Name)
+		_, bConfigured := gatewayConfig(gateways, b.Name)
+		return cmp.Or(
+			compareBools(aConfigured, bConfigured),
+			compareNamespacedNames(a, b),
+		)
+	})
+
+	placedGateways := make(map[types.NamespacedName]gatewayv1.Gateway, len(gatewayResources.Gateways))
+	sharedGateways := make(map[types.NamespacedName]bool)
+	renamedGateways := make(map[types.NamespacedName]types.NamespacedName)
+	for _, key := range keys {
+		gateway := gatewayResources.Gateways[key]
+		config, _ := gatewayConfig(gateways, key.Name)
+		gatewayPath := field.NewPath("gateways").Key(key.Name)
+		newKey := key
+		if config.Name != "" {
+			newKey.Name = config.Name
+		}
+		if config.Namespace != "" {
+			newKey.Namespace = config.Namespace
+		}
+		placedGateway, placed := placedGateways[newKey]
+		if placed && !(config.Shared && sharedGateways[newKey]) {
+			errs = append(errs, field.Duplicate(gatewayPath, newKey.String()))
+			continue
+		}
+
+		gateway.Name, gateway.Namespace = newKey.Name, newKey.Namespace
+		if config.GatewayClassName != "" {
+			gateway.Spec.GatewayClassName = gatewayv1.ObjectName(config.GatewayClassName)
+		}
+		// The listeners of shared Gateways are restricted to the namespace of
+		// their routes even if it is the one of the Gateway, as the listeners
+		// of other namespaces may be merged into it.
+		if config.Shared || newKey.Namespace != key.Namespace {
+			moveListeners(&gateway, key, gatewayResources)
+		}
+		if placed {
+			if err := mergeListeners(&placedGateway, gateway.Spec.Listeners, gatewayPath); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			gateway = placedGateway
+		}
+		placedGateways[newKey] = gateway
+		sharedGateways[newKey] = config.Shared
+		if newKey != key {
+			renamedGateways[key] = newKey
+		}
+	}
+	gatewayResources.Gateways = placedGateways
+
+	if len(renamedGateways) == 0 {
+		return errs
+	}
+	for key, route := range gatewayResources.HTTPRoutes {
+		updateParentRefs(route.Namespace, route.Spec.ParentRefs, renamedGateways)
+		gatewayResources.HTTPRoutes[key] = route
+	}
+	for key, route := range gatewayResources.TLSRoutes {
+		updateParentRefs(route.Namespace, route.Spec.ParentRefs, renamedGateways)
+		gatewayResources.TLSRoutes[key] = route
+	}
+	for key, route := range gatewayResources.TCPRoutes {
+		updateParentRefs(route.Namespace, route.Spec.ParentRefs, renamedGateways)
+		gatewayResources.TCPRoutes[key] = route
+	}
+	for key, route := range gatewayResources.UDPRoutes {
+		updateParentRefs(route.Namespace, route.Spec.ParentRefs, renamedGateways)
+		gatewayResources.UDPRoutes[key] = route
+	}
+	for i := range gatewayResources.GatewayExtensions {
+		updateTargetRef(&gatewayResources.GatewayExtensions[i], renamedGateways)
+	}
+	renameSources(gatewayResources.Sources, renamedGateways)
+	return errs
+}
+
+// moveListeners restricts the listeners of gateway, moved from key, to the
+// routes of the namespace of key. If the namespace of gateway changed, the
+// TLS Secrets are referenced in the namespace of key, through a
+// ReferenceGrant added to gatewayResources.
+func moveListeners(gateway *gatewayv1.Gateway, key types.NamespacedName, gatewayResources *GatewayResources) {
+	sourceNamespace := key.Namespace
+	fromSelector := gatewayv1.NamespacesFromSelector
+	var secrets []gatewayv1.ObjectName
+	for i := range gateway.Spec.Listeners {
+		listener := &gateway.Spec.Listeners[i]
+		listener.AllowedRoutes = &gatewayv1.AllowedRoutes{
+			Namespaces: &gatewayv1.RouteNamespaces{
+				From: &fromSelector,
+				Selector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{
+						Key:      namespaceNameLabel,
+						Operator: metav1.LabelSelectorOpIn,
+						Values:   []string{sourceNamespace},
+					}},
+				},
+			},
+		}
+		if listener.TLS == nil || gateway.Namespace == sourceNamespace {
+			continue
+		}
+		for j := range listener.TLS.CertificateRefs {
+			certificateRef := &listener.TLS.CertificateRefs[j]
+			if certificateRef.Namespace != nil {
+				continue
+			}
+			namespace := gatewayv1.Namespace(sourceNamespace)
+			certificateRef.Namespace = &namespace
+			if !slices.Contains(secrets, certificateRef.Name) {
+				secrets = append(secrets, certificateRef.Name)
+			}
+		}
+	}
+	if len(secrets) == 0 {
+		return
+	}
+
+	slices.Sort(secrets)
+	referenceGrant := gatewayv1beta1.ReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: sourceNamespace,
+			Name:      naming.Name(gateway.Namespace, gateway.Name, "secrets"),
+		},
+		Spec: gatewayv1beta1.ReferenceGrantSpec{
+			From: []gatewayv1beta1.ReferenceGrantFrom{{
+				Group:     gatewayv1.GroupName,
+				Kind:      "Gateway",
+				Namespace: gatewayv1.Namespace(gateway.Namespace),
+			}},
+		},
+	}
+	referenceGrant.SetGroupVersionKind(gatewayv1beta1.SchemeGroupVersion.WithKind("ReferenceGrant"))
+	for _, secret := range secrets {
+		name := secret
+		referenceGrant.Spec.To = append(referenceGrant.Spec.To, gatewayv1beta1.ReferenceGrantTo{
+			Group: "",
+			Kind:  "Secret",
+			Name:  &name,
+		})
+	}
+	if gatewayResources.ReferenceGrants == nil {
+		gatewayResources.ReferenceGrants = make(map[types.NamespacedName]gatewayv1beta1.ReferenceGrant)
+	}
+	gatewayResources.ReferenceGrants[types.NamespacedName{Namespace: referenceGrant.Namespace, Name: referenceGrant.Name}] = referenceGrant
+
+	referenceGrantRef := intermediate.ObjectRef{Kind: "ReferenceGrant", Namespace: referenceGrant.Namespace, Name: referenceGrant.Name}
+	for _, source := range gatewayResources.Sources[intermediate.ObjectRef{Kind: "Gateway", Namespace: key.Namespace, Name: key.Name}] {
+		intermediate.AddSource(gatewayResources.Sources, referenceGrantRef, source)
+	}
+}
+
+// mergeListeners adds listeners to the shared Gateway. The listeners with the
+// name of a listener of the Gateway must have the same hostname, port and
+// protocol, and only add the namespaces of their routes and their
+// certificates to it.
+func mergeListeners(gateway *gatewayv1.Gateway, listeners []gatewayv1.Listener, fldPath *field.Path) *field.Error {
+	merged := slices.Clone(gateway.Spec.Listeners)
+	for _, listener := range listeners {
+		i := slices.IndexFunc(merged, func(l gatewayv1.Listener) bool { return l.Name == listener.Name })
+		if i < 0 {
+			merged = append(merged, listener)
+			continue
+		}
+		existing := &merged[i]
+		if !apiequality.Semantic.DeepEqual(existing.Hostname, listener.Hostname) || existing.Port != listener.Port || existing.Protocol != listener.Protocol {
+			return field.Invalid(fldPath, listener.Name, fmt.Sprintf("listener conflicts with listener %s of shared Gateway %s/%s", existing.Name, gateway.Namespace, gateway.Name))
+		}
+		existing.AllowedRoutes = existing.AllowedRoutes.DeepCopy()
+		requirement := &existing.AllowedRoutes.Namespaces.Selector.MatchExpressions[0]
+		for _, namespace := range listener.AllowedRoutes.Namespaces.Selector.MatchExpressions[0].Values {
+			if !slices.Contains(requirement.Values, namespace) {
+				requirement.Values = append(requirement.Values, namespace)
+			}
+		}
+		slices.Sort(requirement.Values)
+		if listener.TLS == nil {
+			continue
+		}
+		if existing.TLS == nil {
+			existing.TLS = listener.TLS.DeepCopy()
+			continue
+		}
+		existing.TLS = existing.TLS.DeepCopy()
+		for _, certificateRef := range listener.TLS.CertificateRefs {
+			if !slices.ContainsFunc(existing.TLS.CertificateRefs, func(r gatewayv1.SecretObjectReference) bool {
+				return apiequality.Semantic.DeepEqual(r, certificateRef)
+			}) {
+				existing.TLS.CertificateRefs = append(existing.TLS.CertificateRefs, certificateRef)
+			}
+		}
+	}
+	gateway.Spec.Listeners = merged
+	return nil
+}
+
+// gatewayConfig returns the config of the Gateway generated with name, or the
+// default one if it is not listed.
+func gatewayConfig(gateways map[string]GatewayConfig, name string) (GatewayConfig, bool) {
+	if config, ok := gateways[name]; ok {
+		return config, true
+	}
+	config, ok := gateways[DefaultGatewayConfigKey]
+	return config, ok
+}
+
+// updateParentRefs points the parent references of a route in
+// routeNamespace to the renamed Gateways.
+func updateParentRefs(routeNamespace string, parentRefs []gatewayv1.ParentReference, renamedGateways map[types.NamespacedName]types.NamespacedName) {
+	for i := range parentRefs {
+		parentRef := &parentRefs[i]
+		if (parentRef.Group != nil && *parentRef.Group != gatewayv1.GroupName) || (parentRef.Kind != nil && *parentRef.Kind != "Gateway") {
+			continue
+		}
+		key := types.NamespacedName{Namespace: routeNamespace, Name: string(parentRef.Name)}
+		if parentRef.Namespace != nil {
+			key.Namespace = string(*parentRef.Namespace)
+		}
+		newKey, ok := renamedGateways[key]
+		if !ok {
+			continue
+		}
+		parentRef.Name = gatewayv1.ObjectName(newKey.Name)
+		if newKey.Namespace == routeNamespace {
+			parentRef.Namespace = nil
+		} else {
+			namespace := gatewayv1.Namespace(newKey.Namespace)
+			parentRef.Namespace = &namespace
+		}
+	}
+}
+
+// updateTargetRef points the target reference of a Gateway extension, which
+// is in the namespace of its target, to the renamed Gateway, and moves the
+// extension along with it.
+func updateTargetRef(extension *unstructured.Unstructured, renamedGateways map[types.NamespacedName]types.NamespacedName) {
+	kind, _, _ := unstructured.NestedString(extension.Object, "spec", "targetRef", "kind")
+	name, _, _ := unstructured.NestedString(extension.Object, "spec", "targetRef", "name")
+	if kind != "Gateway" {
+		return
+	}
+	newKey, ok := renamedGateways[types.NamespacedName{Namespace: extension.GetNamespace(), Name: name}]
+	if !ok {
+		return
+	}
+	_ = unstructured.SetNestedField(extension.Object, newKey.Name, "spec", "targetRef", "name")
+	extension.SetNamespace(newKey.Namespace)
+}
+
+// renameSources moves the sources of the renamed Gateways to their new names.
+func renameSources(sources map[intermediate.ObjectRef][]intermediate.ObjectRef, renamedGateways map[types.NamespacedName]types.NamespacedName) {
+	// The sources are all removed before being added back, as a Gateway may
+	// be renamed to the former name of another.
+	moved := make(map[types.NamespacedName][]intermediate.ObjectRef)
+	for key := range renamedGateways {
+		ref := intermediate.ObjectRef{Kind: "Gateway", Namespace: key.Namespace, Name: key.Name}
+		if gatewaySources, ok := sources[ref]; ok {
+			moved[key] = gatewaySources
+			delete(sources, ref)
+		}
+	}
+	for key, gatewaySources := range moved {
+		newKey := renamedGateways[key]
+		for _, source := range gatewaySources {
+			intermediate.AddSource(sources, intermediate.ObjectRef{Kind: "Gateway", Namespace: newKey.Namespace, Name: newKey.Name}, source)
+		}
+	}
+}
+
+func compareNamespacedNames(a, b types.NamespacedName) int {
+	return cmp.Or(
+		cmp.Compare(a.Namespace, b.Namespace),
+		cmp.Compare(a.Name, b.Name),
+	)
+}
+
+func compareBools(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case !a:
+		return -1
+	default:
+		return 1
+	}
+}
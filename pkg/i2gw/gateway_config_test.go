@@ -0,0 +1,282 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package i2gw
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/intermediate"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+func Test_ApplyGatewayConfigs(t *testing.T) {
+	gatewayKey := types.NamespacedName{Namespace: "apps", Name: "nginx"}
+	routeKey := types.NamespacedName{Namespace: "apps", Name: "web"}
+	ingressRef := intermediate.ObjectRef{Kind: "Ingress", Namespace: "apps", Name: "web"}
+
+	newGatewayResources := func() GatewayResources {
+		return GatewayResources{
+			Gateways: map[types.NamespacedName]gatewayv1.Gateway{
+				gatewayKey: {
+					ObjectMeta: metav1.ObjectMeta{Namespace: "apps", Name: "nginx"},
+					Spec: gatewayv1.GatewaySpec{
+						GatewayClassName: "nginx",
+						Listeners: []gatewayv1.Listener{{
+							Name:     "https",
+							Port:     443,
+							Protocol: gatewayv1.HTTPSProtocolType,
+							TLS: &gatewayv1.GatewayTLSConfig{
+								CertificateRefs: []gatewayv1.SecretObjectReference{{Name: "web-cert"}},
+							},
+						}},
+					},
+				},
+			},
+			HTTPRoutes: map[types.NamespacedName]gatewayv1.HTTPRoute{
+				routeKey: {
+					ObjectMeta: metav1.ObjectMeta{Namespace: "apps", Name: "web"},
+					Spec: gatewayv1.HTTPRouteSpec{
+						CommonRouteSpec: gatewayv1.CommonRouteSpec{
+							ParentRefs: []gatewayv1.ParentReference{{Name: "nginx"}, {Name: "other"}},
+						},
+					},
+				},
+			},
+			GatewayExtensions: []unstructured.Unstructured{{Object: map[string]interface{}{
+				"apiVersion": "networking.gke.io/v1",
+				"kind":       "GCPGatewayPolicy",
+				"metadata":   map[string]interface{}{"namespace": "apps", "name": "nginx"},
+				"spec":       map[string]interface{}{"targetRef": map[string]interface{}{"kind": "Gateway", "name": "nginx"}},
+			}}},
+			Sources: map[intermediate.ObjectRef][]intermediate.ObjectRef{
+				{Kind: "Gateway", Namespace: "apps", Name: "nginx"}: {ingressRef},
+			},
+		}
+	}
+
+	t.Run("rename in the same namespace", func(t *testing.T) {
+		gatewayResources := newGatewayResources()
+		errs := ApplyGatewayConfigs(&gatewayResources, map[string]GatewayConfig{
+			"nginx": {Name: "public", GatewayClassName: "nginx-gateway"},
+		})
+		if len(errs) != 0 {
+			t.Fatalf("Expected no errors, got %v", errs)
+		}
+
+		gateway, ok := gatewayResources.Gateways[types.NamespacedName{Namespace: "apps", Name: "public"}]
+		if !ok || len(gatewayResources.Gateways) != 1 {
+			t.Fatalf("Expected only Gateway apps/public, got %v", gatewayResources.Gateways)
+		}
+		if gateway.Name != "public" || gateway.Spec.GatewayClassName != "nginx-gateway" {
+			t.Errorf("Expected Gateway public of class nginx-gateway, got %s of class %s", gateway.Name, gateway.Spec.GatewayClassName)
+		}
+		if gateway.Spec.Listeners[0].AllowedRoutes != nil {
+			t.Errorf("Expected the listeners to be unchanged, got %+v", gateway.Spec.Listeners[0].AllowedRoutes)
+		}
+		expectedParentRefs := []gatewayv1.ParentReference{{Name: "public"}, {Name: "other"}}
+		if diff := cmp.Diff(expectedParentRefs, gatewayResources.HTTPRoutes[routeKey].Spec.ParentRefs); diff != "" {
+			t.Errorf("Unexpected parentRefs (-want +got):\n%s", diff)
+		}
+		if name, _, _ := unstructured.NestedString(gatewayResources.GatewayExtensions[0].Object, "spec", "targetRef", "name"); name != "public" {
+			t.Errorf("Expected the extension to target Gateway public, got %s", name)
+		}
+		expectedSources := map[intermediate.ObjectRef][]intermediate.ObjectRef{
+			{Kind: "Gateway", Namespace: "apps", Name: "public"}: {ingressRef},
+		}
+		if diff := cmp.Diff(expectedSources, gatewayResources.Sources); diff != "" {
+			t.Errorf("Unexpected sources (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("move to another namespace", func(t *testing.T) {
+		gatewayResources := newGatewayResources()
+		errs := ApplyGatewayConfigs(&gatewayResources, map[string]GatewayConfig{
+			"nginx": {Namespace: "infra"},
+		})
+		if len(errs) != 0 {
+			t.Fatalf("Expected no errors, got %v", errs)
+		}
+
+		gateway, ok := gatewayResources.Gateways[types.NamespacedName{Namespace: "infra", Name: "nginx"}]
+		if !ok {
+			t.Fatalf("Expected Gateway infra/nginx, got %v", gatewayResources.Gateways)
+		}
+		fromSelector := gatewayv1.NamespacesFromSelector
+		expectedAllowedRoutes := &gatewayv1.AllowedRoutes{
+			Namespaces: &gatewayv1.RouteNamespaces{
+				From: &fromSelector,
+				Selector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{
+						Key:      "kubernetes.io/metadata.name",
+						Operator: metav1.LabelSelectorOpIn,
+						Values:   []string{"apps"},
+					}},
+				},
+			},
+		}
+		if diff := cmp.Diff(expectedAllowedRoutes, gateway.Spec.Listeners[0].AllowedRoutes); diff != "" {
+			t.Errorf("Unexpected allowedRoutes (-want +got):\n%s", diff)
+		}
+		appsNamespace := gatewayv1.Namespace("apps")
+		expectedCertificateRefs := []gatewayv1.SecretObjectReference{{Name: "web-cert", Namespace: &appsNamespace}}
+		if diff := cmp.Diff(expectedCertificateRefs, gateway.Spec.Listeners[0].TLS.CertificateRefs); diff != "" {
+			t.Errorf("Unexpected certificateRefs (-want +got):\n%s", diff)
+		}
+
+		infraNamespace := gatewayv1.Namespace("infra")
+		expectedParentRefs := []gatewayv1.ParentReference{{Name: "nginx", Namespace: &infraNamespace}, {Name: "other"}}
+		if diff := cmp.Diff(expectedParentRefs, gatewayResources.HTTPRoutes[routeKey].Spec.ParentRefs); diff != "" {
+			t.Errorf("Unexpected parentRefs (-want +got):\n%s", diff)
+		}
+		if namespace := gatewayResources.GatewayExtensions[0].GetNamespace(); namespace != "infra" {
+			t.Errorf("Expected the extension to be moved to infra, got %s", namespace)
+		}
+
+		secretName := gatewayv1.ObjectName("web-cert")
+		expectedReferenceGrant := gatewayv1beta1.ReferenceGrant{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "apps", Name: "infra-nginx-secrets"},
+			Spec: gatewayv1beta1.ReferenceGrantSpec{
+				From: []gatewayv1beta1.ReferenceGrantFrom{{Group: gatewayv1.GroupName, Kind: "Gateway", Namespace: "infra"}},
+				To:   []gatewayv1beta1.ReferenceGrantTo{{Group: "", Kind: "Secret", Name: &secretName}},
+			},
+		}
+		expectedReferenceGrant.SetGroupVersionKind(gatewayv1beta1.SchemeGroupVersion.WithKind("ReferenceGrant"))
+		referenceGrant := gatewayResources.ReferenceGrants[types.NamespacedName{Namespace: "apps", Name: "infra-nginx-secrets"}]
+		if diff := cmp.Diff(expectedReferenceGrant, referenceGrant); diff != "" {
+			t.Errorf("Unexpected ReferenceGrant (-want +got):\n%s", diff)
+		}
+		expectedSources := map[intermediate.ObjectRef][]intermediate.ObjectRef{
+			{Kind: "Gateway", Namespace: "infra", Name: "nginx"}:                     {ingressRef},
+			{Kind: "ReferenceGrant", Namespace: "apps", Name: "infra-nginx-secrets"}: {ingressRef},
+		}
+		if diff := cmp.Diff(expectedSources, gatewayResources.Sources); diff != "" {
+			t.Errorf("Unexpected sources (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("shared Gateway", func(t *testing.T) {
+		gatewayResources := newGatewayResources()
+		// The same listener, and another one, are generated in namespace shop.
+		appsGateway := gatewayResources.Gateways[gatewayKey]
+		shopGateway := *appsGateway.DeepCopy()
+		shopGateway.Namespace = "shop"
+		shopGateway.Spec.Listeners[0].TLS.CertificateRefs[0].Name = "shop-cert"
+		shopGateway.Spec.Listeners = append(shopGateway.Spec.Listeners, gatewayv1.Listener{
+			Name:     "http",
+			Port:     80,
+			Protocol: gatewayv1.HTTPProtocolType,
+		})
+		gatewayResources.Gateways[types.NamespacedName{Namespace: "shop", Name: "nginx"}] = shopGateway
+		gatewayResources.Sources[intermediate.ObjectRef{Kind: "Gateway", Namespace: "shop", Name: "nginx"}] = []intermediate.ObjectRef{{Kind: "Ingress", Namespace: "shop", Name: "shop"}}
+
+		errs := ApplyGatewayConfigs(&gatewayResources, map[string]GatewayConfig{
+			DefaultGatewayConfigKey: {Namespace: "infra", Shared: true},
+		})
+		if len(errs) != 0 {
+			t.Fatalf("Expected no errors, got %v", errs)
+		}
+		if len(gatewayResources.Gateways) != 1 {
+			t.Fatalf("Expected a single Gateway, got %v", gatewayResources.Gateways)
+		}
+
+		gateway := gatewayResources.Gateways[types.NamespacedName{Namespace: "infra", Name: "nginx"}]
+		fromSelector := gatewayv1.NamespacesFromSelector
+		allowedRoutes := func(namespaces ...string) *gatewayv1.AllowedRoutes {
+			return &gatewayv1.AllowedRoutes{
+				Namespaces: &gatewayv1.RouteNamespaces{
+					From: &fromSelector,
+					Selector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{{
+							Key:      "kubernetes.io/metadata.name",
+							Operator: metav1.LabelSelectorOpIn,
+							Values:   namespaces,
+						}},
+					},
+				},
+			}
+		}
+		appsNamespace, shopNamespace := gatewayv1.Namespace("apps"), gatewayv1.Namespace("shop")
+		expectedListeners := []gatewayv1.Listener{
+			{
+				Name:          "https",
+				Port:          443,
+				Protocol:      gatewayv1.HTTPSProtocolType,
+				AllowedRoutes: allowedRoutes("apps", "shop"),
+				TLS: &gatewayv1.GatewayTLSConfig{
+					CertificateRefs: []gatewayv1.SecretObjectReference{
+						{Name: "web-cert", Namespace: &appsNamespace},
+						{Name: "shop-cert", Namespace: &shopNamespace},
+					},
+				},
+			},
+			{
+				Name:          "http",
+				Port:          80,
+				Protocol:      gatewayv1.HTTPProtocolType,
+				AllowedRoutes: allowedRoutes("shop"),
+			},
+		}
+		if diff := cmp.Diff(expectedListeners, gateway.Spec.Listeners); diff != "" {
+			t.Errorf("Unexpected listeners (-want +got):\n%s", diff)
+		}
+		for _, key := range []types.NamespacedName{{Namespace: "apps", Name: "infra-nginx-secrets"}, {Namespace: "shop", Name: "infra-nginx-secrets"}} {
+			if _, ok := gatewayResources.ReferenceGrants[key]; !ok {
+				t.Errorf("Expected ReferenceGrant %s, got %v", key, gatewayResources.ReferenceGrants)
+			}
+		}
+		expectedSources := []intermediate.ObjectRef{ingressRef, {Kind: "Ingress", Namespace: "shop", Name: "shop"}}
+		if diff := cmp.Diff(expectedSources, gatewayResources.Sources[intermediate.ObjectRef{Kind: "Gateway", Namespace: "infra", Name: "nginx"}]); diff != "" {
+			t.Errorf("Unexpected sources (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("conflicting shared listeners", func(t *testing.T) {
+		gatewayResources := newGatewayResources()
+		appsGateway := gatewayResources.Gateways[gatewayKey]
+		shopGateway := *appsGateway.DeepCopy()
+		shopGateway.Namespace = "shop"
+		shopGateway.Spec.Listeners[0].Port = 8443
+		gatewayResources.Gateways[types.NamespacedName{Namespace: "shop", Name: "nginx"}] = shopGateway
+
+		errs := ApplyGatewayConfigs(&gatewayResources, map[string]GatewayConfig{
+			"nginx": {Namespace: "infra", Shared: true},
+		})
+		if len(errs) != 1 || errs[0].Type != field.ErrorTypeInvalid {
+			t.Errorf("Expected a conflicting listener error, got %v", errs)
+		}
+	})
+
+	t.Run("conflicting placement", func(t *testing.T) {
+		gatewayResources := newGatewayResources()
+		gatewayResources.Gateways[types.NamespacedName{Namespace: "apps", Name: "public"}] = gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "apps", Name: "public"},
+		}
+		errs := ApplyGatewayConfigs(&gatewayResources, map[string]GatewayConfig{
+			"nginx": {Name: "public"},
+		})
+		expectedErrs := field.ErrorList{field.Duplicate(field.NewPath("gateways").Key("nginx"), "apps/public")}
+		if diff := cmp.Diff(expectedErrs, errs); diff != "" {
+			t.Errorf("Unexpected errors (-want +got):\n%s", diff)
+		}
+	})
+}
@@ -0,0 +1,264 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package i2gw
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/intermediate"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/naming"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// MaxGatewayListeners is the maximum number of listeners a Gateway can have.
+const MaxGatewayListeners = 64
+
+// gatewayShard is one of the Gateways a Gateway with too many listeners is
+// split into.
+type gatewayShard struct {
+	key       types.NamespacedName
+	listeners []gatewayv1.Listener
+}
+
+// ShardGateways splits the Gateways of gatewayResources which have more than
+// MaxGatewayListeners listeners into numbered Gateways. The first one keeps
+// the name of the Gateway, so that the hostnames it holds keep their
+// addresses, and the others are named <name>-2, <name>-3 and so on. The
+// listeners of a hostname are kept in the same Gateway, and the hostnames are
+// assigned to the Gateways in order, so that the split is the same on every
+// run.
+//
+// The parent references of the routes are pointed at the Gateways holding the
+// listeners they attach to. The Gateway extensions and the sources of a split
+// Gateway are copied to all its Gateways, and its addresses are only kept by
+// the first one. It returns the names of the Gateways every split Gateway was
+// split into.
+func ShardGateways(gatewayResources *GatewayResources) (map[types.NamespacedName][]types.NamespacedName, field.ErrorList) {
+	keys := make([]types.NamespacedName, 0, len(gatewayResources.Gateways))
+	for key, gateway := range gatewayResources.Gateways {
+		if len(gateway.Spec.Listeners) > MaxGatewayListeners {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	slices.SortFunc(keys, compareNamespacedNames)
+
+	var errs field.ErrorList
+	shardedGateways := make(map[types.NamespacedName][]gatewayShard)
+	for _, key := range keys {
+		gateway := gatewayResources.Gateways[key]
+		fieldPath := field.NewPath(fmt.Sprintf("%s/%s", key.Namespace, key.Name)).Child("spec", "listeners")
+		shards, err := shardListeners(key, gateway.Spec.Listeners, fieldPath)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if i := slices.IndexFunc(shards[1:], func(shard gatewayShard) bool {
+			_, exists := gatewayResources.Gateways[shard.key]
+			return exists
+		}); i >= 0 {
+			errs = append(errs, field.Duplicate(fieldPath, shards[i+1].key.String()))
+			continue
+		}
+		shardedGateways[key] = shards
+	}
+
+	shardKeys := make(map[types.NamespacedName][]types.NamespacedName, len(shardedGateways))
+	for key, shards := range shardedGateways {
+		gateway := gatewayResources.Gateways[key]
+		gatewayRef := intermediate.ObjectRef{Kind: "Gateway", Namespace: key.Namespace, Name: key.Name}
+		for i, shard := range shards {
+			shardGateway := *gateway.DeepCopy()
+			shardGateway.Name = shard.key.Name
+			shardGateway.Spec.Listeners = shard.listeners
+			if i > 0 {
+				shardGateway.Spec.Addresses = nil
+				shardRef := intermediate.ObjectRef{Kind: "Gateway", Namespace: shard.key.Namespace, Name: shard.key.Name}
+				for _, source := range gatewayResources.Sources[gatewayRef] {
+					intermediate.AddSource(gatewayResources.Sources, shardRef, source)
+				}
+			}
+			gatewayResources.Gateways[shard.key] = shardGateway
+			shardKeys[key] = append(shardKeys[key], shard.key)
+		}
+	}
+
+	for key, route := range gatewayResources.HTTPRoutes {
+		route.Spec.ParentRefs = shardParentRefs(route.Namespace, route.Spec.Hostnames, route.Spec.ParentRefs, shardedGateways)
+		gatewayResources.HTTPRoutes[key] = route
+	}
+	for key, route := range gatewayResources.TLSRoutes {
+		route.Spec.ParentRefs = shardParentRefs(route.Namespace, route.Spec.Hostnames, route.Spec.ParentRefs, shardedGateways)
+		gatewayResources.TLSRoutes[key] = route
+	}
+	for key, route := range gatewayResources.TCPRoutes {
+		route.Spec.ParentRefs = shardParentRefs(route.Namespace, nil, route.Spec.ParentRefs, shardedGateways)
+		gatewayResources.TCPRoutes[key] = route
+	}
+	for key, route := range gatewayResources.UDPRoutes {
+		route.Spec.ParentRefs = shardParentRefs(route.Namespace, nil, route.Spec.ParentRefs, shardedGateways)
+		gatewayResources.UDPRoutes[key] = route
+	}
+	gatewayResources.GatewayExtensions = shardGatewayExtensions(gatewayResources.GatewayExtensions, gatewayResources.Sources, shardedGateways)
+	return shardKeys, errs
+}
+
+// shardListeners splits the listeners of the Gateway of key into shards of at
+// most MaxGatewayListeners listeners, keeping the listeners of a hostname in
+// the same shard.
+func shardListeners(key types.NamespacedName, listeners []gatewayv1.Listener, fieldPath *field.Path) ([]gatewayShard, *field.Error) {
+	var hostnames []string
+	listenersByHostname := make(map[string][]gatewayv1.Listener)
+	for _, listener := range listeners {
+		var hostname string
+		if listener.Hostname != nil {
+			hostname = string(*listener.Hostname)
+		}
+		if _, ok := listenersByHostname[hostname]; !ok {
+			hostnames = append(hostnames, hostname)
+		}
+		listenersByHostname[hostname] = append(listenersByHostname[hostname], listener)
+	}
+	slices.Sort(hostnames)
+
+	shards := []gatewayShard{{key: key}}
+	for _, hostname := range hostnames {
+		hostnameListeners := listenersByHostname[hostname]
+		if len(hostnameListeners) > MaxGatewayListeners {
+			return nil, field.TooMany(fieldPath, len(hostnameListeners), MaxGatewayListeners)
+		}
+		shard := &shards[len(shards)-1]
+		if len(shard.listeners)+len(hostnameListeners) > MaxGatewayListeners {
+			shards = append(shards, gatewayShard{key: types.NamespacedName{
+				Namespace: key.Namespace,
+				Name:      naming.Name(key.Name, strconv.Itoa(len(shards)+1)),
+			}})
+			shard = &shards[len(shards)-1]
+		}
+		shard.listeners = append(shard.listeners, hostnameListeners...)
+	}
+	return shards, nil
+}
+
+// shardParentRefs returns the parent references of a route in routeNamespace
+// with the given hostnames, where the references to the split Gateways are
+// replaced by references to the shards holding the listeners the route
+// attaches to. A reference which attaches to no listener is kept on the first
+// shard.
+func shardParentRefs(routeNamespace string, hostnames []gatewayv1.Hostname, parentRefs []gatewayv1.ParentReference, shardedGateways map[types.NamespacedName][]gatewayShard) []gatewayv1.ParentReference {
+	var sharded []gatewayv1.ParentReference
+	for _, parentRef := range parentRefs {
+		if (parentRef.Group != nil && *parentRef.Group != gatewayv1.GroupName) || (parentRef.Kind != nil && *parentRef.Kind != "Gateway") {
+			sharded = append(sharded, parentRef)
+			continue
+		}
+		key := types.NamespacedName{Namespace: routeNamespace, Name: string(parentRef.Name)}
+		if parentRef.Namespace != nil {
+			key.Namespace = string(*parentRef.Namespace)
+		}
+		shards, ok := shardedGateways[key]
+		if !ok {
+			sharded = append(sharded, parentRef)
+			continue
+		}
+		var attached bool
+		for _, shard := range shards {
+			if !slices.ContainsFunc(shard.listeners, func(listener gatewayv1.Listener) bool {
+				return AttachesToListener(parentRef, hostnames, listener)
+			}) {
+				continue
+			}
+			shardParentRef := *parentRef.DeepCopy()
+			shardParentRef.Name = gatewayv1.ObjectName(shard.key.Name)
+			sharded = append(sharded, shardParentRef)
+			attached = true
+		}
+		if !attached {
+			sharded = append(sharded, parentRef)
+		}
+	}
+	return sharded
+}
+
+// AttachesToListener returns whether a route with the given hostnames and
+// parent reference attaches to listener: the listener matches the sectionName
+// and port of the reference, if any, and its hostname, if any, intersects the
+// hostnames of the route, if any, wildcards included.
+func AttachesToListener(parentRef gatewayv1.ParentReference, hostnames []gatewayv1.Hostname, listener gatewayv1.Listener) bool {
+	if parentRef.SectionName != nil && *parentRef.SectionName != listener.Name {
+		return false
+	}
+	if parentRef.Port != nil && *parentRef.Port != listener.Port {
+		return false
+	}
+	if listener.Hostname == nil || *listener.Hostname == "" || len(hostnames) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(hostnames, func(hostname gatewayv1.Hostname) bool {
+		return hostnameMatches(string(*listener.Hostname), string(hostname)) || hostnameMatches(string(hostname), string(*listener.Hostname))
+	})
+}
+
+// hostnameMatches returns whether hostname, which may be a wildcard, is
+// matched by pattern, e.g. foo.example.com and *.foo.example.com by
+// *.example.com.
+func hostnameMatches(pattern, hostname string) bool {
+	if pattern == hostname {
+		return true
+	}
+	suffix, ok := strings.CutPrefix(pattern, "*")
+	return ok && len(hostname) > len(suffix) && strings.HasSuffix(hostname, suffix)
+}
+
+// shardGatewayExtensions returns the Gateway extensions, each followed by a
+// copy for every shard but the first of the Gateway it targets, if it was
+// split, named after the extension with the suffix of the shard.
+func shardGatewayExtensions(extensions []unstructured.Unstructured, sources map[intermediate.ObjectRef][]intermediate.ObjectRef, shardedGateways map[types.NamespacedName][]gatewayShard) []unstructured.Unstructured {
+	var sharded []unstructured.Unstructured
+	for _, extension := range extensions {
+		sharded = append(sharded, extension)
+		kind, _, _ := unstructured.NestedString(extension.Object, "spec", "targetRef", "kind")
+		name, _, _ := unstructured.NestedString(extension.Object, "spec", "targetRef", "name")
+		shards, ok := shardedGateways[types.NamespacedName{Namespace: extension.GetNamespace(), Name: name}]
+		if kind != "Gateway" || !ok {
+			continue
+		}
+		extensionRef := intermediate.ObjectRef{Kind: extension.GetKind(), Namespace: extension.GetNamespace(), Name: extension.GetName()}
+		for i, shard := range shards {
+			if i == 0 {
+				continue
+			}
+			shardExtension := *extension.DeepCopy()
+			shardExtension.SetName(naming.Name(extension.GetName(), strconv.Itoa(i+1)))
+			_ = unstructured.SetNestedField(shardExtension.Object, shard.key.Name, "spec", "targetRef", "name")
+			sharded = append(sharded, shardExtension)
+			shardExtensionRef := intermediate.ObjectRef{Kind: shardExtension.GetKind(), Namespace: shardExtension.GetNamespace(), Name: shardExtension.GetName()}
+			for _, source := range sources[extensionRef] {
+				intermediate.AddSource(sources, shardExtensionRef, source)
+			}
+		}
+	}
+	return sharded
+}
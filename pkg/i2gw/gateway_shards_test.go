@@ -0,0 +1,217 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package i2gw
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/intermediate"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/naming"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func Test_ShardGateways(t *testing.T) {
+	gatewayKey := types.NamespacedName{Namespace: "apps", Name: "nginx"}
+	shardKey := types.NamespacedName{Namespace: "apps", Name: "nginx-2"}
+	ingressRef := intermediate.ObjectRef{Kind: "Ingress", Namespace: "apps", Name: "web"}
+	section := gatewayv1.SectionName("host-39-example-com-https")
+
+	// newGatewayResources returns a Gateway with an HTTP and an HTTPS listener
+	// for each of the given number of hosts, and a route per test case.
+	newGatewayResources := func(hosts int) GatewayResources {
+		gateway := gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "apps", Name: "nginx"},
+			Spec: gatewayv1.GatewaySpec{
+				GatewayClassName: "nginx",
+				Addresses:        []gatewayv1.GatewayAddress{{Value: "10.0.0.1"}},
+			},
+		}
+		for i := 0; i < hosts; i++ {
+			hostname := gatewayv1.Hostname(fmt.Sprintf("host-%02d.example.com", i))
+			gateway.Spec.Listeners = append(gateway.Spec.Listeners, gatewayv1.Listener{
+				Name:     gatewayv1.SectionName(fmt.Sprintf("host-%02d-example-com-http", i)),
+				Hostname: &hostname,
+				Port:     80,
+				Protocol: gatewayv1.HTTPProtocolType,
+			}, gatewayv1.Listener{
+				Name:     gatewayv1.SectionName(fmt.Sprintf("host-%02d-example-com-https", i)),
+				Hostname: &hostname,
+				Port:     443,
+				Protocol: gatewayv1.HTTPSProtocolType,
+			})
+		}
+		route := func(name string, parentRef gatewayv1.ParentReference, hostnames ...gatewayv1.Hostname) gatewayv1.HTTPRoute {
+			return gatewayv1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "apps", Name: name},
+				Spec: gatewayv1.HTTPRouteSpec{
+					CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: []gatewayv1.ParentReference{parentRef, {Name: "other"}}},
+					Hostnames:       hostnames,
+				},
+			}
+		}
+		return GatewayResources{
+			Gateways: map[types.NamespacedName]gatewayv1.Gateway{gatewayKey: gateway},
+			HTTPRoutes: map[types.NamespacedName]gatewayv1.HTTPRoute{
+				{Namespace: "apps", Name: "first"}:    route("first", gatewayv1.ParentReference{Name: "nginx"}, "host-00.example.com"),
+				{Namespace: "apps", Name: "last"}:     route("last", gatewayv1.ParentReference{Name: "nginx"}, "host-39.example.com"),
+				{Namespace: "apps", Name: "wildcard"}: route("wildcard", gatewayv1.ParentReference{Name: "nginx"}, "*.example.com"),
+				{Namespace: "apps", Name: "section"}:  route("section", gatewayv1.ParentReference{Name: "nginx", SectionName: &section}),
+				{Namespace: "apps", Name: "unknown"}:  route("unknown", gatewayv1.ParentReference{Name: "nginx"}, "unknown.example.net"),
+			},
+			GatewayExtensions: []unstructured.Unstructured{{Object: map[string]interface{}{
+				"apiVersion": "networking.gke.io/v1",
+				"kind":       "GCPGatewayPolicy",
+				"metadata":   map[string]interface{}{"namespace": "apps", "name": "nginx"},
+				"spec":       map[string]interface{}{"targetRef": map[string]interface{}{"kind": "Gateway", "name": "nginx"}},
+			}}},
+			Sources: map[intermediate.ObjectRef][]intermediate.ObjectRef{
+				{Kind: "Gateway", Namespace: "apps", Name: "nginx"}:          {ingressRef},
+				{Kind: "GCPGatewayPolicy", Namespace: "apps", Name: "nginx"}: {ingressRef},
+			},
+		}
+	}
+
+	t.Run("gateway within the limit", func(t *testing.T) {
+		gatewayResources := newGatewayResources(32)
+		shards, errs := ShardGateways(&gatewayResources)
+		if len(errs) != 0 {
+			t.Fatalf("Expected no errors, got %v", errs)
+		}
+		if len(shards) != 0 || len(gatewayResources.Gateways) != 1 {
+			t.Fatalf("Expected Gateway apps/nginx not to be split, got %v", gatewayResources.Gateways)
+		}
+		expectedParentRefs := []gatewayv1.ParentReference{{Name: "nginx"}, {Name: "other"}}
+		if diff := cmp.Diff(expectedParentRefs, gatewayResources.HTTPRoutes[types.NamespacedName{Namespace: "apps", Name: "last"}].Spec.ParentRefs); diff != "" {
+			t.Errorf("Unexpected parentRefs (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("gateway over the limit", func(t *testing.T) {
+		gatewayResources := newGatewayResources(40)
+		shards, errs := ShardGateways(&gatewayResources)
+		if len(errs) != 0 {
+			t.Fatalf("Expected no errors, got %v", errs)
+		}
+
+		expectedShards := map[types.NamespacedName][]types.NamespacedName{gatewayKey: {gatewayKey, shardKey}}
+		if diff := cmp.Diff(expectedShards, shards); diff != "" {
+			t.Errorf("Unexpected shards (-want +got):\n%s", diff)
+		}
+		gateway, shard := gatewayResources.Gateways[gatewayKey], gatewayResources.Gateways[shardKey]
+		if len(gateway.Spec.Listeners) != MaxGatewayListeners || len(shard.Spec.Listeners) != 16 {
+			t.Fatalf("Expected %d and 16 listeners, got %d and %d", MaxGatewayListeners, len(gateway.Spec.Listeners), len(shard.Spec.Listeners))
+		}
+		if name := shard.Spec.Listeners[0].Name; name != "host-32-example-com-http" {
+			t.Errorf("Expected the second Gateway to start with listener host-32-example-com-http, got %s", name)
+		}
+		if shard.Name != "nginx-2" || shard.Spec.GatewayClassName != "nginx" {
+			t.Errorf("Expected Gateway nginx-2 of class nginx, got %s of class %s", shard.Name, shard.Spec.GatewayClassName)
+		}
+		if len(gateway.Spec.Addresses) != 1 || len(shard.Spec.Addresses) != 0 {
+			t.Errorf("Expected the addresses to be kept by the first Gateway only, got %v and %v", gateway.Spec.Addresses, shard.Spec.Addresses)
+		}
+
+		expectedParentRefs := map[string][]gatewayv1.ParentReference{
+			"first":    {{Name: "nginx"}, {Name: "other"}},
+			"last":     {{Name: "nginx-2"}, {Name: "other"}},
+			"wildcard": {{Name: "nginx"}, {Name: "nginx-2"}, {Name: "other"}},
+			"section":  {{Name: "nginx-2", SectionName: &section}, {Name: "other"}},
+			"unknown":  {{Name: "nginx"}, {Name: "other"}},
+		}
+		for name, expected := range expectedParentRefs {
+			if diff := cmp.Diff(expected, gatewayResources.HTTPRoutes[types.NamespacedName{Namespace: "apps", Name: name}].Spec.ParentRefs); diff != "" {
+				t.Errorf("Unexpected parentRefs of route %s (-want +got):\n%s", name, diff)
+			}
+		}
+
+		if len(gatewayResources.GatewayExtensions) != 2 {
+			t.Fatalf("Expected 2 extensions, got %d", len(gatewayResources.GatewayExtensions))
+		}
+		extension := gatewayResources.GatewayExtensions[1]
+		if name, _, _ := unstructured.NestedString(extension.Object, "spec", "targetRef", "name"); extension.GetName() != "nginx-2" || name != "nginx-2" {
+			t.Errorf("Expected extension nginx-2 to target Gateway nginx-2, got %s targeting %s", extension.GetName(), name)
+		}
+		expectedSources := map[intermediate.ObjectRef][]intermediate.ObjectRef{
+			{Kind: "Gateway", Namespace: "apps", Name: "nginx"}:            {ingressRef},
+			{Kind: "Gateway", Namespace: "apps", Name: "nginx-2"}:          {ingressRef},
+			{Kind: "GCPGatewayPolicy", Namespace: "apps", Name: "nginx"}:   {ingressRef},
+			{Kind: "GCPGatewayPolicy", Namespace: "apps", Name: "nginx-2"}: {ingressRef},
+		}
+		if diff := cmp.Diff(expectedSources, gatewayResources.Sources); diff != "" {
+			t.Errorf("Unexpected sources (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("hostname over the limit", func(t *testing.T) {
+		gatewayResources := newGatewayResources(0)
+		gateway := gatewayResources.Gateways[gatewayKey]
+		for i := 0; i <= MaxGatewayListeners; i++ {
+			gateway.Spec.Listeners = append(gateway.Spec.Listeners, gatewayv1.Listener{
+				Name:     gatewayv1.SectionName(fmt.Sprintf("port-%d", 8000+i)),
+				Port:     gatewayv1.PortNumber(8000 + i),
+				Protocol: gatewayv1.HTTPProtocolType,
+			})
+		}
+		gatewayResources.Gateways[gatewayKey] = gateway
+
+		_, errs := ShardGateways(&gatewayResources)
+		if len(errs) != 1 {
+			t.Fatalf("Expected 1 error, got %v", errs)
+		}
+		if len(gatewayResources.Gateways) != 1 {
+			t.Errorf("Expected Gateway apps/nginx not to be split, got %v", gatewayResources.Gateways)
+		}
+	})
+}
+
+func Test_shardGatewayExtensions(t *testing.T) {
+	// The name of the shard is truncated with a hash, so it does not start
+	// with the name of the Gateway.
+	gatewayName := strings.Repeat("a", naming.MaxLength)
+	shardName := naming.Name(gatewayName, "2")
+	extensionName := strings.Repeat("b", naming.MaxLength)
+	extensions := []unstructured.Unstructured{{Object: map[string]interface{}{
+		"apiVersion": "networking.gke.io/v1",
+		"kind":       "GCPGatewayPolicy",
+		"metadata":   map[string]interface{}{"namespace": "apps", "name": extensionName},
+		"spec":       map[string]interface{}{"targetRef": map[string]interface{}{"kind": "Gateway", "name": gatewayName}},
+	}}}
+	shards := map[types.NamespacedName][]gatewayShard{
+		{Namespace: "apps", Name: gatewayName}: {
+			{key: types.NamespacedName{Namespace: "apps", Name: gatewayName}},
+			{key: types.NamespacedName{Namespace: "apps", Name: shardName}},
+		},
+	}
+
+	sharded := shardGatewayExtensions(extensions, map[intermediate.ObjectRef][]intermediate.ObjectRef{}, shards)
+	if len(sharded) != 2 {
+		t.Fatalf("Expected 2 extensions, got %d", len(sharded))
+	}
+	extension := sharded[1]
+	if name := extension.GetName(); name != naming.Name(extensionName, "2") || len(name) > naming.MaxLength {
+		t.Errorf("Expected extension %s, got %s", naming.Name(extensionName, "2"), name)
+	}
+	if name, _, _ := unstructured.NestedString(extension.Object, "spec", "targetRef", "name"); name != shardName {
+		t.Errorf("Expected the extension to target Gateway %s, got %s", shardName, name)
+	}
+}
@@ -18,11 +18,15 @@ package i2gw
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
@@ -32,64 +36,199 @@ const GeneratorAnnotationKey = "gateway.networking.k8s.io/generator"
 
 var CurrentVersion = "0.3.0"
 
-func ToGatewayAPIResources(ctx context.Context, namespace string, inputFile string, providers []string, providerSpecificFlags map[string]map[string]string) ([]GatewayResources, map[string]string, error) {
+// ToGatewayAPIResources reads the resources of the providers from the input
+// files, or from the cluster if there are none, and converts them to Gateway
+// API resources. See ExpandInputFiles for the supported input files.
+func ToGatewayAPIResources(ctx context.Context, namespace string, inputFiles []string, providers []string, providerSpecificFlags map[string]map[string]string) ([]GatewayResources, map[string]string, error) {
+	return ToGatewayAPIResourcesWithOptions(ctx, namespace, inputFiles, providers, providerSpecificFlags, ConversionOptions{})
+}
+
+// ConversionOptions tune how ToGatewayAPIResourcesWithOptions converts the
+// resources.
+type ConversionOptions struct {
+	// Gateways configures the generated Gateways, by generated name. See
+	// ApplyGatewayConfigs.
+	Gateways map[string]GatewayConfig
+	// DisabledFeatures lists the features which are not converted, by
+	// provider. See ParseFeatures.
+	DisabledFeatures map[ProviderName][]string
+	// Tracer, if not nil, is called after every stage of the conversion of
+	// every provider.
+	Tracer Tracer
+	// Client, if not nil, is used to read the resources from the cluster
+	// instead of a client of the current kubeconfig context.
+	Client client.Client
+	// Notifications, if not nil, collects the notifications of the
+	// conversion. Otherwise, they are collected for this conversion only.
+	Notifications *notifications.NotificationAggregator
+	// BestEffort skips the Ingresses, rules and paths which can not be
+	// converted, instead of failing the whole conversion. The resources of
+	// the others are returned along with the *ConversionError holding the
+	// errors. See ProviderConf.BestEffort.
+	BestEffort bool
+	// NotificationConsumers receive the notifications of the conversion as
+	// they are sent. They are ignored if Notifications is set, which
+	// forwards the notifications to its own consumers.
+	NotificationConsumers []notifications.Consumer
+	// InputFiles holds the manifests read in memory for this conversion, by
+	// name, such as the standard input read with ReadStdinInputFile or the
+	// manifests rendered with RenderHelmChart. See ProviderConf.InputFiles.
+	InputFiles map[string][]byte
+}
+
+// ToGatewayAPIResourcesWithOptions is ToGatewayAPIResources, tuned by opts.
+func ToGatewayAPIResourcesWithOptions(ctx context.Context, namespace string, inputFiles []string, providers []string, providerSpecificFlags map[string]map[string]string, opts ConversionOptions) ([]GatewayResources, map[string]string, error) {
 	var clusterClient client.Client
 
-	if inputFile == "" {
-		conf, err := config.GetConfig()
-		if err != nil {
-			return nil, nil, fmt.Errorf("failed to get client config: %w", err)
+	if len(inputFiles) == 0 {
+		cl := opts.Client
+		if cl == nil {
+			var err error
+			if cl, err = NewClusterClient(); err != nil {
+				return nil, nil, err
+			}
 		}
+		clusterClient = client.NewNamespacedClient(cl, namespace)
+	}
 
-		cl, err := client.New(conf, client.Options{})
-		if err != nil {
-			return nil, nil, fmt.Errorf("failed to create client: %w", err)
+	var filenames []string
+	var inputFileData map[string][]byte
+	if len(inputFiles) > 0 {
+		var err error
+		if filenames, err = ExpandInputFiles(inputFiles, opts.InputFiles); err != nil {
+			return nil, nil, err
+		}
+		if inputFileData, err = ReadInputFiles(filenames, opts.InputFiles); err != nil {
+			return nil, nil, err
 		}
-		clusterClient = client.NewNamespacedClient(cl, namespace)
 	}
 
-	providerByName, err := constructProviders(&ProviderConf{
+	if opts.Notifications == nil {
+		opts.Notifications = notifications.NewNotificationAggregator(opts.NotificationConsumers...)
+	}
+	conf := &ProviderConf{
 		Client:                clusterClient,
 		Namespace:             namespace,
 		ProviderSpecificFlags: providerSpecificFlags,
-	}, providers)
-	if err != nil {
+		DisabledFeatures:      opts.DisabledFeatures,
+		Tracer:                opts.Tracer,
+		Notifications:         opts.Notifications,
+		BestEffort:            opts.BestEffort,
+		InputFiles:            inputFileData,
+	}
+	gatewayResources, err := convert(ctx, conf, providers, filenames, opts.Gateways)
+	var conversionErr *ConversionError
+	if err != nil && !errors.As(err, &conversionErr) {
 		return nil, nil, err
 	}
+	notificationTablesMap := opts.Notifications.CreateNotificationTables()
+	if conversionErr != nil {
+		if opts.BestEffort {
+			return gatewayResources, notificationTablesMap, conversionErr
+		}
+		return nil, notificationTablesMap, conversionErr
+	}
 
-	if inputFile != "" {
-		if err = readProviderResourcesFromFile(ctx, providerByName, inputFile); err != nil {
-			return nil, nil, err
+	return gatewayResources, notificationTablesMap, nil
+}
+
+// convert reads the resources of the providers from the files, or from the
+// client of conf if there are none, and converts them to Gateway API
+// resources. If providers failed to convert some of the resources, the
+// resources which could be converted are returned along with a
+// *ConversionError.
+func convert(ctx context.Context, conf *ProviderConf, providers []string, filenames []string, gateways map[string]GatewayConfig) ([]GatewayResources, error) {
+	providerByName, err := constructProviders(conf, providers)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(filenames) > 0 {
+		if err = readProviderResourcesFromFiles(ctx, providerByName, filenames); err != nil {
+			return nil, err
 		}
 	} else {
 		if err = readProviderResourcesFromCluster(ctx, providerByName); err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 	}
 
 	var (
 		gatewayResources []GatewayResources
-		errs             field.ErrorList
+		errs             = make(map[ProviderName]field.ErrorList)
 	)
-	for _, provider := range providerByName {
+	// The providers are converted in the order of their names, so that the
+	// resources are returned in the same order on every run.
+	names := make([]ProviderName, 0, len(providerByName))
+	for name := range providerByName {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		provider := providerByName[name]
 		ir, conversionErrs := provider.ToIR()
-		errs = append(errs, conversionErrs...)
+		errs[name] = append(errs[name], conversionErrs...)
+		conf.TraceIR(name, TraceStageToIR, ir)
 		providerGatewayResources, conversionErrs := provider.ToGatewayResources(ir)
-		errs = append(errs, conversionErrs...)
+		errs[name] = append(errs[name], conversionErrs...)
+		errs[name] = append(errs[name], ApplyGatewayConfigs(&providerGatewayResources, gateways)...)
+		shards, shardErrs := ShardGateways(&providerGatewayResources)
+		errs[name] = append(errs[name], shardErrs...)
+		notifyGatewayShards(conf, name, providerGatewayResources, shards)
+		if conf.Tracer != nil {
+			conf.Tracer.TraceGatewayResources(name, providerGatewayResources)
+		}
+		if len(errs[name]) == 0 {
+			delete(errs, name)
+		}
 		gatewayResources = append(gatewayResources, providerGatewayResources)
 	}
-	notificationTablesMap := notifications.NotificationAggr.CreateNotificationTables()
 	if len(errs) > 0 {
-		return nil, notificationTablesMap, aggregatedErrs(errs)
+		return gatewayResources, &ConversionError{Errors: errs}
 	}
+	return gatewayResources, nil
+}
 
-	return gatewayResources, notificationTablesMap, nil
+// notifyGatewayShards sends a notification for every Gateway which was split
+// into shards.
+func notifyGatewayShards(conf *ProviderConf, provider ProviderName, gatewayResources GatewayResources, shards map[types.NamespacedName][]types.NamespacedName) {
+	keys := make([]types.NamespacedName, 0, len(shards))
+	for key := range shards {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, compareNamespacedNames)
+	for _, key := range keys {
+		var names []string
+		var gateways []client.Object
+		for _, shardKey := range shards[key] {
+			gateway := gatewayResources.Gateways[shardKey]
+			names = append(names, shardKey.Name)
+			gateways = append(gateways, &gateway)
+		}
+		message := fmt.Sprintf("Gateway %s has more than %d listeners, and was split into the Gateways %s", key, MaxGatewayListeners, strings.Join(names, ", "))
+		conf.Notify(provider, notifications.NewNotification(notifications.InfoNotification, message, gateways...))
+	}
 }
 
-func readProviderResourcesFromFile(ctx context.Context, providerByName map[ProviderName]Provider, inputFile string) error {
+// NewClusterClient creates a client for the cluster of the current kubeconfig
+// context.
+func NewClusterClient() (client.Client, error) {
+	conf, err := config.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client config: %w", err)
+	}
+
+	cl, err := client.New(conf, client.Options{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+	return cl, nil
+}
+
+func readProviderResourcesFromFiles(ctx context.Context, providerByName map[ProviderName]Provider, filenames []string) error {
 	for name, provider := range providerByName {
-		if err := provider.ReadResourcesFromFile(ctx, inputFile); err != nil {
-			return fmt.Errorf("failed to read %s resources from file: %w", name, err)
+		if err := provider.ReadResourcesFromFiles(ctx, filenames); err != nil {
+			return fmt.Errorf("failed to read %s resources from files: %w", name, err)
 		}
 	}
 	return nil
@@ -122,6 +261,27 @@ func constructProviders(conf *ProviderConf, providers []string) (map[ProviderNam
 	return providerByName, nil
 }
 
+// ConversionError is returned by ToGatewayAPIResources when providers failed
+// to convert some of the resources.
+type ConversionError struct {
+	// Errors holds the errors of every provider which failed.
+	Errors map[ProviderName]field.ErrorList
+}
+
+func (e *ConversionError) Error() string {
+	names := make([]ProviderName, 0, len(e.Errors))
+	for name := range e.Errors {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var errs field.ErrorList
+	for _, name := range names {
+		errs = append(errs, e.Errors[name]...)
+	}
+	return aggregatedErrs(errs).Error()
+}
+
 func aggregatedErrs(errs field.ErrorList) error {
 	errMsg := fmt.Errorf("\n# Encountered %d errors", len(errs))
 	for _, err := range errs {
@@ -136,6 +296,7 @@ func GetSupportedProviders() []string {
 	for key := range ProviderConstructorByName {
 		supportedProviders = append(supportedProviders, string(key))
 	}
+	slices.Sort(supportedProviders)
 	return supportedProviders
 }
 
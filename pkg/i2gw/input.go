@@ -0,0 +1,171 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package i2gw
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// StdinFilename is the input file standing for the standard input.
+const StdinFilename = "-"
+
+// inputFileExtensions are the extensions of the files read from input
+// directories.
+var inputFileExtensions = []string{".yaml", ".yml", ".json"}
+
+// ExpandInputFiles resolves the input files to the list of files to read:
+//   - Files in inputFiles, such as the standard input read with
+//     ReadStdinInputFile or the manifests rendered with RenderHelmChart, are
+//     read from memory.
+//   - Glob patterns are expanded, and must match at least one file.
+//   - Directories are walked recursively for .yaml, .yml and .json files.
+//
+// Files are returned in the order of the inputs, sorted by path within a
+// directory or a glob pattern. A file matched by several inputs is only
+// returned once.
+func ExpandInputFiles(inputs []string, inputFiles map[string][]byte) ([]string, error) {
+	var filenames []string
+	seen := make(map[string]bool)
+	add := func(filename string) {
+		if !seen[filename] {
+			seen[filename] = true
+			filenames = append(filenames, filename)
+		}
+	}
+
+	for _, input := range inputs {
+		if _, ok := inputFiles[input]; ok {
+			add(input)
+			continue
+		}
+
+		paths := []string{input}
+		if isGlobPattern(input) {
+			matches, err := filepath.Glob(input)
+			if err != nil {
+				return nil, fmt.Errorf("invalid input file pattern %s: %w", input, err)
+			}
+			if len(matches) == 0 {
+				return nil, fmt.Errorf("no input files match %s", input)
+			}
+			paths = matches
+		}
+
+		for _, path := range paths {
+			info, err := os.Stat(path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read input file: %w", err)
+			}
+			if !info.IsDir() {
+				add(path)
+				continue
+			}
+			dirFilenames, err := readInputDir(path)
+			if err != nil {
+				return nil, err
+			}
+			for _, filename := range dirFilenames {
+				add(filename)
+			}
+		}
+	}
+
+	return filenames, nil
+}
+
+// readInputDir returns the files with one of the inputFileExtensions in dir
+// and its subdirectories, sorted by path.
+func readInputDir(dir string) ([]string, error) {
+	var filenames []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && slices.Contains(inputFileExtensions, strings.ToLower(filepath.Ext(path))) {
+			filenames = append(filenames, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input directory %s: %w", dir, err)
+	}
+	slices.Sort(filenames)
+	return filenames, nil
+}
+
+func isGlobPattern(path string) bool {
+	return strings.ContainsAny(path, "*?[")
+}
+
+// ReadStdinInputFile reads r into inputFiles as the input file
+// StdinFilename, if it is one of the inputs. The standard input can only be
+// read once, so it is read before the conversion, and then from memory by
+// every provider.
+func ReadStdinInputFile(inputs []string, r io.Reader, inputFiles map[string][]byte) error {
+	if !slices.Contains(inputs, StdinFilename) {
+		return nil
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("failed to read stdin: %w", err)
+	}
+	inputFiles[StdinFilename] = data
+	return nil
+}
+
+// InputFileNames returns the names of the input files, sorted.
+func InputFileNames(inputFiles map[string][]byte) []string {
+	filenames := make([]string, 0, len(inputFiles))
+	for filename := range inputFiles {
+		filenames = append(filenames, filename)
+	}
+	slices.Sort(filenames)
+	return filenames
+}
+
+// ReadInputFiles reads the named input files from the file system, except
+// those already in inputFiles, and returns them along with inputFiles.
+func ReadInputFiles(filenames []string, inputFiles map[string][]byte) (map[string][]byte, error) {
+	files := make(map[string][]byte, len(filenames))
+	maps.Copy(files, inputFiles)
+	for _, filename := range filenames {
+		if _, ok := files[filename]; ok {
+			continue
+		}
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read input file: %w", err)
+		}
+		files[filename] = data
+	}
+	return files, nil
+}
+
+// InputFileDisplayName returns the name of the input file shown to the user.
+func InputFileDisplayName(filename string) string {
+	if filename == StdinFilename {
+		return "stdin"
+	}
+	return filename
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package i2gw
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_ExpandInputFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.yaml", "b.json", "notes.txt", "sub/c.yml", "sub/d.yaml"} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	path := func(name string) string {
+		return filepath.Join(dir, name)
+	}
+
+	testCases := []struct {
+		name          string
+		inputs        []string
+		inputFiles    map[string][]byte
+		expected      []string
+		expectedError bool
+	}{
+		{
+			name:       "files and stdin",
+			inputs:     []string{path("sub/d.yaml"), "-", path("a.yaml")},
+			inputFiles: map[string][]byte{StdinFilename: []byte("")},
+			expected:   []string{path("sub/d.yaml"), "-", path("a.yaml")},
+		},
+		{
+			name:     "directory is read recursively",
+			inputs:   []string{dir},
+			expected: []string{path("a.yaml"), path("b.json"), path("sub/c.yml"), path("sub/d.yaml")},
+		},
+		{
+			name:     "glob pattern",
+			inputs:   []string{path("*.yaml"), path("sub/*")},
+			expected: []string{path("a.yaml"), path("sub/c.yml"), path("sub/d.yaml")},
+		},
+		{
+			name:     "files matched several times are returned once",
+			inputs:   []string{path("sub/d.yaml"), path("sub")},
+			expected: []string{path("sub/d.yaml"), path("sub/c.yml")},
+		},
+		{
+			name:          "stdin not read",
+			inputs:        []string{"-"},
+			expectedError: true,
+		},
+		{
+			name:          "glob pattern matching no file",
+			inputs:        []string{path("*.xml")},
+			expectedError: true,
+		},
+		{
+			name:          "missing file",
+			inputs:        []string{path("missing.yaml")},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ExpandInputFiles(tc.inputs, tc.inputFiles)
+			if (err != nil) != tc.expectedError {
+				t.Fatalf("Expected error %t but got %v", tc.expectedError, err)
+			}
+			if diff := cmp.Diff(tc.expected, got); diff != "" {
+				t.Errorf("Unexpected files (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
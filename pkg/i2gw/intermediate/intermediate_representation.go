@@ -17,6 +17,8 @@ limitations under the License.
 package intermediate
 
 import (
+	"fmt"
+
 	"k8s.io/apimachinery/pkg/types"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
@@ -38,6 +40,55 @@ type IR struct {
 	UDPRoutes      map[types.NamespacedName]gatewayv1alpha2.UDPRoute
 
 	ReferenceGrants map[types.NamespacedName]gatewayv1beta1.ReferenceGrant
+
+	// Sources maps every generated object to the objects it was converted
+	// from, e.g. an HTTPRoute to the Ingresses that contributed rules to it.
+	// Objects without a recorded source are not present in the map.
+	Sources map[ObjectRef][]ObjectRef
+
+	// RuleSources maps every generated route to the sources of its rules, by
+	// rule index. A rule merging the paths of several Ingresses, e.g. with the
+	// same host and path, has a source for every path.
+	RuleSources map[ObjectRef]map[int][]RuleSource
+
+	// RuleGroupHTTPRoutes maps every group of Ingress rules converted
+	// together to the keys of its HTTPRoutes, in the order of their rules:
+	// the route of the group, followed by the ones its rules were split
+	// into, if they were too many for a single route.
+	RuleGroupHTTPRoutes map[RuleGroupKey][]types.NamespacedName
+}
+
+// RuleGroupKey identifies the group of the rules with the same host of the
+// Ingresses of a namespace and an ingress class.
+type RuleGroupKey struct {
+	Namespace    string
+	IngressClass string
+	Host         string
+}
+
+// ObjectRef identifies a Kubernetes object, either one that was read as input
+// or one that was generated by the conversion.
+type ObjectRef struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+// String returns the object as Kind/namespace/name, or Kind/name if it is
+// not namespaced.
+func (r ObjectRef) String() string {
+	if r.Namespace == "" {
+		return fmt.Sprintf("%s/%s", r.Kind, r.Name)
+	}
+	return fmt.Sprintf("%s/%s/%s", r.Kind, r.Namespace, r.Name)
+}
+
+// RuleSource identifies the path of an Ingress rule a route rule was
+// converted from.
+type RuleSource struct {
+	Source    ObjectRef `json:"source"`
+	RuleIndex int       `json:"ruleIndex"`
+	PathIndex int       `json:"pathIndex"`
 }
 
 // GatewayContext contains the Gateway-API Gateway object and GatewayIR, which
@@ -48,6 +99,25 @@ type IR struct {
 type GatewayContext struct {
 	gatewayv1.Gateway
 	ProviderSpecificIR ProviderSpecificGatewayIR
+	// DefaultBackend, if set, serves the requests to the Gateway which match
+	// no route, e.g. the spec.defaultBackend of its Ingresses.
+	DefaultBackend *DefaultBackendIR
+}
+
+// DefaultBackendIR is the default backend of a Gateway. It is served by a
+// catch-all rule of an HTTPRoute without hostnames attached to all the
+// listeners of the Gateway, so that the rules of the routes with hostnames
+// and the rules of more specific paths take precedence over it.
+type DefaultBackendIR struct {
+	BackendRef gatewayv1.BackendRef
+	// HTTPRoute is the name of the HTTPRoute holding the catch-all rule, in
+	// the namespace of the Gateway, which is generated from the IR. It is
+	// empty if a rule of the routes without hostname already matches all
+	// the paths, as the default backend is then never used.
+	HTTPRoute string
+	// Sources are the objects the default backend was read from, e.g. the
+	// Ingresses with the same default backend.
+	Sources []ObjectRef
 }
 
 type ProviderSpecificGatewayIR struct {
@@ -67,6 +137,11 @@ type ProviderSpecificGatewayIR struct {
 type HTTPRouteContext struct {
 	gatewayv1.HTTPRoute
 	ProviderSpecificIR ProviderSpecificHTTPRouteIR
+	// HTTPSRedirectRoute is the name of the HTTPRoute redirecting the HTTP
+	// requests of the route to HTTPS, if it is added, checked for collisions
+	// with the names of the other HTTPRoutes of the namespace. It is set on
+	// the first route of the hosts with TLS.
+	HTTPSRedirectRoute string
 }
 
 type ProviderSpecificHTTPRouteIR struct {
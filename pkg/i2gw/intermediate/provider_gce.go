@@ -18,7 +18,10 @@ package intermediate
 
 type GceGatewayIR struct {
 	EnableHTTPSRedirect bool
-	SslPolicy           *SslPolicyConfig
+	// HTTPSRedirectStatusCode is the status code of the redirects to HTTPS,
+	// 301 or 302, when EnableHTTPSRedirect is set.
+	HTTPSRedirectStatusCode int
+	SslPolicy               *SslPolicyConfig
 }
 type SslPolicyConfig struct {
 	Name string
@@ -58,5 +61,9 @@ func mergeGceGatewayIR(current, existing *GceGatewayIR) *GceGatewayIR {
 	// If both GceGatewayIRs are not nil, merge their fields.
 	var mergedGatewayIR GceGatewayIR
 	mergedGatewayIR.EnableHTTPSRedirect = current.EnableHTTPSRedirect || existing.EnableHTTPSRedirect
+	mergedGatewayIR.HTTPSRedirectStatusCode = existing.HTTPSRedirectStatusCode
+	if current.EnableHTTPSRedirect {
+		mergedGatewayIR.HTTPSRedirectStatusCode = current.HTTPSRedirectStatusCode
+	}
 	return &mergedGatewayIR
 }
@@ -17,9 +17,12 @@ limitations under the License.
 package intermediate
 
 import (
+	"cmp"
 	"fmt"
 	"maps"
+	"slices"
 
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
@@ -46,6 +49,10 @@ func MergeIRs(irs ...IR) (IR, field.ErrorList) {
 		TCPRoutes:       make(map[types.NamespacedName]gatewayv1alpha2.TCPRoute),
 		UDPRoutes:       make(map[types.NamespacedName]gatewayv1alpha2.UDPRoute),
 		ReferenceGrants: make(map[types.NamespacedName]gatewayv1beta1.ReferenceGrant),
+		Sources:         make(map[ObjectRef][]ObjectRef),
+		RuleSources:     make(map[ObjectRef]map[int][]RuleSource),
+
+		RuleGroupHTTPRoutes: make(map[RuleGroupKey][]types.NamespacedName),
 	}
 	var errs field.ErrorList
 	mergedIRs.Gateways, errs = mergeGatewayContexts(irs)
@@ -61,10 +68,66 @@ func MergeIRs(irs ...IR) (IR, field.ErrorList) {
 		maps.Copy(mergedIRs.TCPRoutes, gr.TCPRoutes)
 		maps.Copy(mergedIRs.UDPRoutes, gr.UDPRoutes)
 		maps.Copy(mergedIRs.ReferenceGrants, gr.ReferenceGrants)
+		maps.Copy(mergedIRs.RuleGroupHTTPRoutes, gr.RuleGroupHTTPRoutes)
+		for object, sources := range gr.Sources {
+			for _, source := range sources {
+				AddSource(mergedIRs.Sources, object, source)
+			}
+		}
+		for route, ruleSources := range gr.RuleSources {
+			for ruleIdx, sources := range ruleSources {
+				for _, source := range sources {
+					AddRuleSource(mergedIRs.RuleSources, route, ruleIdx, source)
+				}
+			}
+		}
 	}
 	return mergedIRs, errs
 }
 
+// AddSource records that object was converted from source. The sources of
+// every object are kept sorted and free of duplicates.
+func AddSource(sources map[ObjectRef][]ObjectRef, object, source ObjectRef) {
+	existing := sources[object]
+	i, found := slices.BinarySearchFunc(existing, source, compareObjectRefs)
+	if found {
+		return
+	}
+	sources[object] = slices.Insert(existing, i, source)
+}
+
+// AddRuleSource records that the rule at ruleIdx of route was converted from
+// source. The sources of every rule are kept sorted and free of duplicates.
+func AddRuleSource(ruleSources map[ObjectRef]map[int][]RuleSource, route ObjectRef, ruleIdx int, source RuleSource) {
+	if ruleSources[route] == nil {
+		ruleSources[route] = make(map[int][]RuleSource)
+	}
+	existing := ruleSources[route][ruleIdx]
+	i, found := slices.BinarySearchFunc(existing, source, compareRuleSources)
+	if found {
+		return
+	}
+	ruleSources[route][ruleIdx] = slices.Insert(existing, i, source)
+}
+
+func compareRuleSources(a, b RuleSource) int {
+	return cmp.Or(
+		compareObjectRefs(a.Source, b.Source),
+		cmp.Compare(a.RuleIndex, b.RuleIndex),
+		cmp.Compare(a.PathIndex, b.PathIndex),
+	)
+}
+
+func compareObjectRefs(a, b ObjectRef) int {
+	if c := cmp.Compare(a.Kind, b.Kind); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.Namespace, b.Namespace); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.Name, b.Name)
+}
+
 func mergeGatewayContexts(irs []IR) (map[types.NamespacedName]GatewayContext, field.ErrorList) {
 	newGatewayContexts := make(map[types.NamespacedName]GatewayContext)
 	errs := field.ErrorList{}
@@ -76,13 +139,15 @@ func mergeGatewayContexts(irs []IR) (map[types.NamespacedName]GatewayContext, fi
 				g.Gateway.Spec.Listeners = append(g.Gateway.Spec.Listeners, existingGatewayContext.Gateway.Spec.Listeners...)
 				g.Gateway.Spec.Addresses = append(g.Gateway.Spec.Addresses, existingGatewayContext.Gateway.Spec.Addresses...)
 				g.ProviderSpecificIR = mergedGatewayIR(g.ProviderSpecificIR, existingGatewayContext.ProviderSpecificIR)
+				defaultBackend, err := mergeDefaultBackends(nn, g.DefaultBackend, existingGatewayContext.DefaultBackend)
+				if err != nil {
+					errs = append(errs, err)
+				}
+				g.DefaultBackend = defaultBackend
 			}
-			newGatewayContexts[nn] = GatewayContext{Gateway: g.Gateway}
-			// 64 is the maximum number of listeners a Gateway can have
-			if len(g.Spec.Listeners) > 64 {
-				fieldPath := field.NewPath(fmt.Sprintf("%s/%s", nn.Namespace, nn.Name)).Child("spec").Child("listeners")
-				errs = append(errs, field.Invalid(fieldPath, g, "error while merging gateway listeners: a gateway cannot have more than 64 listeners"))
-			}
+			// The Gateways with more than 64 listeners are split after the
+			// conversion, see i2gw.ShardGateways.
+			newGatewayContexts[nn] = GatewayContext{Gateway: g.Gateway, DefaultBackend: g.DefaultBackend}
 			// 16 is the maximum number of addresses a Gateway can have
 			if len(g.Spec.Addresses) > 16 {
 				fieldPath := field.NewPath(fmt.Sprintf("%s/%s", nn.Namespace, nn.Name)).Child("spec").Child("addresses")
@@ -93,6 +158,31 @@ func mergeGatewayContexts(irs []IR) (map[types.NamespacedName]GatewayContext, fi
 	return newGatewayContexts, errs
 }
 
+// mergeDefaultBackends returns the default backend of the Gateway nn merged
+// from the current and the existing ones, with the sources of both if they
+// are the same. Otherwise, the existing one is kept and an error is returned.
+func mergeDefaultBackends(nn types.NamespacedName, current, existing *DefaultBackendIR) (*DefaultBackendIR, *field.Error) {
+	if current == nil {
+		return existing, nil
+	}
+	if existing == nil {
+		return current, nil
+	}
+	if !apiequality.Semantic.DeepEqual(current.BackendRef, existing.BackendRef) {
+		fieldPath := field.NewPath(fmt.Sprintf("%s/%s", nn.Namespace, nn.Name))
+		return existing, field.Invalid(fieldPath, current.BackendRef.Name,
+			fmt.Sprintf("error while merging gateway default backends: conflicts with the default backend %s", existing.BackendRef.Name))
+	}
+	merged := *existing
+	merged.Sources = slices.Clone(existing.Sources)
+	for _, source := range current.Sources {
+		if !slices.Contains(merged.Sources, source) {
+			merged.Sources = append(merged.Sources, source)
+		}
+	}
+	return &merged, nil
+}
+
 func mergedGatewayIR(current, existing ProviderSpecificGatewayIR) ProviderSpecificGatewayIR {
 	var mergedGatewayIR ProviderSpecificGatewayIR
 	// TODO(issue #190): Find a different way to merge GatewayIR, instead of
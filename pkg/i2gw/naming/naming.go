@@ -0,0 +1,130 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package naming builds the names of the generated objects and of the
+// listeners of the generated Gateways, so that they are valid DNS-1123
+// subdomains of at most MaxLength characters, and that the names of different
+// inputs do not collide.
+package naming
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+)
+
+// MaxLength is the maximum length of the names of objects and of the section
+// names of listeners.
+const MaxLength = 253
+
+// AllHosts is the name of the empty host.
+const AllHosts = "all-hosts"
+
+// WildcardHost is the name of the * host, which is distinct from the one of
+// the empty host so that their routes and listeners get distinct names.
+const WildcardHost = "wildcard-host"
+
+// hashLength is the length of the hash suffixes added to the names which are
+// too long or which collide.
+const hashLength = 8
+
+var (
+	invalidCharacters = regexp.MustCompile("[^a-z0-9.-]+")
+	labelSeparators   = regexp.MustCompile(`[-.]*\.[-.]*`)
+	hostSeparators    = regexp.MustCompile("[^a-z0-9]+")
+)
+
+// Name returns the name made of parts joined by dashes, e.g. the name of an
+// Ingress and the name of a host. The parts are lowercased, and the
+// characters other than alphanumerics, dashes and dots are replaced by
+// dashes, so that valid names are kept as they are. The empty parts are
+// skipped. A name longer than MaxLength is truncated with a hash of its parts,
+// so that different long names stay different.
+func Name(parts ...string) string {
+	var sanitized []string
+	for _, part := range parts {
+		part = invalidCharacters.ReplaceAllString(strings.ToLower(part), "-")
+		part = strings.Trim(labelSeparators.ReplaceAllString(part, "."), "-.")
+		if part != "" {
+			sanitized = append(sanitized, part)
+		}
+	}
+	name := strings.Join(sanitized, "-")
+	if len(name) <= MaxLength {
+		return name
+	}
+	return WithHash(name, strings.Join(parts, "/"))
+}
+
+// Host returns the name of host, with dashes instead of its dots and of the
+// * of wildcard hosts, e.g. example-com for both example.com and
+// *.example.com. The empty host is named AllHosts and the * host is named
+// WildcardHost. As different hosts may have the same name, the names of the
+// hosts of a Gateway or a namespace should be checked for collisions with a
+// Scope.
+func Host(host string) string {
+	name := strings.Trim(hostSeparators.ReplaceAllString(strings.ToLower(host), "-"), "-")
+	if name == "" {
+		if strings.Contains(host, "*") {
+			return WildcardHost
+		}
+		return AllHosts
+	}
+	return Name(name)
+}
+
+// WithHash returns name followed by a dash and a short hash of input,
+// truncating name so that the result is at most MaxLength long. The hash only
+// depends on input, so that the name is the same on every run.
+func WithHash(name, input string) string {
+	sum := sha256.Sum256([]byte(input))
+	if maxNameLength := MaxLength - hashLength - 1; len(name) > maxNameLength {
+		name = strings.TrimRight(name[:maxNameLength], "-.")
+	}
+	return name + "-" + hex.EncodeToString(sum[:])[:hashLength]
+}
+
+// Scope detects the collisions between the names of the inputs of a scope,
+// e.g. the hosts of the listeners of a Gateway, or the Ingresses and hosts of
+// the routes of a namespace. All the inputs are added before their names are
+// read, and every input whose name is shared by other inputs gets a hash
+// suffix, so that the names do not depend on the order of the inputs.
+type Scope struct {
+	inputs map[string]map[string]bool
+}
+
+// NewScope returns an empty Scope.
+func NewScope() *Scope {
+	return &Scope{inputs: make(map[string]map[string]bool)}
+}
+
+// Add adds input, of the given name, to the scope.
+func (s *Scope) Add(name, input string) {
+	if s.inputs[name] == nil {
+		s.inputs[name] = make(map[string]bool)
+	}
+	s.inputs[name][input] = true
+}
+
+// Name returns the name of input, which is name unless other inputs of the
+// scope have the same name, in which case it is WithHash(name, input).
+func (s *Scope) Name(name, input string) string {
+	if len(s.inputs[name]) > 1 {
+		return WithHash(name, input)
+	}
+	return name
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package naming
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+func TestName(t *testing.T) {
+	long := strings.Repeat("a", 200)
+
+	testCases := []struct {
+		name     string
+		parts    []string
+		expected string
+	}{
+		{
+			name:     "valid parts are kept",
+			parts:    []string{"foo", "example.com", "http"},
+			expected: "foo-example.com-http",
+		},
+		{
+			name:     "invalid characters are replaced",
+			parts:    []string{"Foo_Bar", "*.example.com"},
+			expected: "foo-bar-example.com",
+		},
+		{
+			name:     "empty parts are skipped",
+			parts:    []string{"", "foo", "", "http"},
+			expected: "foo-http",
+		},
+		{
+			name:     "empty labels are removed",
+			parts:    []string{"-foo..-.bar-"},
+			expected: "foo.bar",
+		},
+		{
+			name:     "long names are truncated with a hash",
+			parts:    []string{long, long},
+			expected: WithHash(long+"-"+long, long+"/"+long),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			name := Name(tc.parts...)
+			if name != tc.expected {
+				t.Errorf("Expected name %q, got %q", tc.expected, name)
+			}
+			if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+				t.Errorf("Expected a valid name, got %q: %v", name, errs)
+			}
+		})
+	}
+}
+
+func TestHost(t *testing.T) {
+	testCases := []struct {
+		host     string
+		expected string
+	}{
+		{host: "", expected: AllHosts},
+		{host: "*", expected: WildcardHost},
+		{host: "example.com", expected: "example-com"},
+		{host: "*.Example.com", expected: "example-com"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.host, func(t *testing.T) {
+			if name := Host(tc.host); name != tc.expected {
+				t.Errorf("Expected name %q, got %q", tc.expected, name)
+			}
+		})
+	}
+}
+
+func TestWithHash(t *testing.T) {
+	name := WithHash(strings.Repeat("a", MaxLength-5)+".b", "input")
+	if len(name) > MaxLength {
+		t.Errorf("Expected a name of at most %d characters, got %d", MaxLength, len(name))
+	}
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		t.Errorf("Expected a valid name, got %q: %v", name, errs)
+	}
+	if name != WithHash(strings.Repeat("a", MaxLength-5)+".b", "input") {
+		t.Errorf("Expected the same name for the same input")
+	}
+	if WithHash("foo", "input") == WithHash("foo", "other") {
+		t.Errorf("Expected different names for different inputs")
+	}
+}
+
+func TestScope(t *testing.T) {
+	scope := NewScope()
+	scope.Add("a-b-com", "a.b.com")
+	scope.Add("a-b-com", "a-b.com")
+	scope.Add("a-b-com", "a-b.com")
+	scope.Add("c-com", "c.com")
+
+	testCases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "a-b-com", input: "a.b.com", expected: WithHash("a-b-com", "a.b.com")},
+		{name: "a-b-com", input: "a-b.com", expected: WithHash("a-b-com", "a-b.com")},
+		{name: "c-com", input: "c.com", expected: "c-com"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			if name := scope.Name(tc.name, tc.input); name != tc.expected {
+				t.Errorf("Expected name %q, got %q", tc.expected, name)
+			}
+		})
+	}
+}
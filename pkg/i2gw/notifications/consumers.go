@@ -0,0 +1,130 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifications
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+
+	"k8s.io/klog/v2"
+)
+
+// Consumer receives the notifications sent to a NotificationAggregator.
+// Consume is called in the order the notifications are sent, one at a time,
+// and must not send notifications itself.
+type Consumer interface {
+	Consume(provider string, notification Notification)
+}
+
+// ConsumerFunc is a function used as a Consumer.
+type ConsumerFunc func(provider string, notification Notification)
+
+// Consume calls f.
+func (f ConsumerFunc) Consume(provider string, notification Notification) {
+	f(provider, notification)
+}
+
+// Collector collects the notifications in memory, by provider, e.g. for
+// tests. It is safe for concurrent use.
+type Collector struct {
+	mutex         sync.Mutex
+	notifications map[string][]Notification
+}
+
+// Consume adds the notification to the collected notifications.
+func (c *Collector) Consume(provider string, notification Notification) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.notifications == nil {
+		c.notifications = map[string][]Notification{}
+	}
+	c.notifications[provider] = append(c.notifications[provider], notification)
+}
+
+// Notifications returns a copy of the notifications collected so far, by
+// provider.
+func (c *Collector) Notifications() map[string][]Notification {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return copyNotifications(c.notifications)
+}
+
+// TableConsumer collects the notifications to display them as tables, one
+// per provider.
+type TableConsumer struct {
+	Collector
+}
+
+// Tables returns the tables of the notifications collected so far, by
+// provider. See NotificationAggregator.CreateNotificationTables.
+func (c *TableConsumer) Tables() map[string]string {
+	return createNotificationTables(c.Notifications())
+}
+
+// JSONConsumer writes every notification as a line of JSON, in the format of
+// a ReportEntry. It is safe for concurrent use.
+type JSONConsumer struct {
+	mutex   sync.Mutex
+	encoder *json.Encoder
+	err     error
+}
+
+// NewJSONConsumer returns a JSONConsumer writing to w.
+func NewJSONConsumer(w io.Writer) *JSONConsumer {
+	return &JSONConsumer{encoder: json.NewEncoder(w)}
+}
+
+// Consume writes the notification. Once a write failed, the notifications
+// are dropped, see Err.
+func (c *JSONConsumer) Consume(provider string, notification Notification) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.err == nil {
+		c.err = c.encoder.Encode(newReportEntry(provider, notification))
+	}
+}
+
+// Err returns the error of the first write which failed, if any.
+func (c *JSONConsumer) Err() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.err
+}
+
+// KlogConsumer logs the notifications with klog. Errors are logged as
+// errors, warnings at verbosity 0 and the other notifications at verbosity 2.
+type KlogConsumer struct{}
+
+// Consume logs the notification.
+func (KlogConsumer) Consume(provider string, notification Notification) {
+	keysAndValues := []any{"provider", provider}
+	if objects := convertObjectsToStr(notification.CallingObjects); objects != "" {
+		keysAndValues = append(keysAndValues, "objects", objects)
+	}
+	if notification.FieldPath != "" {
+		keysAndValues = append(keysAndValues, "fieldPath", notification.FieldPath)
+	}
+	switch notification.Type {
+	case ErrorNotification:
+		klog.ErrorS(nil, notification.Message, keysAndValues...)
+	case WarningNotification:
+		klog.InfoS(notification.Message, keysAndValues...)
+	default:
+		klog.V(2).InfoS(notification.Message, keysAndValues...)
+	}
+}
@@ -17,7 +17,9 @@ limitations under the License.
 package notifications
 
 import (
+	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 	"sync"
 
@@ -26,44 +28,124 @@ import (
 	"github.com/olekukonko/tablewriter"
 )
 
-func init() {
-	NotificationAggr = NotificationAggregator{Notifications: map[string][]Notification{}}
-}
-
 const (
 	InfoNotification    MessageType = "INFO"
 	WarningNotification MessageType = "WARNING"
 	ErrorNotification   MessageType = "ERROR"
 )
 
+// SourceFileAnnotationKey is set on the objects read from files to the path
+// of their file, which is shown alongside the calling objects.
+const SourceFileAnnotationKey = "ingress2gateway.kubernetes.io/source-file"
+
 type MessageType string
 
+// MessageTypes lists the message types from the most to the least severe.
+var MessageTypes = []MessageType{ErrorNotification, WarningNotification, InfoNotification}
+
+// AtLeast returns whether t is as severe as other or more.
+func (t MessageType) AtLeast(other MessageType) bool {
+	i := slices.Index(MessageTypes, t)
+	return i != -1 && i <= slices.Index(MessageTypes, other)
+}
+
 type Notification struct {
 	Type           MessageType
 	Message        string
 	CallingObjects []client.Object
+	// FieldPath is the path of the field of the calling objects the
+	// notification is about, if any.
+	FieldPath string
 }
 
+// NotificationAggregator collects the notifications of a single conversion,
+// by provider, and forwards them to its consumers. A notification identical
+// to one already sent by the same provider, i.e. with the same type, message,
+// field path and calling objects, is dropped. It is safe for concurrent use.
 type NotificationAggregator struct {
 	mutex         sync.Mutex
 	Notifications map[string][]Notification
+	consumers     []Consumer
+	// sent holds the keys of the notifications sent so far, see
+	// notificationKey.
+	sent map[string]bool
 }
 
-var NotificationAggr NotificationAggregator
+// NewNotificationAggregator returns an empty NotificationAggregator, which
+// forwards the notifications to consumers.
+func NewNotificationAggregator(consumers ...Consumer) *NotificationAggregator {
+	return &NotificationAggregator{Notifications: map[string][]Notification{}, consumers: consumers}
+}
 
 // DispatchNotification is used to send a notification to the NotificationAggregator
 func (na *NotificationAggregator) DispatchNotification(notification Notification, ProviderName string) {
 	na.mutex.Lock()
+	defer na.mutex.Unlock()
+	if na.Notifications == nil {
+		na.Notifications = map[string][]Notification{}
+	}
+	if na.sent == nil {
+		na.sent = map[string]bool{}
+	}
+	key := notificationKey(ProviderName, notification)
+	if na.sent[key] {
+		return
+	}
+	na.sent[key] = true
 	na.Notifications[ProviderName] = append(na.Notifications[ProviderName], notification)
-	na.mutex.Unlock()
+	for _, consumer := range na.consumers {
+		consumer.Consume(ProviderName, notification)
+	}
+}
+
+// notificationKey identifies a notification of provider by its report entry,
+// which holds its type, message, field path and calling objects. The Go types
+// of the calling objects are part of the key, as their kind may be unset.
+func notificationKey(provider string, notification Notification) string {
+	key := struct {
+		Entry ReportEntry
+		Types []string
+	}{Entry: newReportEntry(provider, notification)}
+	for _, obj := range notification.CallingObjects {
+		key.Types = append(key.Types, fmt.Sprintf("%T", obj))
+	}
+	data, err := json.Marshal(key)
+	if err != nil {
+		// The key only holds strings, so this does not happen.
+		return fmt.Sprintf("%#v", key)
+	}
+	return string(data)
+}
+
+// GetNotifications returns a copy of the notifications sent so far, by
+// provider. A nil NotificationAggregator has no notifications.
+func (na *NotificationAggregator) GetNotifications() map[string][]Notification {
+	if na == nil {
+		return map[string][]Notification{}
+	}
+	na.mutex.Lock()
+	defer na.mutex.Unlock()
+	return copyNotifications(na.Notifications)
+}
+
+func copyNotifications(notificationsByProvider map[string][]Notification) map[string][]Notification {
+	notifications := make(map[string][]Notification, len(notificationsByProvider))
+	for provider, msgs := range notificationsByProvider {
+		notifications[provider] = append([]Notification(nil), msgs...)
+	}
+	return notifications
 }
 
 // CreateNotificationTables takes all generated notifications and returns a map[string]string
 // that displays the notifications in a tabular format based on provider
 func (na *NotificationAggregator) CreateNotificationTables() map[string]string {
+	return createNotificationTables(na.GetNotifications())
+}
+
+func createNotificationTables(notificationsByProvider map[string][]Notification) map[string]string {
 	notificationTablesMap := make(map[string]string)
 
-	for provider, msgs := range na.Notifications {
+	for provider, msgs := range notificationsByProvider {
 		providerTable := strings.Builder{}
 
 		t := tablewriter.NewWriter(&providerTable)
@@ -92,6 +174,9 @@ func convertObjectsToStr(ob []client.Object) string {
 			sb.WriteString(", ")
 		}
 		object := o.GetObjectKind().GroupVersionKind().Kind + ": " + client.ObjectKeyFromObject(o).String()
+		if sourceFile, ok := o.GetAnnotations()[SourceFileAnnotationKey]; ok {
+			object = fmt.Sprintf("%s (%s)", object, sourceFile)
+		}
 		sb.WriteString(object)
 	}
 
@@ -17,11 +17,13 @@ limitations under the License.
 package notifications
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
 	istioclientv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -198,6 +200,22 @@ func TestConvertObjectsToStr(t *testing.T) {
 			},
 			want: "Gateway: gate/way, HTTPRoute: prod/route",
 		},
+		{
+			name: "object read from a file",
+			objects: []client.Object{
+				&networkingv1.Ingress{
+					TypeMeta: metav1.TypeMeta{
+						Kind: "Ingress",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "ingress",
+						Namespace:   "test",
+						Annotations: map[string]string{SourceFileAnnotationKey: "manifests/ingress.yaml"},
+					},
+				},
+			},
+			want: "Ingress: test/ingress (manifests/ingress.yaml)",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -208,3 +226,138 @@ func TestConvertObjectsToStr(t *testing.T) {
 		})
 	}
 }
+
+func TestMessageTypeAtLeast(t *testing.T) {
+	testCases := []struct {
+		mType MessageType
+		other MessageType
+		want  bool
+	}{
+		{mType: ErrorNotification, other: WarningNotification, want: true},
+		{mType: WarningNotification, other: WarningNotification, want: true},
+		{mType: InfoNotification, other: WarningNotification, want: false},
+		{mType: WarningNotification, other: ErrorNotification, want: false},
+		{mType: MessageType("UNKNOWN"), other: InfoNotification, want: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.mType)+"/"+string(tc.other), func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.mType.AtLeast(tc.other))
+		})
+	}
+}
+
+func TestNotificationAggregatorDispatchNotification(t *testing.T) {
+	ingress := func(name string) client.Object {
+		return &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name}}
+	}
+	service := func(name string) client.Object {
+		return &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name}}
+	}
+
+	testCases := []struct {
+		name         string
+		sent         map[string][]Notification
+		wantedUnique map[string][]Notification
+	}{
+		{
+			name: "identical notifications on the same object",
+			sent: map[string][]Notification{
+				"kong": {
+					NewNotification(WarningNotification, "ignored", ingress("foo")),
+					NewNotification(WarningNotification, "ignored", ingress("foo")),
+				},
+			},
+			wantedUnique: map[string][]Notification{
+				"kong": {NewNotification(WarningNotification, "ignored", ingress("foo"))},
+			},
+		},
+		{
+			name: "different objects, types, messages and providers",
+			sent: map[string][]Notification{
+				"kong": {
+					NewNotification(WarningNotification, "ignored", ingress("foo")),
+					NewNotification(WarningNotification, "ignored", ingress("bar")),
+					NewNotification(WarningNotification, "ignored", service("foo")),
+					NewNotification(InfoNotification, "ignored", ingress("foo")),
+					NewNotification(WarningNotification, "skipped", ingress("foo")),
+				},
+				"istio": {
+					NewNotification(WarningNotification, "ignored", ingress("foo")),
+				},
+			},
+			wantedUnique: map[string][]Notification{
+				"kong": {
+					NewNotification(WarningNotification, "ignored", ingress("foo")),
+					NewNotification(WarningNotification, "ignored", ingress("bar")),
+					NewNotification(WarningNotification, "ignored", service("foo")),
+					NewNotification(InfoNotification, "ignored", ingress("foo")),
+					NewNotification(WarningNotification, "skipped", ingress("foo")),
+				},
+				"istio": {
+					NewNotification(WarningNotification, "ignored", ingress("foo")),
+				},
+			},
+		},
+		{
+			name: "different field paths",
+			sent: map[string][]Notification{
+				"istio": {
+					{Type: InfoNotification, Message: "ignored", FieldPath: "spec.a"},
+					{Type: InfoNotification, Message: "ignored", FieldPath: "spec.b"},
+					{Type: InfoNotification, Message: "ignored", FieldPath: "spec.a"},
+				},
+			},
+			wantedUnique: map[string][]Notification{
+				"istio": {
+					{Type: InfoNotification, Message: "ignored", FieldPath: "spec.a"},
+					{Type: InfoNotification, Message: "ignored", FieldPath: "spec.b"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			collector := &Collector{}
+			var consumed int
+			na := NewNotificationAggregator(collector, ConsumerFunc(func(string, Notification) { consumed++ }))
+			for _, provider := range []string{"istio", "kong"} {
+				for _, n := range tc.sent[provider] {
+					na.DispatchNotification(n, provider)
+				}
+			}
+
+			assert.Equal(t, tc.wantedUnique, na.GetNotifications())
+			assert.Equal(t, tc.wantedUnique, collector.Notifications())
+			var wantedCount int
+			for _, msgs := range tc.wantedUnique {
+				wantedCount += len(msgs)
+			}
+			assert.Equal(t, wantedCount, consumed)
+		})
+	}
+}
+
+func TestJSONConsumer(t *testing.T) {
+	var out bytes.Buffer
+	na := NewNotificationAggregator(NewJSONConsumer(&out))
+	ingress := &networkingv1.Ingress{
+		TypeMeta:   metav1.TypeMeta{Kind: "Ingress"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo"},
+	}
+	na.DispatchNotification(NewNotification(WarningNotification, "ignored", ingress), "kong")
+	na.DispatchNotification(Notification{Type: InfoNotification, Message: "converted", FieldPath: "spec.rules"}, "istio")
+
+	assert.Equal(t, `{"type":"WARNING","provider":"kong","message":"ignored","objects":[{"kind":"Ingress","namespace":"default","name":"foo"}]}
+{"type":"INFO","provider":"istio","message":"converted","fieldPath":"spec.rules"}
+`, out.String())
+}
+
+func TestTableConsumer(t *testing.T) {
+	tables := &TableConsumer{}
+	na := NewNotificationAggregator(tables)
+	na.DispatchNotification(NewNotification(WarningNotification, "warning message"), "provider1")
+
+	assert.Equal(t, na.CreateNotificationTables(), tables.Tables())
+}
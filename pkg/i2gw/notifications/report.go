@@ -0,0 +1,331 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifications
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+type ReportFormat string
+
+const (
+	JSONReport  ReportFormat = "json"
+	SARIFReport ReportFormat = "sarif"
+	JUnitReport ReportFormat = "junit"
+)
+
+// ReportFormats lists the supported report formats.
+var ReportFormats = []ReportFormat{JSONReport, SARIFReport, JUnitReport}
+
+// Report holds the notifications and the errors of a conversion, in a form
+// which can be written for machines to consume.
+type Report struct {
+	Entries []ReportEntry `json:"entries"`
+}
+
+// ReportEntry is a notification or a conversion error.
+type ReportEntry struct {
+	Type     MessageType `json:"type"`
+	Provider string      `json:"provider"`
+	Message  string      `json:"message"`
+	// FieldPath is the path of the field the entry is about, if known.
+	FieldPath string         `json:"fieldPath,omitempty"`
+	Objects   []ReportObject `json:"objects,omitempty"`
+}
+
+// ReportObject identifies a calling object of a notification.
+type ReportObject struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	// SourceFile is the file the object was read from, if any.
+	SourceFile string `json:"sourceFile,omitempty"`
+}
+
+// String returns the object as Kind/namespace/name, or Kind/name if it is
+// not namespaced.
+func (o ReportObject) String() string {
+	if o.Namespace == "" {
+		return fmt.Sprintf("%s/%s", o.Kind, o.Name)
+	}
+	return fmt.Sprintf("%s/%s/%s", o.Kind, o.Namespace, o.Name)
+}
+
+// NewReport creates a report of the notifications and the conversion errors,
+// both by provider. Providers are sorted by name, and the errors of a
+// provider follow its notifications.
+func NewReport(notificationsByProvider map[string][]Notification, errsByProvider map[string]field.ErrorList) Report {
+	var providers []string
+	for provider := range notificationsByProvider {
+		providers = append(providers, provider)
+	}
+	for provider := range errsByProvider {
+		if _, ok := notificationsByProvider[provider]; !ok {
+			providers = append(providers, provider)
+		}
+	}
+	slices.Sort(providers)
+
+	report := Report{Entries: []ReportEntry{}}
+	for _, provider := range providers {
+		for _, n := range notificationsByProvider[provider] {
+			report.Entries = append(report.Entries, newReportEntry(provider, n))
+		}
+		for _, err := range errsByProvider[provider] {
+			report.Entries = append(report.Entries, ReportEntry{
+				Type:      ErrorNotification,
+				Provider:  provider,
+				Message:   err.ErrorBody(),
+				FieldPath: err.Field,
+			})
+		}
+	}
+	return report
+}
+
+// newReportEntry returns the report entry of a notification of provider.
+func newReportEntry(provider string, n Notification) ReportEntry {
+	entry := ReportEntry{
+		Type:      n.Type,
+		Provider:  provider,
+		Message:   n.Message,
+		FieldPath: n.FieldPath,
+	}
+	for _, o := range n.CallingObjects {
+		entry.Objects = append(entry.Objects, ReportObject{
+			Kind:       o.GetObjectKind().GroupVersionKind().Kind,
+			Namespace:  o.GetNamespace(),
+			Name:       o.GetName(),
+			SourceFile: o.GetAnnotations()[SourceFileAnnotationKey],
+		})
+	}
+	return entry
+}
+
+// Write writes the report in the given format. The version of the tool is
+// included in the SARIF report.
+func (r Report) Write(w io.Writer, format ReportFormat, toolVersion string) error {
+	switch format {
+	case JSONReport:
+		return writeJSON(w, r)
+	case SARIFReport:
+		return writeJSON(w, r.toSARIF(toolVersion))
+	case JUnitReport:
+		if _, err := io.WriteString(w, xml.Header); err != nil {
+			return err
+		}
+		encoder := xml.NewEncoder(w)
+		encoder.Indent("", "  ")
+		if err := encoder.Encode(r.toJUnit()); err != nil {
+			return err
+		}
+		_, err := io.WriteString(w, "\n")
+		return err
+	default:
+		return fmt.Errorf("%s is not a supported report format", format)
+	}
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// The SARIF types only hold the properties of the SARIF 2.1.0 format used in
+// the reports.
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifResult struct {
+	RuleID     string            `json:"ruleId"`
+	Level      string            `json:"level"`
+	Message    sarifMessage      `json:"message"`
+	Locations  []sarifLocation   `json:"locations,omitempty"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// sarifLevels maps the notification types to the SARIF levels.
+var sarifLevels = map[MessageType]string{
+	ErrorNotification:   "error",
+	WarningNotification: "warning",
+	InfoNotification:    "note",
+}
+
+// toSARIF converts the report to a SARIF log with one rule per provider.
+func (r Report) toSARIF(toolVersion string) sarifLog {
+	driver := sarifDriver{
+		Name:           "ingress2gateway",
+		Version:        toolVersion,
+		InformationURI: "https://github.com/kubernetes-sigs/ingress2gateway",
+		Rules:          []sarifRule{},
+	}
+	results := []sarifResult{}
+	for _, entry := range r.Entries {
+		if !slices.ContainsFunc(driver.Rules, func(rule sarifRule) bool { return rule.ID == entry.Provider }) {
+			driver.Rules = append(driver.Rules, sarifRule{
+				ID:               entry.Provider,
+				ShortDescription: sarifMessage{Text: fmt.Sprintf("Notifications from %s", entry.Provider)},
+			})
+		}
+
+		result := sarifResult{
+			RuleID:  entry.Provider,
+			Level:   sarifLevels[entry.Type],
+			Message: sarifMessage{Text: entry.Message},
+		}
+		if entry.FieldPath != "" {
+			result.Properties = map[string]string{"fieldPath": entry.FieldPath}
+		}
+		for _, o := range entry.Objects {
+			location := sarifLocation{
+				LogicalLocations: []sarifLogicalLocation{{
+					Name:               o.Name,
+					FullyQualifiedName: o.String(),
+					Kind:               "resource",
+				}},
+			}
+			if o.SourceFile != "" {
+				location.PhysicalLocation = &sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: o.SourceFile},
+				}
+			}
+			result.Locations = append(result.Locations, location)
+		}
+		results = append(results, result)
+	}
+
+	return sarifLog{
+		Version: "2.1.0",
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Runs:    []sarifRun{{Tool: sarifTool{Driver: driver}, Results: results}},
+	}
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// toJUnit converts the report to JUnit test suites, one per provider and one
+// test case per entry. Errors are reported as failures, and the other entries
+// as passed test cases with the message in their output.
+func (r Report) toJUnit() junitTestSuites {
+	suites := junitTestSuites{Name: "ingress2gateway", Suites: []junitTestSuite{}}
+	for _, entry := range r.Entries {
+		if len(suites.Suites) == 0 || suites.Suites[len(suites.Suites)-1].Name != entry.Provider {
+			suites.Suites = append(suites.Suites, junitTestSuite{Name: entry.Provider})
+		}
+		suite := &suites.Suites[len(suites.Suites)-1]
+
+		name := entry.Provider
+		if len(entry.Objects) > 0 {
+			name = entry.Objects[0].String()
+		}
+		if entry.FieldPath != "" {
+			name = fmt.Sprintf("%s %s", name, entry.FieldPath)
+		}
+		testCase := junitTestCase{Name: name, ClassName: entry.Provider}
+		if entry.Type == ErrorNotification {
+			testCase.Failure = &junitFailure{Message: entry.Message, Type: string(entry.Type), Text: entry.Message}
+			suite.Failures++
+			suites.Failures++
+		} else {
+			testCase.SystemOut = fmt.Sprintf("%s: %s", entry.Type, entry.Message)
+		}
+		suite.TestCases = append(suite.TestCases, testCase)
+		suite.Tests++
+		suites.Tests++
+	}
+	return suites
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifications
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func testReport() Report {
+	ingress := &networkingv1.Ingress{
+		TypeMeta: metav1.TypeMeta{Kind: "Ingress"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "web",
+			Namespace:   "default",
+			Annotations: map[string]string{SourceFileAnnotationKey: "manifests/web.yaml"},
+		},
+	}
+	return NewReport(
+		map[string][]Notification{
+			"kong": {{Type: InfoNotification, Message: "info from kong"}},
+			"ingress-nginx": {{
+				Type:           WarningNotification,
+				Message:        "ignoring field",
+				CallingObjects: []client.Object{ingress},
+				FieldPath:      "metadata.annotations",
+			}},
+		},
+		map[string]field.ErrorList{
+			"ingress-nginx": {field.Invalid(field.NewPath("spec", "rules"), "x", "invalid rule")},
+		},
+	)
+}
+
+func Test_NewReport(t *testing.T) {
+	expected := Report{Entries: []ReportEntry{
+		{
+			Type:      WarningNotification,
+			Provider:  "ingress-nginx",
+			Message:   "ignoring field",
+			FieldPath: "metadata.annotations",
+			Objects:   []ReportObject{{Kind: "Ingress", Namespace: "default", Name: "web", SourceFile: "manifests/web.yaml"}},
+		},
+		{
+			Type:      ErrorNotification,
+			Provider:  "ingress-nginx",
+			Message:   `Invalid value: "x": invalid rule`,
+			FieldPath: "spec.rules",
+		},
+		{
+			Type:     InfoNotification,
+			Provider: "kong",
+			Message:  "info from kong",
+		},
+	}}
+	if diff := cmp.Diff(expected, testReport()); diff != "" {
+		t.Errorf("Unexpected report (-want +got):\n%s", diff)
+	}
+}
+
+func Test_Report_Write(t *testing.T) {
+	report := testReport()
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := report.Write(&buf, JSONReport, "1.0.0"); err != nil {
+			t.Fatal(err)
+		}
+		var got Report
+		if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+			t.Fatalf("Failed to parse the JSON report: %v", err)
+		}
+		if diff := cmp.Diff(report, got); diff != "" {
+			t.Errorf("Unexpected report (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("sarif", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := report.Write(&buf, SARIFReport, "1.0.0"); err != nil {
+			t.Fatal(err)
+		}
+		var got sarifLog
+		if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+			t.Fatalf("Failed to parse the SARIF report: %v", err)
+		}
+		run := got.Runs[0]
+		if len(run.Tool.Driver.Rules) != 2 || run.Tool.Driver.Version != "1.0.0" {
+			t.Errorf("Expected one rule per provider and the tool version, got %+v", run.Tool.Driver)
+		}
+		var levels []string
+		for _, result := range run.Results {
+			levels = append(levels, result.Level)
+		}
+		if diff := cmp.Diff([]string{"warning", "error", "note"}, levels); diff != "" {
+			t.Errorf("Unexpected result levels (-want +got):\n%s", diff)
+		}
+		location := run.Results[0].Locations[0]
+		if location.PhysicalLocation.ArtifactLocation.URI != "manifests/web.yaml" || location.LogicalLocations[0].FullyQualifiedName != "Ingress/default/web" {
+			t.Errorf("Unexpected location %+v", location)
+		}
+	})
+
+	t.Run("junit", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := report.Write(&buf, JUnitReport, "1.0.0"); err != nil {
+			t.Fatal(err)
+		}
+		var got junitTestSuites
+		if err := xml.Unmarshal(buf.Bytes(), &got); err != nil {
+			t.Fatalf("Failed to parse the JUnit report: %v", err)
+		}
+		if got.Tests != 3 || got.Failures != 1 || len(got.Suites) != 2 {
+			t.Errorf("Expected 3 tests with 1 failure in 2 suites, got %d tests with %d failures in %d suites", got.Tests, got.Failures, len(got.Suites))
+		}
+		if failure := got.Suites[0].TestCases[1].Failure; failure == nil || failure.Type != string(ErrorNotification) {
+			t.Errorf("Expected the conversion error to be a failure, got %+v", got.Suites[0].TestCases[1])
+		}
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		if err := report.Write(&bytes.Buffer{}, "yaml", "1.0.0"); err == nil {
+			t.Errorf("Expected an error for an unsupported format")
+		}
+	})
+}
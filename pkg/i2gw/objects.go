@@ -0,0 +1,129 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package i2gw
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/intermediate"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+// GeneratorAnnotationValue returns the value of the GeneratorAnnotationKey
+// annotation set on the generated objects.
+func GeneratorAnnotationValue() string {
+	return fmt.Sprintf("ingress2gateway-%s", CurrentVersion)
+}
+
+// ToObjects flattens the resources generated by every provider into a single
+// list of objects. The objects are copies with their GroupVersionKind set, and
+// all of them but GatewayClasses and Gateway extensions carry the generator
+// annotation.
+//
+// The objects are sorted by kind, in the order GatewayClasses, Gateways,
+// HTTPRoutes, TLSRoutes, TCPRoutes, UDPRoutes, ReferenceGrants and Gateway
+// extensions, then by namespace and name, so that identical inputs always
+// produce identical output.
+func ToObjects(gatewayResources []GatewayResources) []client.Object {
+	var objects []client.Object
+
+	add := func(obj client.Object, gvk schema.GroupVersionKind, annotate bool) {
+		if obj.GetObjectKind().GroupVersionKind().Empty() {
+			obj.GetObjectKind().SetGroupVersionKind(gvk)
+		}
+		if annotate {
+			annotations := obj.GetAnnotations()
+			if annotations == nil {
+				annotations = make(map[string]string)
+			}
+			annotations[GeneratorAnnotationKey] = GeneratorAnnotationValue()
+			obj.SetAnnotations(annotations)
+		}
+		objects = append(objects, obj)
+	}
+
+	for _, r := range gatewayResources {
+		for _, gatewayClass := range r.GatewayClasses {
+			add(gatewayClass.DeepCopy(), gatewayv1.SchemeGroupVersion.WithKind("GatewayClass"), false)
+		}
+		for _, gateway := range r.Gateways {
+			add(gateway.DeepCopy(), gatewayv1.SchemeGroupVersion.WithKind("Gateway"), true)
+		}
+		for _, httpRoute := range r.HTTPRoutes {
+			add(httpRoute.DeepCopy(), gatewayv1.SchemeGroupVersion.WithKind("HTTPRoute"), true)
+		}
+		for _, tlsRoute := range r.TLSRoutes {
+			add(tlsRoute.DeepCopy(), gatewayv1alpha2.SchemeGroupVersion.WithKind("TLSRoute"), true)
+		}
+		for _, tcpRoute := range r.TCPRoutes {
+			add(tcpRoute.DeepCopy(), gatewayv1alpha2.SchemeGroupVersion.WithKind("TCPRoute"), true)
+		}
+		for _, udpRoute := range r.UDPRoutes {
+			add(udpRoute.DeepCopy(), gatewayv1alpha2.SchemeGroupVersion.WithKind("UDPRoute"), true)
+		}
+		for _, referenceGrant := range r.ReferenceGrants {
+			add(referenceGrant.DeepCopy(), gatewayv1beta1.SchemeGroupVersion.WithKind("ReferenceGrant"), true)
+		}
+		for _, gatewayExtension := range r.GatewayExtensions {
+			add(gatewayExtension.DeepCopy(), gatewayExtension.GroupVersionKind(), false)
+		}
+	}
+
+	slices.SortStableFunc(objects, compareObjects)
+	return objects
+}
+
+// objectKindOrder is the order of the kinds generated by the conversion.
+// Gateway extensions have any other kind, and come last.
+var objectKindOrder = []string{"GatewayClass", "Gateway", "HTTPRoute", "TLSRoute", "TCPRoute", "UDPRoute", "ReferenceGrant"}
+
+// compareObjects orders objects by kind, as in objectKindOrder, then by
+// namespace and name.
+func compareObjects(a, b client.Object) int {
+	aKind, bKind := a.GetObjectKind().GroupVersionKind().Kind, b.GetObjectKind().GroupVersionKind().Kind
+	kindIndex := func(kind string) int {
+		if i := slices.Index(objectKindOrder, kind); i >= 0 {
+			return i
+		}
+		return len(objectKindOrder)
+	}
+	return cmp.Or(
+		cmp.Compare(kindIndex(aKind), kindIndex(bKind)),
+		cmp.Compare(aKind, bKind),
+		cmp.Compare(a.GetNamespace(), b.GetNamespace()),
+		cmp.Compare(a.GetName(), b.GetName()),
+	)
+}
+
+// MergeSources merges the sources recorded by every provider.
+func MergeSources(gatewayResources []GatewayResources) map[intermediate.ObjectRef][]intermediate.ObjectRef {
+	sources := make(map[intermediate.ObjectRef][]intermediate.ObjectRef)
+	for _, r := range gatewayResources {
+		for object, objectSources := range r.Sources {
+			for _, source := range objectSources {
+				intermediate.AddSource(sources, object, source)
+			}
+		}
+	}
+	return sources
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package i2gw
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+func Test_ToObjects(t *testing.T) {
+	gateway := func(namespace, name string) gatewayv1.Gateway {
+		return gatewayv1.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	}
+	httpRoute := func(namespace, name string) gatewayv1.HTTPRoute {
+		return gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	}
+	extension := func(kind, name string) unstructured.Unstructured {
+		u := unstructured.Unstructured{}
+		u.SetAPIVersion("networking.gke.io/v1")
+		u.SetKind(kind)
+		u.SetNamespace("default")
+		u.SetName(name)
+		return u
+	}
+
+	gatewayResources := []GatewayResources{
+		{
+			HTTPRoutes: map[types.NamespacedName]gatewayv1.HTTPRoute{
+				{Namespace: "default", Name: "b"}: httpRoute("default", "b"),
+				{Namespace: "default", Name: "a"}: httpRoute("default", "a"),
+				{Namespace: "apps", Name: "z"}:    httpRoute("apps", "z"),
+			},
+			Gateways: map[types.NamespacedName]gatewayv1.Gateway{
+				{Namespace: "default", Name: "nginx"}: gateway("default", "nginx"),
+			},
+			GatewayExtensions: []unstructured.Unstructured{
+				extension("HealthCheckPolicy", "a"),
+				extension("GCPBackendPolicy", "b"),
+			},
+		},
+		{
+			Gateways: map[types.NamespacedName]gatewayv1.Gateway{
+				{Namespace: "apps", Name: "kong"}: gateway("apps", "kong"),
+			},
+			GatewayClasses: map[types.NamespacedName]gatewayv1.GatewayClass{
+				{Name: "kong"}: {ObjectMeta: metav1.ObjectMeta{Name: "kong"}},
+			},
+			ReferenceGrants: map[types.NamespacedName]gatewayv1beta1.ReferenceGrant{
+				{Namespace: "default", Name: "grant"}: {ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "grant"}},
+			},
+		},
+	}
+
+	expectedObjects := []string{
+		"GatewayClass//kong",
+		"Gateway/apps/kong",
+		"Gateway/default/nginx",
+		"HTTPRoute/apps/z",
+		"HTTPRoute/default/a",
+		"HTTPRoute/default/b",
+		"ReferenceGrant/default/grant",
+		"GCPBackendPolicy/default/b",
+		"HealthCheckPolicy/default/a",
+	}
+
+	// The resources are held in maps, so the conversion is repeated to catch
+	// a random order.
+	for i := 0; i < 10; i++ {
+		var gotObjects []string
+		for _, obj := range ToObjects(gatewayResources) {
+			gotObjects = append(gotObjects, obj.GetObjectKind().GroupVersionKind().Kind+"/"+obj.GetNamespace()+"/"+obj.GetName())
+		}
+		if diff := cmp.Diff(expectedObjects, gotObjects); diff != "" {
+			t.Fatalf("Unexpected objects order (-want +got): %s", diff)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package i2gw
+
+import (
+	"cmp"
+	"encoding/json"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/intermediate"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// SourcesAnnotationKey is set on generated objects to the comma-separated
+	// list of the objects they were converted from, as Kind/namespace/name.
+	SourcesAnnotationKey = "ingress2gateway.kubernetes.io/sources"
+	// RuleSourcesAnnotationKey is set on generated routes to the JSON list of
+	// the sources of their rules.
+	RuleSourcesAnnotationKey = "ingress2gateway.kubernetes.io/rule-sources"
+)
+
+// parseSourcesAnnotation returns the objects listed by the value of the
+// SourcesAnnotationKey annotation, skipping the malformed ones.
+func parseSourcesAnnotation(value string) []intermediate.ObjectRef {
+	var sources []intermediate.ObjectRef
+	for _, source := range strings.Split(value, ",") {
+		switch parts := strings.Split(strings.TrimSpace(source), "/"); len(parts) {
+		case 2:
+			sources = append(sources, intermediate.ObjectRef{Kind: parts[0], Name: parts[1]})
+		case 3:
+			sources = append(sources, intermediate.ObjectRef{Kind: parts[0], Namespace: parts[1], Name: parts[2]})
+		}
+	}
+	return sources
+}
+
+// ObjectProvenance links a generated object to the objects it was converted
+// from, and each of its rules to the Ingress paths it was converted from.
+type ObjectProvenance struct {
+	Object  intermediate.ObjectRef   `json:"object"`
+	Sources []intermediate.ObjectRef `json:"sources"`
+	Rules   []RuleProvenance         `json:"rules,omitempty"`
+}
+
+// RuleProvenance holds the sources of the rule at Index.
+type RuleProvenance struct {
+	Index   int                       `json:"index"`
+	Sources []intermediate.RuleSource `json:"sources"`
+}
+
+// Provenance merges the sources recorded by every provider, and returns the
+// provenance of every generated object with a known source, sorted by kind,
+// namespace and name.
+func Provenance(gatewayResources []GatewayResources) []ObjectProvenance {
+	sources := MergeSources(gatewayResources)
+	ruleSources := make(map[intermediate.ObjectRef]map[int][]intermediate.RuleSource)
+	for _, r := range gatewayResources {
+		for route, routeRuleSources := range r.RuleSources {
+			for ruleIdx, sources := range routeRuleSources {
+				for _, source := range sources {
+					intermediate.AddRuleSource(ruleSources, route, ruleIdx, source)
+				}
+			}
+		}
+	}
+
+	var provenance []ObjectProvenance
+	for object, objectSources := range sources {
+		p := ObjectProvenance{Object: object, Sources: objectSources}
+		for ruleIdx, sources := range ruleSources[object] {
+			p.Rules = append(p.Rules, RuleProvenance{Index: ruleIdx, Sources: sources})
+		}
+		slices.SortFunc(p.Rules, func(a, b RuleProvenance) int {
+			return cmp.Compare(a.Index, b.Index)
+		})
+		provenance = append(provenance, p)
+	}
+	slices.SortFunc(provenance, func(a, b ObjectProvenance) int {
+		return cmp.Or(
+			cmp.Compare(a.Object.Kind, b.Object.Kind),
+			cmp.Compare(a.Object.Namespace, b.Object.Namespace),
+			cmp.Compare(a.Object.Name, b.Object.Name),
+		)
+	})
+	return provenance
+}
+
+// AddProvenanceAnnotations sets the SourcesAnnotationKey and
+// RuleSourcesAnnotationKey annotations on the generated objects with a known
+// provenance.
+func AddProvenanceAnnotations(gatewayResources []GatewayResources) error {
+	annotationsByObject := make(map[intermediate.ObjectRef]map[string]string)
+	for _, p := range Provenance(gatewayResources) {
+		sources := make([]string, 0, len(p.Sources))
+		for _, source := range p.Sources {
+			sources = append(sources, source.String())
+		}
+		annotations := map[string]string{SourcesAnnotationKey: strings.Join(sources, ",")}
+		if len(p.Rules) > 0 {
+			rules, err := json.Marshal(p.Rules)
+			if err != nil {
+				return err
+			}
+			annotations[RuleSourcesAnnotationKey] = string(rules)
+		}
+		annotationsByObject[p.Object] = annotations
+	}
+
+	for _, r := range gatewayResources {
+		annotateObjects(r.Gateways, "Gateway", annotationsByObject)
+		annotateObjects(r.HTTPRoutes, "HTTPRoute", annotationsByObject)
+		annotateObjects(r.TLSRoutes, "TLSRoute", annotationsByObject)
+		annotateObjects(r.TCPRoutes, "TCPRoute", annotationsByObject)
+		annotateObjects(r.UDPRoutes, "UDPRoute", annotationsByObject)
+		annotateObjects(r.ReferenceGrants, "ReferenceGrant", annotationsByObject)
+	}
+	return nil
+}
+
+// annotateObjects adds the annotations of every object of the given kind.
+// The annotations maps are copied, as they may be shared with the IR.
+func annotateObjects[T any, PT interface {
+	*T
+	metav1.Object
+}](objects map[types.NamespacedName]T, kind string, annotationsByObject map[intermediate.ObjectRef]map[string]string) {
+	for key, obj := range objects {
+		annotations, ok := annotationsByObject[intermediate.ObjectRef{Kind: kind, Namespace: key.Namespace, Name: key.Name}]
+		if !ok {
+			continue
+		}
+		objAnnotations := maps.Clone(PT(&obj).GetAnnotations())
+		if objAnnotations == nil {
+			objAnnotations = make(map[string]string)
+		}
+		maps.Copy(objAnnotations, annotations)
+		PT(&obj).SetAnnotations(objAnnotations)
+		objects[key] = obj
+	}
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package i2gw
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/intermediate"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func Test_AddProvenanceAnnotations(t *testing.T) {
+	ingressRef := func(name string) intermediate.ObjectRef {
+		return intermediate.ObjectRef{Kind: "Ingress", Namespace: "default", Name: name}
+	}
+	routeRef := intermediate.ObjectRef{Kind: "HTTPRoute", Namespace: "default", Name: "foo-example-com"}
+	gatewayRef := intermediate.ObjectRef{Kind: "Gateway", Namespace: "default", Name: "nginx"}
+
+	routeAnnotations := map[string]string{"example.com/kept": "true"}
+	gatewayResources := []GatewayResources{
+		{
+			Gateways: map[types.NamespacedName]gatewayv1.Gateway{
+				{Namespace: "default", Name: "nginx"}: {ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nginx"}},
+			},
+			HTTPRoutes: map[types.NamespacedName]gatewayv1.HTTPRoute{
+				{Namespace: "default", Name: "foo-example-com"}: {ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo-example-com", Annotations: routeAnnotations}},
+				{Namespace: "default", Name: "unknown"}:         {ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "unknown"}},
+			},
+			Sources: map[intermediate.ObjectRef][]intermediate.ObjectRef{
+				routeRef:   {ingressRef("bar"), ingressRef("foo")},
+				gatewayRef: {ingressRef("bar"), ingressRef("foo")},
+			},
+			RuleSources: map[intermediate.ObjectRef]map[int][]intermediate.RuleSource{
+				routeRef: {
+					1: {{Source: ingressRef("bar"), RuleIndex: 1, PathIndex: 0}},
+					0: {{Source: ingressRef("bar"), RuleIndex: 0, PathIndex: 2}, {Source: ingressRef("foo"), RuleIndex: 0, PathIndex: 0}},
+				},
+			},
+		},
+	}
+
+	expectedProvenance := []ObjectProvenance{
+		{
+			Object:  gatewayRef,
+			Sources: []intermediate.ObjectRef{ingressRef("bar"), ingressRef("foo")},
+		},
+		{
+			Object:  routeRef,
+			Sources: []intermediate.ObjectRef{ingressRef("bar"), ingressRef("foo")},
+			Rules: []RuleProvenance{
+				{Index: 0, Sources: []intermediate.RuleSource{{Source: ingressRef("bar"), RuleIndex: 0, PathIndex: 2}, {Source: ingressRef("foo"), RuleIndex: 0, PathIndex: 0}}},
+				{Index: 1, Sources: []intermediate.RuleSource{{Source: ingressRef("bar"), RuleIndex: 1, PathIndex: 0}}},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedProvenance, Provenance(gatewayResources)); diff != "" {
+		t.Errorf("Unexpected provenance (-want +got):\n%s", diff)
+	}
+
+	if err := AddProvenanceAnnotations(gatewayResources); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expectedGatewayAnnotations := map[string]string{
+		SourcesAnnotationKey: "Ingress/default/bar,Ingress/default/foo",
+	}
+	if diff := cmp.Diff(expectedGatewayAnnotations, gatewayResources[0].Gateways[types.NamespacedName{Namespace: "default", Name: "nginx"}].Annotations); diff != "" {
+		t.Errorf("Unexpected Gateway annotations (-want +got):\n%s", diff)
+	}
+
+	expectedRouteAnnotations := map[string]string{
+		"example.com/kept":       "true",
+		SourcesAnnotationKey:     "Ingress/default/bar,Ingress/default/foo",
+		RuleSourcesAnnotationKey: `[{"index":0,"sources":[{"source":{"kind":"Ingress","namespace":"default","name":"bar"},"ruleIndex":0,"pathIndex":2},{"source":{"kind":"Ingress","namespace":"default","name":"foo"},"ruleIndex":0,"pathIndex":0}]},{"index":1,"sources":[{"source":{"kind":"Ingress","namespace":"default","name":"bar"},"ruleIndex":1,"pathIndex":0}]}]`,
+	}
+	if diff := cmp.Diff(expectedRouteAnnotations, gatewayResources[0].HTTPRoutes[types.NamespacedName{Namespace: "default", Name: "foo-example-com"}].Annotations); diff != "" {
+		t.Errorf("Unexpected HTTPRoute annotations (-want +got):\n%s", diff)
+	}
+
+	if annotations := gatewayResources[0].HTTPRoutes[types.NamespacedName{Namespace: "default", Name: "unknown"}].Annotations; annotations != nil {
+		t.Errorf("Expected no annotations on objects without provenance, got %v", annotations)
+	}
+	if len(routeAnnotations) != 1 {
+		t.Errorf("Expected the original annotations to be left unchanged, got %v", routeAnnotations)
+	}
+}
@@ -18,11 +18,16 @@ package i2gw
 
 import (
 	"context"
+	"io/fs"
+	"slices"
+	"strings"
 	"sync"
 
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/intermediate"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
 	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -49,6 +54,42 @@ type ProviderConf struct {
 	Client                client.Client
 	Namespace             string
 	ProviderSpecificFlags map[string]map[string]string
+	// DisabledFeatures lists the feature parsers skipped by ParseFeatures,
+	// by provider.
+	DisabledFeatures map[ProviderName][]string
+	// Tracer, if set, is called after every stage of the conversion.
+	Tracer Tracer
+	// Notifications, if set, collects the notifications of the conversion.
+	Notifications *notifications.NotificationAggregator
+	// BestEffort makes the providers skip the resources, rules or paths they
+	// can not convert, and return the resources of the others along with
+	// the errors, instead of no resources at all.
+	BestEffort bool
+	// InputFiles holds the manifests read in memory for this conversion, by
+	// name, such as the standard input or a rendered Helm chart. They are read instead of the files
+	// of the same name, see ReadInputFile.
+	InputFiles map[string][]byte
+}
+
+// Notify sends notification to the Notifications of the conf, if any, as a
+// notification of provider.
+func (c *ProviderConf) Notify(provider ProviderName, notification notifications.Notification) {
+	if c == nil || c.Notifications == nil {
+		return
+	}
+	c.Notifications.DispatchNotification(notification, string(provider))
+}
+
+// ReadInputFile reads the named input file from the InputFiles of the conf,
+// which may be nil. The file system is never read: an input file which is not
+// in InputFiles does not exist.
+func (c *ProviderConf) ReadInputFile(filename string) ([]byte, error) {
+	if c != nil {
+		if data, ok := c.InputFiles[filename]; ok {
+			return data, nil
+		}
+	}
+	return nil, &fs.PathError{Op: "read", Path: filename, Err: fs.ErrNotExist}
 }
 
 // The Provider interface specifies the required functionality which needs to be
@@ -66,9 +107,9 @@ type CustomResourceReader interface {
 	// the underlying Provider implementation from the kubernetes cluster.
 	ReadResourcesFromCluster(ctx context.Context) error
 
-	// ReadResourcesFromFile reads custom resources associated with
-	// the underlying Provider implementation from the file.
-	ReadResourcesFromFile(ctx context.Context, filename string) error
+	// ReadResourcesFromFiles reads custom resources associated with
+	// the underlying Provider implementation from the files.
+	ReadResourcesFromFiles(ctx context.Context, filenames []string) error
 }
 
 // The ResourcesToIRConverter interface specifies conversion functions from Ingress
@@ -111,14 +152,22 @@ type GatewayResources struct {
 	ReferenceGrants map[types.NamespacedName]gatewayv1beta1.ReferenceGrant
 
 	GatewayExtensions []unstructured.Unstructured
+
+	// Sources maps generated objects to the objects they were converted from.
+	Sources map[intermediate.ObjectRef][]intermediate.ObjectRef
+
+	// RuleSources maps generated routes to the sources of their rules, by
+	// rule index.
+	RuleSources map[intermediate.ObjectRef]map[int][]intermediate.RuleSource
 }
 
 // FeatureParser is a function that reads the Ingresses, and applies
-// the appropriate modifications to the GatewayResources.
+// the appropriate modifications to the GatewayResources. Notifications are
+// sent with the Notify method of the ProviderConf, which may be nil.
 //
 // Different FeatureParsers will run in undetermined order. The function must
 // modify / create only the required fields of the gateway resources and nothing else.
-type FeatureParser func([]networkingv1.Ingress, *intermediate.IR) field.ErrorList
+type FeatureParser func(*ProviderConf, []networkingv1.Ingress, *intermediate.IR) field.ErrorList
 
 var providerSpecificFlagDefinitions = providerSpecificFlags{
 	flags: make(map[ProviderName]map[string]ProviderSpecificFlag),
@@ -164,3 +213,111 @@ func RegisterProviderSpecificFlag(provider ProviderName, flag ProviderSpecificFl
 func GetProviderSpecificFlagDefinitions() map[ProviderName]map[string]ProviderSpecificFlag {
 	return providerSpecificFlagDefinitions.all()
 }
+
+var providerAnnotationDefinitions = providerAnnotations{
+	annotations: make(map[ProviderName]ProviderAnnotations),
+	mu:          sync.RWMutex{},
+}
+
+type providerAnnotations struct {
+	annotations map[ProviderName]ProviderAnnotations
+	mu          sync.RWMutex // thread-safe, so provider annotations can be registered concurrently.
+}
+
+// ProviderAnnotations declares the Ingress annotations of a provider, and the
+// ones it converts.
+type ProviderAnnotations struct {
+	// Prefixes are the prefixes of the annotations of the provider, e.g.
+	// konghq.com/.
+	Prefixes []string
+	// Keys are the annotations converted by the provider.
+	Keys []string
+	// KeyPrefixes are the prefixes of families of annotations converted by
+	// the provider, e.g. konghq.com/headers. for konghq.com/headers.<name>.
+	KeyPrefixes []string
+}
+
+// IsProviderAnnotation returns whether key has one of the provider prefixes.
+func (a ProviderAnnotations) IsProviderAnnotation(key string) bool {
+	return slices.ContainsFunc(a.Prefixes, func(prefix string) bool { return strings.HasPrefix(key, prefix) })
+}
+
+// IsConverted returns whether the annotation key is converted by the provider.
+func (a ProviderAnnotations) IsConverted(key string) bool {
+	return slices.Contains(a.Keys, key) ||
+		slices.ContainsFunc(a.KeyPrefixes, func(prefix string) bool { return strings.HasPrefix(key, prefix) })
+}
+
+func (a *providerAnnotations) add(provider ProviderName, annotations ProviderAnnotations) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.annotations[provider] = annotations
+}
+
+func (a *providerAnnotations) all() map[ProviderName]ProviderAnnotations {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.annotations
+}
+
+// RegisterProviderAnnotations registers the Ingress annotations of a provider,
+// which are reported by the audit command when they are not converted.
+// RegisterProviderAnnotations is thread-safe.
+func RegisterProviderAnnotations(provider ProviderName, annotations ProviderAnnotations) {
+	providerAnnotationDefinitions.add(provider, annotations)
+}
+
+// GetProviderAnnotations returns the Ingress annotations registered by the
+// providers.
+func GetProviderAnnotations() map[ProviderName]ProviderAnnotations {
+	return providerAnnotationDefinitions.all()
+}
+
+var providerResourceKinds = providerKinds{
+	kinds: make(map[ProviderName][]schema.GroupVersionKind),
+	mu:    sync.RWMutex{},
+}
+
+type providerKinds struct {
+	kinds map[ProviderName][]schema.GroupVersionKind
+	mu    sync.RWMutex // thread-safe, so provider resource kinds can be registered concurrently.
+}
+
+func (k *providerKinds) add(provider ProviderName, kinds []schema.GroupVersionKind) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.kinds[provider] = append(k.kinds[provider], kinds...)
+}
+
+func (k *providerKinds) get(provider ProviderName) []schema.GroupVersionKind {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return slices.Clone(k.kinds[provider])
+}
+
+// find returns the registered kind named kind, of any provider.
+func (k *providerKinds) find(kind string) (schema.GroupVersionKind, bool) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	for _, kinds := range k.kinds {
+		for _, gvk := range kinds {
+			if gvk.Kind == kind {
+				return gvk, true
+			}
+		}
+	}
+	return schema.GroupVersionKind{}, false
+}
+
+// RegisterProviderResourceKinds registers the kinds of the resources a
+// provider reads from the cluster, which are watched by the sync command.
+// RegisterProviderResourceKinds is thread-safe.
+func RegisterProviderResourceKinds(provider ProviderName, kinds ...schema.GroupVersionKind) {
+	providerResourceKinds.add(provider, kinds)
+}
+
+// GetProviderResourceKinds returns the kinds of the resources registered by
+// provider.
+func GetProviderResourceKinds(provider ProviderName) []schema.GroupVersionKind {
+	return providerResourceKinds.get(provider)
+}
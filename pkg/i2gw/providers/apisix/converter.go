@@ -26,13 +26,16 @@ import (
 
 // resourcesToIRConverter implements the ToIR function of i2gw.ResourcesToIRConverter interface.
 type resourcesToIRConverter struct {
+	conf *i2gw.ProviderConf
+
 	featureParsers                []i2gw.FeatureParser
 	implementationSpecificOptions i2gw.ProviderImplementationSpecificOptions
 }
 
 // newResourcesToIRConverter returns an apisix resourcesToIRConverter instance.
-func newResourcesToIRConverter() *resourcesToIRConverter {
+func newResourcesToIRConverter(conf *i2gw.ProviderConf) *resourcesToIRConverter {
 	return &resourcesToIRConverter{
+		conf: conf,
 		featureParsers: []i2gw.FeatureParser{
 			httpToHTTPSFeature,
 		},
@@ -47,19 +50,19 @@ func (c *resourcesToIRConverter) convertToIR(storage *storage) (intermediate.IR,
 	for _, ing := range storage.Ingresses {
 		ingressList = append(ingressList, *ing)
 	}
+	ingressList = common.ResolveServicePorts(c.conf, Name, ingressList, storage.Services)
+	common.NotifyDefaultBackendConflicts(c.conf, Name, ingressList)
 	// Convert plain ingress resources to gateway resources, ignoring all
 	// provider-specific features.
 	ir, errs := common.ToIR(ingressList, c.implementationSpecificOptions)
-	if len(errs) > 0 {
+	if len(errs) > 0 && !c.conf.BestEffort {
 		return intermediate.IR{}, errs
 	}
+	common.NotifyUnusedTLSHosts(c.conf, Name, ingressList, &ir)
+	c.conf.TraceIR(Name, i2gw.TraceStageCommonToIR, ir)
 
-	for _, parseFeatureFunc := range c.featureParsers {
-		// Apply the feature parsing function to the gateway resources, one by one.
-		parseErrs := parseFeatureFunc(ingressList, &ir)
-		// Append the parsing errors to the error list.
-		errs = append(errs, parseErrs...)
-	}
+	// Apply the feature parsing functions to the gateway resources, one by one.
+	errs = append(errs, i2gw.ParseFeatures(c.conf, Name, c.featureParsers, ingressList, &ir)...)
 
 	return ir, errs
 }
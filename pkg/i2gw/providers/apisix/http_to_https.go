@@ -19,6 +19,7 @@ package apisix
 import (
 	"fmt"
 
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/intermediate"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/providers/common"
@@ -29,7 +30,7 @@ import (
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
-func httpToHTTPSFeature(ingresses []networkingv1.Ingress, ir *intermediate.IR) field.ErrorList {
+func httpToHTTPSFeature(conf *i2gw.ProviderConf, ingresses []networkingv1.Ingress, ir *intermediate.IR) field.ErrorList {
 	var errs field.ErrorList
 	httpToHTTPSAnnotation := apisixAnnotation("http-to-https")
 	ruleGroups := common.GetRuleGroups(ingresses)
@@ -39,24 +40,41 @@ func httpToHTTPSFeature(ingresses []networkingv1.Ingress, ir *intermediate.IR) f
 				if rule.Ingress.Spec.Rules == nil {
 					continue
 				}
-				key := types.NamespacedName{Namespace: rule.Ingress.Namespace, Name: common.RouteName(rg.Name, rg.Host)}
-				httpRoute, ok := ir.HTTPRoutes[key]
-				if !ok {
+				keys := common.HTTPRouteKeys(ir, rule.Ingress.Namespace, rg.IngressClass, rg.Host)
+				if len(keys) == 0 {
+					key := types.NamespacedName{Namespace: rule.Ingress.Namespace, Name: common.RouteName(rg.Name, rg.Host)}
 					errs = append(errs, field.NotFound(field.NewPath("HTTPRoute"), key))
+					continue
 				}
 
-				for i, rule := range httpRoute.Spec.Rules {
-					rule.Filters = append(rule.Filters, gatewayv1.HTTPRouteFilter{
-						Type: gatewayv1.HTTPRouteFilterRequestRedirect,
-						RequestRedirect: &gatewayv1.HTTPRequestRedirectFilter{
-							Scheme:     ptr.To("https"),
-							StatusCode: ptr.To(int(301)),
-						},
-					})
-					httpRoute.Spec.Rules[i] = rule
+				// Without an HTTPS listener on the Gateway, the TLS of the
+				// host may be terminated in front of it, and all the requests
+				// are redirected in place as before.
+				if !common.HasHTTPSListener(ir, keys[0]) {
+					for _, key := range keys {
+						httpRoute := ir.HTTPRoutes[key]
+						for i, rule := range httpRoute.Spec.Rules {
+							rule.Filters = append(rule.Filters, gatewayv1.HTTPRouteFilter{
+								Type: gatewayv1.HTTPRouteFilterRequestRedirect,
+								RequestRedirect: &gatewayv1.HTTPRequestRedirectFilter{
+									Scheme:     ptr.To("https"),
+									StatusCode: ptr.To(301),
+								},
+							})
+							httpRoute.Spec.Rules[i] = rule
+						}
+						ir.HTTPRoutes[key] = httpRoute
+					}
+					if annotationFound {
+						httpRoute := ir.HTTPRoutes[keys[0]]
+						notify(conf, notifications.WarningNotification, fmt.Sprintf("parsed \"%v\" annotation of ingress and patched %v fields, as the HTTPRoute %v has no HTTPS listener", httpToHTTPSAnnotation, field.NewPath("httproute", "spec", "rules").Key("").Child("filters"), keys[0]), &httpRoute)
+					}
+					continue
 				}
-				if annotationFound && ok {
-					notify(notifications.InfoNotification, fmt.Sprintf("parsed \"%v\" annotation of ingress and patched %v fields", httpToHTTPSAnnotation, field.NewPath("httproute", "spec", "rules").Key("").Child("filters")), &httpRoute)
+
+				if redirectKey, ok := common.RedirectToHTTPS(conf, Name, ir, keys, 301); ok && annotationFound {
+					httpRoute := ir.HTTPRoutes[redirectKey]
+					notify(conf, notifications.InfoNotification, fmt.Sprintf("parsed \"%v\" annotation of ingress and redirected the HTTP requests of HTTPRoute %v to HTTPS", httpToHTTPSAnnotation, keys[0]), &httpRoute)
 				}
 			}
 		}
@@ -32,12 +32,29 @@ import (
 )
 
 func Test_httpToHttpsFeature(t *testing.T) {
+	listeners := []gatewayv1.Listener{
+		{
+			Name:     "example-com-http",
+			Hostname: ptr.To[gatewayv1.Hostname]("example.com"),
+			Port:     80,
+			Protocol: gatewayv1.HTTPProtocolType,
+		},
+		{
+			Name:     "example-com-https",
+			Hostname: ptr.To[gatewayv1.Hostname]("example.com"),
+			Port:     443,
+			Protocol: gatewayv1.HTTPSProtocolType,
+		},
+	}
+
 	testCases := []struct {
-		name              string
-		ingress           networkingv1.Ingress
-		initialHTTPRoute  *gatewayv1.HTTPRoute
-		expectedHTTPRoute *gatewayv1.HTTPRoute
-		expectedError     field.ErrorList
+		name                      string
+		ingress                   networkingv1.Ingress
+		listeners                 []gatewayv1.Listener
+		initialHTTPRoute          *gatewayv1.HTTPRoute
+		expectedHTTPRoute         *gatewayv1.HTTPRoute
+		expectedRedirectHTTPRoute *gatewayv1.HTTPRoute
+		expectedError             field.ErrorList
 	}{
 		{
 			name: "annotation present",
@@ -57,12 +74,16 @@ func Test_httpToHttpsFeature(t *testing.T) {
 					},
 				},
 			},
+			listeners: listeners,
 			initialHTTPRoute: &gatewayv1.HTTPRoute{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-ingress-example-com",
 					Namespace: "default",
 				},
 				Spec: gatewayv1.HTTPRouteSpec{
+					CommonRouteSpec: gatewayv1.CommonRouteSpec{
+						ParentRefs: []gatewayv1.ParentReference{{Name: "apisix"}},
+					},
 					Hostnames: []gatewayv1.Hostname{"example.com"},
 					Rules: []gatewayv1.HTTPRouteRule{
 						{
@@ -79,12 +100,35 @@ func Test_httpToHttpsFeature(t *testing.T) {
 					Namespace: "default",
 				},
 				Spec: gatewayv1.HTTPRouteSpec{
+					CommonRouteSpec: gatewayv1.CommonRouteSpec{
+						ParentRefs: []gatewayv1.ParentReference{{Name: "apisix", SectionName: ptr.To[gatewayv1.SectionName]("example-com-https")}},
+					},
 					Hostnames: []gatewayv1.Hostname{"example.com"},
 					Rules: []gatewayv1.HTTPRouteRule{
 						{
 							BackendRefs: []gatewayv1.HTTPBackendRef{
 								{BackendRef: gatewayv1.BackendRef{BackendObjectReference: gatewayv1.BackendObjectReference{Name: "example", Port: ptr.To(gatewayv1.PortNumber(3000))}}},
 							},
+						},
+					},
+				},
+			},
+			expectedRedirectHTTPRoute: &gatewayv1.HTTPRoute{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "gateway.networking.k8s.io/v1",
+					Kind:       "HTTPRoute",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-ingress-example-com-https-redirect",
+					Namespace: "default",
+				},
+				Spec: gatewayv1.HTTPRouteSpec{
+					CommonRouteSpec: gatewayv1.CommonRouteSpec{
+						ParentRefs: []gatewayv1.ParentReference{{Name: "apisix", SectionName: ptr.To[gatewayv1.SectionName]("example-com-http")}},
+					},
+					Hostnames: []gatewayv1.Hostname{"example.com"},
+					Rules: []gatewayv1.HTTPRouteRule{
+						{
 							Filters: []gatewayv1.HTTPRouteFilter{
 								{
 									Type: gatewayv1.HTTPRouteFilterRequestRedirect,
@@ -97,6 +141,11 @@ func Test_httpToHttpsFeature(t *testing.T) {
 						},
 					},
 				},
+				Status: gatewayv1.HTTPRouteStatus{
+					RouteStatus: gatewayv1.RouteStatus{
+						Parents: []gatewayv1.RouteParentStatus{},
+					},
+				},
 			},
 			expectedError: field.ErrorList{},
 		},
@@ -115,12 +164,75 @@ func Test_httpToHttpsFeature(t *testing.T) {
 					},
 				},
 			},
+			listeners: listeners,
+			initialHTTPRoute: &gatewayv1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-ingress-example-com",
+					Namespace: "default",
+				},
+				Spec: gatewayv1.HTTPRouteSpec{
+					CommonRouteSpec: gatewayv1.CommonRouteSpec{
+						ParentRefs: []gatewayv1.ParentReference{{Name: "apisix"}},
+					},
+					Hostnames: []gatewayv1.Hostname{"example.com"},
+					Rules: []gatewayv1.HTTPRouteRule{
+						{
+							BackendRefs: []gatewayv1.HTTPBackendRef{
+								{BackendRef: gatewayv1.BackendRef{BackendObjectReference: gatewayv1.BackendObjectReference{Name: "example", Port: ptr.To(gatewayv1.PortNumber(3000))}}},
+							},
+						},
+					},
+				},
+			},
+			expectedHTTPRoute: &gatewayv1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-ingress-example-com",
+					Namespace: "default",
+				},
+				Spec: gatewayv1.HTTPRouteSpec{
+					CommonRouteSpec: gatewayv1.CommonRouteSpec{
+						ParentRefs: []gatewayv1.ParentReference{{Name: "apisix"}},
+					},
+					Hostnames: []gatewayv1.Hostname{"example.com"},
+					Rules: []gatewayv1.HTTPRouteRule{
+						{
+							BackendRefs: []gatewayv1.HTTPBackendRef{
+								{BackendRef: gatewayv1.BackendRef{BackendObjectReference: gatewayv1.BackendObjectReference{Name: "example", Port: ptr.To(gatewayv1.PortNumber(3000))}}},
+							},
+						},
+					},
+				},
+			},
+			expectedError: field.ErrorList{},
+		},
+		{
+			name: "annotation present without HTTPS listener",
+			ingress: networkingv1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-ingress",
+					Namespace: "default",
+					Annotations: map[string]string{
+						"k8s.apisix.apache.org/http-to-https": "true",
+					},
+				},
+				Spec: networkingv1.IngressSpec{
+					Rules: []networkingv1.IngressRule{
+						{
+							Host: "example.com",
+						},
+					},
+				},
+			},
+			listeners: listeners[:1],
 			initialHTTPRoute: &gatewayv1.HTTPRoute{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-ingress-example-com",
 					Namespace: "default",
 				},
 				Spec: gatewayv1.HTTPRouteSpec{
+					CommonRouteSpec: gatewayv1.CommonRouteSpec{
+						ParentRefs: []gatewayv1.ParentReference{{Name: "apisix"}},
+					},
 					Hostnames: []gatewayv1.Hostname{"example.com"},
 					Rules: []gatewayv1.HTTPRouteRule{
 						{
@@ -137,9 +249,21 @@ func Test_httpToHttpsFeature(t *testing.T) {
 					Namespace: "default",
 				},
 				Spec: gatewayv1.HTTPRouteSpec{
+					CommonRouteSpec: gatewayv1.CommonRouteSpec{
+						ParentRefs: []gatewayv1.ParentReference{{Name: "apisix"}},
+					},
 					Hostnames: []gatewayv1.Hostname{"example.com"},
 					Rules: []gatewayv1.HTTPRouteRule{
 						{
+							Filters: []gatewayv1.HTTPRouteFilter{
+								{
+									Type: gatewayv1.HTTPRouteFilterRequestRedirect,
+									RequestRedirect: &gatewayv1.HTTPRequestRedirectFilter{
+										Scheme:     ptr.To("https"),
+										StatusCode: ptr.To(301),
+									},
+								},
+							},
 							BackendRefs: []gatewayv1.HTTPBackendRef{
 								{BackendRef: gatewayv1.BackendRef{BackendObjectReference: gatewayv1.BackendObjectReference{Name: "example", Port: ptr.To(gatewayv1.PortNumber(3000))}}},
 							},
@@ -167,12 +291,16 @@ func Test_httpToHttpsFeature(t *testing.T) {
 					},
 				},
 			},
+			listeners: listeners,
 			initialHTTPRoute: &gatewayv1.HTTPRoute{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-ingress-example-com",
 					Namespace: "default",
 				},
 				Spec: gatewayv1.HTTPRouteSpec{
+					CommonRouteSpec: gatewayv1.CommonRouteSpec{
+						ParentRefs: []gatewayv1.ParentReference{{Name: "apisix"}},
+					},
 					Hostnames: []gatewayv1.Hostname{"example.com"},
 					Rules: []gatewayv1.HTTPRouteRule{
 						{
@@ -189,6 +317,9 @@ func Test_httpToHttpsFeature(t *testing.T) {
 					Namespace: "default",
 				},
 				Spec: gatewayv1.HTTPRouteSpec{
+					CommonRouteSpec: gatewayv1.CommonRouteSpec{
+						ParentRefs: []gatewayv1.ParentReference{{Name: "apisix"}},
+					},
 					Hostnames: []gatewayv1.Hostname{"example.com"},
 					Rules: []gatewayv1.HTTPRouteRule{
 						{
@@ -219,12 +350,16 @@ func Test_httpToHttpsFeature(t *testing.T) {
 					},
 				},
 			},
+			listeners: listeners,
 			initialHTTPRoute: &gatewayv1.HTTPRoute{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-ingress-example-com",
 					Namespace: "default",
 				},
 				Spec: gatewayv1.HTTPRouteSpec{
+					CommonRouteSpec: gatewayv1.CommonRouteSpec{
+						ParentRefs: []gatewayv1.ParentReference{{Name: "apisix"}},
+					},
 					Hostnames: []gatewayv1.Hostname{"example.com"},
 					Rules: []gatewayv1.HTTPRouteRule{
 						{
@@ -241,6 +376,9 @@ func Test_httpToHttpsFeature(t *testing.T) {
 					Namespace: "default",
 				},
 				Spec: gatewayv1.HTTPRouteSpec{
+					CommonRouteSpec: gatewayv1.CommonRouteSpec{
+						ParentRefs: []gatewayv1.ParentReference{{Name: "apisix"}},
+					},
 					Hostnames: []gatewayv1.Hostname{"example.com"},
 					Rules: []gatewayv1.HTTPRouteRule{
 						{
@@ -259,14 +397,27 @@ func Test_httpToHttpsFeature(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			ingresses := []networkingv1.Ingress{tc.ingress}
 			ir := &intermediate.IR{
+				Gateways: map[types.NamespacedName]intermediate.GatewayContext{
+					{Name: "apisix", Namespace: "default"}: {
+						Gateway: gatewayv1.Gateway{
+							ObjectMeta: metav1.ObjectMeta{Name: "apisix", Namespace: "default"},
+							Spec:       gatewayv1.GatewaySpec{Listeners: tc.listeners},
+						},
+					},
+				},
 				HTTPRoutes: map[types.NamespacedName]intermediate.HTTPRouteContext{
 					{Name: tc.expectedHTTPRoute.Name, Namespace: tc.expectedHTTPRoute.Namespace}: {
 						HTTPRoute: *tc.initialHTTPRoute,
 					},
 				},
+				RuleGroupHTTPRoutes: map[intermediate.RuleGroupKey][]types.NamespacedName{
+					{Namespace: tc.ingress.Namespace, IngressClass: common.GetIngressClass(tc.ingress), Host: tc.ingress.Spec.Rules[0].Host}: {
+						{Name: tc.expectedHTTPRoute.Name, Namespace: tc.expectedHTTPRoute.Namespace},
+					},
+				},
 			}
 
-			errs := httpToHTTPSFeature(ingresses, ir)
+			errs := httpToHTTPSFeature(nil, ingresses, ir)
 
 			if len(errs) != len(tc.expectedError) {
 				t.Errorf("expected %d errors, got %d", len(tc.expectedError), len(errs))
@@ -282,6 +433,18 @@ func Test_httpToHttpsFeature(t *testing.T) {
 			if diff := cmp.Diff(*tc.expectedHTTPRoute, actualHTTPRouteContext.HTTPRoute); diff != "" {
 				t.Errorf("Unexpected HTTPRoute resource found, \n want: %+v\n got: %+v\n diff (-want +got):\n%s", *tc.expectedHTTPRoute, actualHTTPRouteContext.HTTPRoute, diff)
 			}
+
+			redirectKey := types.NamespacedName{Namespace: key.Namespace, Name: key.Name + "-https-redirect"}
+			redirectHTTPRouteContext, ok := ir.HTTPRoutes[redirectKey]
+			if tc.expectedRedirectHTTPRoute == nil {
+				if ok {
+					t.Errorf("Unexpected redirect HTTPRoute found: %v", redirectKey)
+				}
+				return
+			}
+			if diff := cmp.Diff(*tc.expectedRedirectHTTPRoute, redirectHTTPRouteContext.HTTPRoute); diff != "" {
+				t.Errorf("Unexpected redirect HTTPRoute resource found, diff (-want +got):\n%s", diff)
+			}
 		})
 	}
 }
@@ -17,11 +17,12 @@ limitations under the License.
 package apisix
 
 import (
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func notify(mType notifications.MessageType, message string, callingObject ...client.Object) {
+func notify(conf *i2gw.ProviderConf, mType notifications.MessageType, message string, callingObject ...client.Object) {
 	newNotification := notifications.NewNotification(mType, message, callingObject...)
-	notifications.NotificationAggr.DispatchNotification(newNotification, string(Name))
+	conf.Notify(Name, newNotification)
 }
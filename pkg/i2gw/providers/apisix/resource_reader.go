@@ -45,17 +45,29 @@ func (r *resourceReader) readResourcesFromCluster(ctx context.Context) (*storage
 		return nil, err
 	}
 	storage.Ingresses = ingresses
+
+	services, err := common.ReadServicesFromCluster(ctx, r.conf.Client, ingresses)
+	if err != nil {
+		return nil, err
+	}
+	storage.Services = services
 	return storage, nil
 }
 
-func (r *resourceReader) readResourcesFromFile(filename string) (*storage, error) {
+func (r *resourceReader) readResourcesFromFiles(filenames []string) (*storage, error) {
 	// read apisix related resources from file.
 	storage := newResourcesStorage()
 
-	ingresses, err := common.ReadIngressesFromFile(filename, r.conf.Namespace, sets.New[string](ApisixIngressClass))
+	ingresses, err := common.ReadIngressesFromFiles(r.conf, filenames, sets.New[string](ApisixIngressClass))
 	if err != nil {
 		return nil, err
 	}
 	storage.Ingresses = ingresses
+
+	services, err := common.ReadServicesFromFiles(r.conf, filenames)
+	if err != nil {
+		return nil, err
+	}
+	storage.Services = services
 	return storage, nil
 }
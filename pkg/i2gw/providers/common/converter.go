@@ -24,7 +24,9 @@ import (
 
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/intermediate"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/naming"
 	networkingv1 "k8s.io/api/networking/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
@@ -33,42 +35,71 @@ import (
 )
 
 // ToIR converts the received ingresses to intermediate.IR without taking into
-// consideration any provider specific logic.
+// consideration any provider specific logic. The Ingress paths and default
+// backends which can not be converted are skipped, and the IR of the others is
+// returned along with their errors, so that providers converting in
+// best-effort mode can keep it. See i2gw.ProviderConf.BestEffort.
 func ToIR(ingresses []networkingv1.Ingress, options i2gw.ProviderImplementationSpecificOptions) (intermediate.IR, field.ErrorList) {
-	aggregator := ingressAggregator{ruleGroups: map[ruleGroupKey]*ingressRuleGroup{}}
+	aggregator := ingressAggregator{ruleGroups: map[ruleGroupKey]*ingressRuleGroup{}, routeNameScopes: map[string]*naming.Scope{}}
 
 	var errs field.ErrorList
 	for _, ingress := range ingresses {
-		aggregator.addIngress(ingress)
-	}
-	if len(errs) > 0 {
-		return intermediate.IR{}, errs
+		errs = append(errs, aggregator.addIngress(ingress, options)...)
 	}
+	aggregator.resolveDefaultBackends()
+	aggregator.reserveHTTPSRedirectRouteNames()
 
-	routes, gateways, errs := aggregator.toHTTPRoutesAndGateways(options)
-	if len(errs) > 0 {
-		return intermediate.IR{}, errs
-	}
+	routes, ruleGroupRoutes, gateways, routeErrs := aggregator.toHTTPRoutesAndGateways(options)
+	errs = append(errs, routeErrs...)
 
 	routeByKey := make(map[types.NamespacedName]intermediate.HTTPRouteContext)
 	for _, route := range routes {
 		key := types.NamespacedName{Namespace: route.Namespace, Name: route.Name}
 		routeByKey[key] = intermediate.HTTPRouteContext{HTTPRoute: route}
 	}
+	for _, rg := range aggregator.ruleGroups {
+		if len(rg.tls) == 0 {
+			continue
+		}
+		key := types.NamespacedName{Namespace: rg.namespace, Name: rg.routeName()}
+		if routeContext, ok := routeByKey[key]; ok {
+			routeContext.HTTPSRedirectRoute = rg.httpsRedirectRouteName()
+			routeByKey[key] = routeContext
+		}
+	}
 
 	gatewayByKey := make(map[types.NamespacedName]intermediate.GatewayContext)
 	for _, gateway := range gateways {
 		key := types.NamespacedName{Namespace: gateway.Namespace, Name: gateway.Name}
-		gatewayByKey[key] = intermediate.GatewayContext{Gateway: gateway}
+		gatewayByKey[key] = intermediate.GatewayContext{
+			Gateway:        gateway,
+			DefaultBackend: aggregator.defaultBackendIR(fmt.Sprintf("%s/%s", gateway.Namespace, gateway.Name)),
+		}
 	}
 
 	return intermediate.IR{
-		Gateways:   gatewayByKey,
-		HTTPRoutes: routeByKey,
-	}, nil
+		Gateways:    gatewayByKey,
+		HTTPRoutes:  routeByKey,
+		Sources:     aggregator.sources(),
+		RuleSources: aggregator.ruleSources(),
+
+		RuleGroupHTTPRoutes: ruleGroupRoutes,
+	}, errs
 }
 
 var (
+	IngressGVK = schema.GroupVersionKind{
+		Group:   "networking.k8s.io",
+		Version: "v1",
+		Kind:    "Ingress",
+	}
+
+	ServiceGVK = schema.GroupVersionKind{
+		Group:   "",
+		Version: "v1",
+		Kind:    "Service",
+	}
+
 	GatewayGVK = schema.GroupVersionKind{
 		Group:   "gateway.networking.k8s.io",
 		Version: "v1",
@@ -105,6 +136,12 @@ type ruleGroupKey string
 type ingressAggregator struct {
 	ruleGroups      map[ruleGroupKey]*ingressRuleGroup
 	defaultBackends []ingressDefaultBackend
+	// gatewayDefaultBackends holds the default backend of every Gateway, by
+	// namespace/name, see resolveDefaultBackends.
+	gatewayDefaultBackends map[string]*gatewayDefaultBackend
+	// routeNameScopes holds the names of the HTTPRoutes of every namespace,
+	// so that the colliding names get a hash suffix.
+	routeNameScopes map[string]*naming.Scope
 }
 
 type pathMatchKey string
@@ -114,19 +151,45 @@ type ingressRuleGroup struct {
 	name         string
 	ingressClass string
 	host         string
-	tls          []networkingv1.IngressTLS
-	rules        []ingressRule
+	// tls holds the TLS configurations of the Ingresses covering the host,
+	// see tlsForHost.
+	tls   []networkingv1.IngressTLS
+	rules []ingressRule
+	// sources holds the names of the Ingresses contributing to the group.
+	sources []string
+	// routeNameScope holds the names of the HTTPRoutes of the namespace.
+	routeNameScope *naming.Scope
 }
 
 type ingressRule struct {
 	rule networkingv1.IngressRule
+	// source is the name of the Ingress the rule belongs to, and ruleIdx the
+	// index of the rule in it.
+	source  string
+	ruleIdx int
+	// invalidPaths holds the indexes of the paths which can not be
+	// converted, and are skipped.
+	invalidPaths map[int]bool
 }
 
 type ingressDefaultBackend struct {
-	name         string
+	name              string
+	namespace         string
+	ingressClass      string
+	creationTimestamp metav1.Time
+	backend           networkingv1.IngressBackend
+}
+
+// gatewayDefaultBackend is the default backend of the Ingresses of a Gateway,
+// the one of its oldest Ingress.
+type gatewayDefaultBackend struct {
 	namespace    string
 	ingressClass string
 	backend      networkingv1.IngressBackend
+	// sources holds the names of the Ingresses with the default backend.
+	sources []string
+	// routeNameScope holds the names of the HTTPRoutes of the namespace.
+	routeNameScope *naming.Scope
 }
 
 type ingressPath struct {
@@ -136,43 +199,361 @@ type ingressPath struct {
 	path     networkingv1.HTTPIngressPath
 }
 
-func (a *ingressAggregator) addIngress(ingress networkingv1.Ingress) {
+// addIngress adds the rules and the default backend of the ingress, and
+// returns the errors of the paths which can not be converted, which are
+// skipped. A default backend without a BackendRef is dropped, see
+// ToBackendRef.
+func (a *ingressAggregator) addIngress(ingress networkingv1.Ingress, options i2gw.ProviderImplementationSpecificOptions) field.ErrorList {
+	var errs field.ErrorList
 	ingressClass := GetIngressClass(ingress)
-	for _, rule := range ingress.Spec.Rules {
-		a.addIngressRule(ingress.Namespace, ingress.Name, ingressClass, rule, ingress.Spec)
+	ingressPath := field.NewPath(fmt.Sprintf("%s/%s", ingress.Namespace, ingress.Name)).Child("spec")
+	for i, rule := range ingress.Spec.Rules {
+		invalidPaths := make(map[int]bool)
+		if rule.HTTP != nil {
+			for j, path := range rule.HTTP.Paths {
+				pathErrs := validateIngressPath(path, ingressPath.Child("rules").Index(i).Child("http", "paths").Index(j), options)
+				if len(pathErrs) > 0 {
+					invalidPaths[j] = true
+					errs = append(errs, pathErrs...)
+				}
+			}
+		}
+		a.addIngressRule(ingress.Namespace, ingress.Name, ingressClass, i, rule, ingress.Spec, invalidPaths)
 	}
-	if ingress.Spec.DefaultBackend != nil {
+	if ingress.Spec.DefaultBackend != nil && ToBackendRef(*ingress.Spec.DefaultBackend) != nil {
 		a.defaultBackends = append(a.defaultBackends, ingressDefaultBackend{
-			name:         ingress.Name,
-			namespace:    ingress.Namespace,
-			ingressClass: ingressClass,
-			backend:      *ingress.Spec.DefaultBackend,
+			name:              ingress.Name,
+			namespace:         ingress.Namespace,
+			ingressClass:      ingressClass,
+			creationTimestamp: ingress.CreationTimestamp,
+			backend:           *ingress.Spec.DefaultBackend,
 		})
 	}
+	return errs
 }
 
-func (a *ingressAggregator) addIngressRule(namespace, name, ingressClass string, rule networkingv1.IngressRule, iSpec networkingv1.IngressSpec) {
+func (a *ingressAggregator) addIngressRule(namespace, name, ingressClass string, ruleIdx int, rule networkingv1.IngressRule, iSpec networkingv1.IngressSpec, invalidPaths map[int]bool) {
 	rgKey := ruleGroupKey(fmt.Sprintf("%s/%s/%s", namespace, ingressClass, rule.Host))
 	rg, ok := a.ruleGroups[rgKey]
 	if !ok {
 		rg = &ingressRuleGroup{
-			namespace:    namespace,
-			name:         name,
-			ingressClass: ingressClass,
-			host:         rule.Host,
+			namespace:      namespace,
+			name:           name,
+			ingressClass:   ingressClass,
+			host:           rule.Host,
+			routeNameScope: a.routeNameScope(namespace),
 		}
+		rg.routeNameScope.Add(RouteName(name, rule.Host), routeNameInput(name, rule.Host))
 		a.ruleGroups[rgKey] = rg
 	}
-	if len(iSpec.TLS) > 0 {
-		rg.tls = append(rg.tls, iSpec.TLS...)
+	rg.tls = append(rg.tls, tlsForHost(iSpec.TLS, rule.Host)...)
+	rg.rules = append(rg.rules, ingressRule{rule: rule, source: name, ruleIdx: ruleIdx, invalidPaths: invalidPaths})
+	if !slices.Contains(rg.sources, name) {
+		rg.sources = append(rg.sources, name)
+	}
+}
+
+// routeNameScope returns the scope of the names of the HTTPRoutes of
+// namespace.
+func (a *ingressAggregator) routeNameScope(namespace string) *naming.Scope {
+	scope, ok := a.routeNameScopes[namespace]
+	if !ok {
+		scope = naming.NewScope()
+		a.routeNameScopes[namespace] = scope
+	}
+	return scope
+}
+
+// resolveDefaultBackends sets the default backend of every Gateway to the one
+// of its oldest Ingress, see compareIngressAge. The different default backends
+// of the other Ingresses of the Gateway are skipped, and reported by
+// NotifyDefaultBackendConflicts.
+func (a *ingressAggregator) resolveDefaultBackends() {
+	defaultBackends := slices.Clone(a.defaultBackends)
+	slices.SortStableFunc(defaultBackends, func(a, b ingressDefaultBackend) int {
+		return compareIngressAge(a.creationTimestamp, a.name, b.creationTimestamp, b.name)
+	})
+	a.gatewayDefaultBackends = make(map[string]*gatewayDefaultBackend)
+	for _, db := range defaultBackends {
+		gwKey := fmt.Sprintf("%s/%s", db.namespace, db.ingressClass)
+		gdb, ok := a.gatewayDefaultBackends[gwKey]
+		if !ok {
+			gdb = &gatewayDefaultBackend{
+				namespace:      db.namespace,
+				ingressClass:   db.ingressClass,
+				backend:        db.backend,
+				routeNameScope: a.routeNameScope(db.namespace),
+			}
+			a.gatewayDefaultBackends[gwKey] = gdb
+		}
+		if apiequality.Semantic.DeepEqual(gdb.backend, db.backend) {
+			gdb.sources = append(gdb.sources, db.name)
+		}
+	}
+	// The names of the routes depend on their sources, which are all known
+	// only now.
+	for _, gdb := range a.gatewayDefaultBackends {
+		gdb.routeNameScope.Add(gdb.unscopedRouteName(), defaultBackendRouteNameInput(gdb.ingressClass))
+	}
+}
+
+// routeName returns the name of the HTTPRoute of the default backend.
+func (gdb *gatewayDefaultBackend) routeName() string {
+	return gdb.routeNameScope.Name(gdb.unscopedRouteName(), defaultBackendRouteNameInput(gdb.ingressClass))
+}
+
+// unscopedRouteName returns the name of the HTTPRoute of the default backend,
+// before collisions are checked. It is named after its Ingress if it has a
+// single one, and after the Gateway if several Ingresses share it.
+func (gdb *gatewayDefaultBackend) unscopedRouteName() string {
+	if len(gdb.sources) == 1 {
+		return naming.Name(gdb.sources[0], "default-backend")
+	}
+	return naming.Name(gdb.ingressClass, "default-backend")
+}
+
+// defaultBackendRouteNameInput returns the input the name of the HTTPRoute of
+// the default backend of the Gateway named gatewayName is told apart by when
+// it collides, which differs from the ones of routeNameInput.
+func defaultBackendRouteNameInput(gatewayName string) string {
+	return "defaultBackend/" + gatewayName
+}
+
+// reserveHTTPSRedirectRouteNames adds the names of the HTTPRoutes which may
+// redirect the HTTP requests of the groups with TLS to HTTPS, see
+// RedirectToHTTPS, to the scopes of the names of the routes, so that they do
+// not collide with the names of the other routes.
+func (a *ingressAggregator) reserveHTTPSRedirectRouteNames() {
+	for _, rg := range a.ruleGroups {
+		if len(rg.tls) > 0 {
+			rg.routeNameScope.Add(naming.Name(RouteName(rg.name, rg.host), "https-redirect"), httpsRedirectRouteNameInput(rg.name, rg.host))
+		}
+	}
+}
+
+// httpsRedirectRouteName returns the name of the HTTPRoute which may redirect
+// the HTTP requests of the group to HTTPS.
+func (rg *ingressRuleGroup) httpsRedirectRouteName() string {
+	return rg.routeNameScope.Name(naming.Name(RouteName(rg.name, rg.host), "https-redirect"), httpsRedirectRouteNameInput(rg.name, rg.host))
+}
+
+// httpsRedirectRouteNameInput returns the input the name of the HTTPRoute
+// redirecting the routes of the host of the Ingress named ingressName to HTTPS
+// is told apart by when it collides, which differs from the ones of
+// routeNameInput and defaultBackendRouteNameInput.
+func httpsRedirectRouteNameInput(ingressName, host string) string {
+	return "httpsRedirect/" + ingressName + "/" + host
+}
+
+// defaultBackendIR returns the default backend of the Gateway of the given
+// namespace/name, if any.
+func (a *ingressAggregator) defaultBackendIR(gwKey string) *intermediate.DefaultBackendIR {
+	gdb, ok := a.gatewayDefaultBackends[gwKey]
+	if !ok {
+		return nil
+	}
+	backendRef := ToBackendRef(gdb.backend)
+	defaultBackend := &intermediate.DefaultBackendIR{BackendRef: *backendRef}
+	if !a.matchesAllPaths(gwKey) {
+		defaultBackend.HTTPRoute = gdb.routeName()
+	}
+	for _, name := range gdb.sources {
+		defaultBackend.Sources = append(defaultBackend.Sources, intermediate.ObjectRef{Kind: "Ingress", Namespace: gdb.namespace, Name: name})
+	}
+	return defaultBackend
+}
+
+// matchesAllPaths returns whether a rule of the listener without hostname of
+// the Gateway of the given namespace/name matches all the paths, i.e. has the
+// Prefix path /, which takes precedence over the default backend.
+func (a *ingressAggregator) matchesAllPaths(gwKey string) bool {
+	for _, rg := range a.ruleGroups {
+		if fmt.Sprintf("%s/%s", rg.namespace, rg.ingressClass) != gwKey || rg.host != "" || rg.skipped() {
+			continue
+		}
+		if !slices.ContainsFunc(rg.listeners(a.gatewayHosts(gwKey)), func(listener gatewayv1.Listener) bool { return listener.Hostname == nil }) {
+			continue
+		}
+		for _, rule := range rg.rules {
+			if rule.rule.HTTP == nil {
+				continue
+			}
+			for j, path := range rule.rule.HTTP.Paths {
+				if !rule.invalidPaths[j] && path.Path == "/" && path.PathType != nil && *path.PathType == networkingv1.PathTypePrefix {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// backendName returns the name of the Service or resource of backend.
+func backendName(backend networkingv1.IngressBackend) string {
+	if backend.Service != nil {
+		return backend.Service.Name
+	}
+	return backend.Resource.Name
+}
+
+// listeners returns the listeners serving the rule group, with the hostnames
+// and TLS configurations of the listeners of the Gateway. The group of a host
+// gets a single listener of its host. The group without host gets a listener
+// for every host of its TLS configurations, with the certificates of that
+// host, except for the hosts in gatewayHosts, which have their own group; and
+// a listener without hostname for its TLS configurations without hosts, or
+// for no TLS configuration if it got no other listener.
+func (rg *ingressRuleGroup) listeners(gatewayHosts map[string]bool) []gatewayv1.Listener {
+	if rg.host != "" {
+		return []gatewayv1.Listener{newListener(rg.host, rg.tls)}
+	}
+	var listeners []gatewayv1.Listener
+	var hostlessTLS []networkingv1.IngressTLS
+	var tlsHosts []string
+	for _, tls := range rg.tls {
+		if len(tls.Hosts) == 0 {
+			hostlessTLS = append(hostlessTLS, tls)
+		}
+		for _, host := range tls.Hosts {
+			if host != "" && !gatewayHosts[host] && !slices.Contains(tlsHosts, host) {
+				tlsHosts = append(tlsHosts, host)
+			}
+		}
+	}
+	for _, host := range tlsHosts {
+		listeners = append(listeners, newListener(host, tlsForHost(rg.tls, host)))
+	}
+	if len(hostlessTLS) > 0 || len(listeners) == 0 {
+		listeners = append(listeners, newListener("", hostlessTLS))
+	}
+	return listeners
+}
+
+// newListener returns the listener of hostname, which is without hostname if
+// empty, with the certificates of tlsConfigs, if any.
+func newListener(hostname string, tlsConfigs []networkingv1.IngressTLS) gatewayv1.Listener {
+	listener := gatewayv1.Listener{}
+	if hostname != "" {
+		listener.Hostname = (*gatewayv1.Hostname)(&hostname)
+	}
+	if len(tlsConfigs) > 0 {
+		listener.TLS = &gatewayv1.GatewayTLSConfig{}
+	}
+	for _, tls := range tlsConfigs {
+		certificateRef := gatewayv1.SecretObjectReference{Name: gatewayv1.ObjectName(tls.SecretName)}
+		if !slices.Contains(listener.TLS.CertificateRefs, certificateRef) {
+			listener.TLS.CertificateRefs = append(listener.TLS.CertificateRefs, certificateRef)
+		}
+	}
+	return listener
+}
+
+// gatewayHosts returns the hosts of the rule groups of the Gateway of the
+// given namespace/name which are not skipped.
+func (a *ingressAggregator) gatewayHosts(gwKey string) map[string]bool {
+	hosts := map[string]bool{}
+	for _, rg := range a.ruleGroups {
+		if fmt.Sprintf("%s/%s", rg.namespace, rg.ingressClass) == gwKey && rg.host != "" && !rg.skipped() {
+			hosts[rg.host] = true
+		}
+	}
+	return hosts
+}
+
+// skipped returns whether all the paths of the rule group were skipped, in
+// which case neither a route nor a listener is generated for it.
+func (rg *ingressRuleGroup) skipped() bool {
+	var invalid bool
+	for _, rule := range rg.rules {
+		if rule.rule.HTTP == nil {
+			continue
+		}
+		if len(rule.invalidPaths) < len(rule.rule.HTTP.Paths) {
+			return false
+		}
+		invalid = invalid || len(rule.invalidPaths) > 0
+	}
+	return invalid
+}
+
+// sources returns the Ingresses each generated HTTPRoute and Gateway was
+// built from.
+func (a *ingressAggregator) sources() map[intermediate.ObjectRef][]intermediate.ObjectRef {
+	sources := make(map[intermediate.ObjectRef][]intermediate.ObjectRef)
+	for _, rg := range a.ruleGroups {
+		if rg.skipped() {
+			continue
+		}
+		gatewayRef := intermediate.ObjectRef{Kind: GatewayGVK.Kind, Namespace: rg.namespace, Name: rg.ingressClass}
+		for _, name := range rg.sources {
+			intermediate.AddSource(sources, gatewayRef, intermediate.ObjectRef{Kind: "Ingress", Namespace: rg.namespace, Name: name})
+		}
+		// Every route is built from the Ingresses of its rules, and a route
+		// without rules from all the Ingresses of the group.
+		ingressPathsByMatchKey := groupIngressPathsByMatchKey(rg.rules)
+		routeNames := rg.routeNames(len(ingressPathsByMatchKey.keys))
+		if len(ingressPathsByMatchKey.keys) == 0 {
+			routeRef := intermediate.ObjectRef{Kind: HTTPRouteGVK.Kind, Namespace: rg.namespace, Name: routeNames[0]}
+			for _, name := range rg.sources {
+				intermediate.AddSource(sources, routeRef, intermediate.ObjectRef{Kind: "Ingress", Namespace: rg.namespace, Name: name})
+			}
+		}
+		for i, key := range ingressPathsByMatchKey.keys {
+			routeRef := intermediate.ObjectRef{Kind: HTTPRouteGVK.Kind, Namespace: rg.namespace, Name: routeNames[i/MaxHTTPRouteRules]}
+			for _, path := range ingressPathsByMatchKey.data[key] {
+				intermediate.AddSource(sources, routeRef, intermediate.ObjectRef{Kind: "Ingress", Namespace: rg.namespace, Name: rg.rules[path.ruleIdx].source})
+			}
+		}
+	}
+	for gwKey, gdb := range a.gatewayDefaultBackends {
+		defaultBackend := a.defaultBackendIR(gwKey)
+		gatewayRef := intermediate.ObjectRef{Kind: GatewayGVK.Kind, Namespace: gdb.namespace, Name: gdb.ingressClass}
+		routeRef := intermediate.ObjectRef{Kind: HTTPRouteGVK.Kind, Namespace: gdb.namespace, Name: defaultBackend.HTTPRoute}
+		for _, source := range defaultBackend.Sources {
+			intermediate.AddSource(sources, gatewayRef, source)
+			if defaultBackend.HTTPRoute != "" {
+				intermediate.AddSource(sources, routeRef, source)
+			}
+		}
+	}
+	return sources
+}
+
+// ruleSources returns the Ingress paths each rule of the generated HTTPRoutes
+// was built from. The rules follow the order of toHTTPRoutes, one per path
+// match, split across the routes of the group by MaxHTTPRouteRules. Rules
+// built from default backends have no rule sources.
+func (a *ingressAggregator) ruleSources() map[intermediate.ObjectRef]map[int][]intermediate.RuleSource {
+	ruleSources := make(map[intermediate.ObjectRef]map[int][]intermediate.RuleSource)
+	for _, rg := range a.ruleGroups {
+		ingressPathsByMatchKey := groupIngressPathsByMatchKey(rg.rules)
+		routeNames := rg.routeNames(len(ingressPathsByMatchKey.keys))
+		for i, key := range ingressPathsByMatchKey.keys {
+			routeRef := intermediate.ObjectRef{Kind: HTTPRouteGVK.Kind, Namespace: rg.namespace, Name: routeNames[i/MaxHTTPRouteRules]}
+			for _, path := range ingressPathsByMatchKey.data[key] {
+				rule := rg.rules[path.ruleIdx]
+				intermediate.AddRuleSource(ruleSources, routeRef, i%MaxHTTPRouteRules, intermediate.RuleSource{
+					Source:    intermediate.ObjectRef{Kind: "Ingress", Namespace: rg.namespace, Name: rule.source},
+					RuleIndex: rule.ruleIdx,
+					PathIndex: path.pathIdx,
+				})
+			}
+		}
 	}
-	rg.rules = append(rg.rules, ingressRule{rule: rule})
+	return ruleSources
 }
 
-func (a *ingressAggregator) toHTTPRoutesAndGateways(options i2gw.ProviderImplementationSpecificOptions) ([]gatewayv1.HTTPRoute, []gatewayv1.Gateway, field.ErrorList) {
+// toHTTPRoutesAndGateways returns the HTTPRoutes of the rule groups, the keys
+// of the HTTPRoutes of every rule group, and the Gateways they are attached
+// to.
+func (a *ingressAggregator) toHTTPRoutesAndGateways(options i2gw.ProviderImplementationSpecificOptions) ([]gatewayv1.HTTPRoute, map[intermediate.RuleGroupKey][]types.NamespacedName, []gatewayv1.Gateway, field.ErrorList) {
 	var httpRoutes []gatewayv1.HTTPRoute
+	ruleGroupRoutes := make(map[intermediate.RuleGroupKey][]types.NamespacedName)
 	var errors field.ErrorList
 	listenersByNamespacedGateway := map[string][]gatewayv1.Listener{}
+	// listenerHostsByNamespacedGateway holds the hostname of every listener,
+	// which tells apart the listeners with colliding names.
+	listenerHostsByNamespacedGateway := map[string][]string{}
 
 	// Sort the rulegroups to iterate the map in a sorted order.
 	ruleGroupsKeys := make([]ruleGroupKey, 0, len(a.ruleGroups))
@@ -186,61 +567,44 @@ func (a *ingressAggregator) toHTTPRoutesAndGateways(options i2gw.ProviderImpleme
 
 	for _, rgk := range ruleGroupsKeys {
 		rg := a.ruleGroups[rgk]
-		listener := gatewayv1.Listener{}
-		if rg.host != "" {
-			listener.Hostname = (*gatewayv1.Hostname)(&rg.host)
-		} else if len(rg.tls) == 1 && len(rg.tls[0].Hosts) == 1 {
-			listener.Hostname = (*gatewayv1.Hostname)(&rg.tls[0].Hosts[0])
-		}
-		if len(rg.tls) > 0 {
-			listener.TLS = &gatewayv1.GatewayTLSConfig{}
-		}
-		for _, tls := range rg.tls {
-			listener.TLS.CertificateRefs = append(listener.TLS.CertificateRefs,
-				gatewayv1.SecretObjectReference{Name: gatewayv1.ObjectName(tls.SecretName)})
+		if rg.skipped() {
+			continue
 		}
 		gwKey := fmt.Sprintf("%s/%s", rg.namespace, rg.ingressClass)
-		listenersByNamespacedGateway[gwKey] = append(listenersByNamespacedGateway[gwKey], listener)
-		httpRoute, errs := rg.toHTTPRoute(options)
-		httpRoutes = append(httpRoutes, httpRoute)
+		for _, listener := range rg.listeners(a.gatewayHosts(gwKey)) {
+			listenersByNamespacedGateway[gwKey] = append(listenersByNamespacedGateway[gwKey], listener)
+			listenerHostsByNamespacedGateway[gwKey] = append(listenerHostsByNamespacedGateway[gwKey], listenerHostname(listener))
+		}
+		routes, errs := rg.toHTTPRoutes(options)
+		httpRoutes = append(httpRoutes, routes...)
 		errors = append(errors, errs...)
-	}
-
-	for i, db := range a.defaultBackends {
-		httpRoute := gatewayv1.HTTPRoute{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      fmt.Sprintf("%s-default-backend", db.name),
-				Namespace: db.namespace,
-			},
-			Spec: gatewayv1.HTTPRouteSpec{
-				CommonRouteSpec: gatewayv1.CommonRouteSpec{
-					ParentRefs: []gatewayv1.ParentReference{{
-						Name: gatewayv1.ObjectName(db.ingressClass),
-					}},
-				},
-			},
-			Status: gatewayv1.HTTPRouteStatus{
-				RouteStatus: gatewayv1.RouteStatus{
-					Parents: []gatewayv1.RouteParentStatus{},
-				},
-			},
+		rgKey := intermediate.RuleGroupKey{Namespace: rg.namespace, IngressClass: rg.ingressClass, Host: rg.host}
+		for _, route := range routes {
+			ruleGroupRoutes[rgKey] = append(ruleGroupRoutes[rgKey], types.NamespacedName{Namespace: route.Namespace, Name: route.Name})
 		}
-		httpRoute.SetGroupVersionKind(HTTPRouteGVK)
+	}
 
-		backendRef, err := toBackendRef(db.backend, field.NewPath(db.name, "paths", "backends").Index(i))
-		if err != nil {
-			errors = append(errors, err)
-		} else {
-			httpRoute.Spec.Rules = append(httpRoute.Spec.Rules, gatewayv1.HTTPRouteRule{
-				BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: *backendRef}},
-			})
+	// The default backends are served on all the listeners, and on the
+	// listeners without hostname for the other hosts, which are added to
+	// the Gateways without one.
+	for gwKey := range a.gatewayDefaultBackends {
+		if !slices.ContainsFunc(listenersByNamespacedGateway[gwKey], func(listener gatewayv1.Listener) bool { return listener.Hostname == nil }) {
+			listenersByNamespacedGateway[gwKey] = append(listenersByNamespacedGateway[gwKey], gatewayv1.Listener{})
+			listenerHostsByNamespacedGateway[gwKey] = append(listenerHostsByNamespacedGateway[gwKey], "")
 		}
+	}
 
-		httpRoutes = append(httpRoutes, httpRoute)
+	// Sort the gateway keys, so that the gateways are returned in a sorted
+	// order.
+	gwKeys := make([]string, 0, len(listenersByNamespacedGateway))
+	for gwKey := range listenersByNamespacedGateway {
+		gwKeys = append(gwKeys, gwKey)
 	}
+	slices.Sort(gwKeys)
 
 	gatewaysByKey := map[string]*gatewayv1.Gateway{}
-	for gwKey, listeners := range listenersByNamespacedGateway {
+	for _, gwKey := range gwKeys {
+		listeners := listenersByNamespacedGateway[gwKey]
 		parts := strings.Split(gwKey, "/")
 		if len(parts) != 2 {
 			errors = append(errors, field.Invalid(field.NewPath(""), "", fmt.Sprintf("error generating Gateway listeners for key: %s", gwKey)))
@@ -260,21 +624,22 @@ func (a *ingressAggregator) toHTTPRoutesAndGateways(options i2gw.ProviderImpleme
 			gateway.SetGroupVersionKind(GatewayGVK)
 			gatewaysByKey[gwKey] = gateway
 		}
-		for _, listener := range listeners {
-			var listenerNamePrefix string
-			if listener.Hostname != nil && *listener.Hostname != "" {
-				listenerNamePrefix = fmt.Sprintf("%s-", NameFromHost(string(*listener.Hostname)))
-			}
+		listenerNameScope := naming.NewScope()
+		for i, listener := range listeners {
+			listenerNameScope.Add(listenerNamePrefix(listener), listenerHostsByNamespacedGateway[gwKey][i])
+		}
+		for i, listener := range listeners {
+			listenerNamePrefix := listenerNameScope.Name(listenerNamePrefix(listener), listenerHostsByNamespacedGateway[gwKey][i])
 
 			gateway.Spec.Listeners = append(gateway.Spec.Listeners, gatewayv1.Listener{
-				Name:     gatewayv1.SectionName(fmt.Sprintf("%shttp", listenerNamePrefix)),
+				Name:     gatewayv1.SectionName(naming.Name(listenerNamePrefix, "http")),
 				Hostname: listener.Hostname,
 				Port:     80,
 				Protocol: gatewayv1.HTTPProtocolType,
 			})
 			if listener.TLS != nil {
 				gateway.Spec.Listeners = append(gateway.Spec.Listeners, gatewayv1.Listener{
-					Name:     gatewayv1.SectionName(fmt.Sprintf("%shttps", listenerNamePrefix)),
+					Name:     gatewayv1.SectionName(naming.Name(listenerNamePrefix, "https")),
 					Hostname: listener.Hostname,
 					Port:     443,
 					Protocol: gatewayv1.HTTPSProtocolType,
@@ -285,36 +650,60 @@ func (a *ingressAggregator) toHTTPRoutesAndGateways(options i2gw.ProviderImpleme
 	}
 
 	var gateways []gatewayv1.Gateway
-	for _, gw := range gatewaysByKey {
-		gateways = append(gateways, *gw)
+	for _, gwKey := range gwKeys {
+		if gw, ok := gatewaysByKey[gwKey]; ok {
+			gateways = append(gateways, *gw)
+		}
 	}
 
-	return httpRoutes, gateways, errors
+	return httpRoutes, ruleGroupRoutes, gateways, errors
 }
 
-func (rg *ingressRuleGroup) toHTTPRoute(options i2gw.ProviderImplementationSpecificOptions) (gatewayv1.HTTPRoute, field.ErrorList) {
-	ingressPathsByMatchKey := groupIngressPathsByMatchKey(rg.rules)
-	httpRoute := gatewayv1.HTTPRoute{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      RouteName(rg.name, rg.host),
-			Namespace: rg.namespace,
-		},
-		Spec: gatewayv1.HTTPRouteSpec{},
-		Status: gatewayv1.HTTPRouteStatus{
-			RouteStatus: gatewayv1.RouteStatus{
-				Parents: []gatewayv1.RouteParentStatus{},
-			},
-		},
+// routeName returns the name of the HTTPRoute of the group, the first one of
+// routeNames.
+func (rg *ingressRuleGroup) routeName() string {
+	return rg.routeNameScope.Name(RouteName(rg.name, rg.host), routeNameInput(rg.name, rg.host))
+}
+
+// routeNames returns the names of the HTTPRoutes the given number of rules of
+// the group are split into, by MaxHTTPRouteRules. A group without rules has a
+// single route.
+func (rg *ingressRuleGroup) routeNames(rules int) []string {
+	name := rg.routeName()
+	parts := max(1, (rules+MaxHTTPRouteRules-1)/MaxHTTPRouteRules)
+	names := make([]string, parts)
+	for part := range names {
+		names[part] = routePartName(name, part, parts)
 	}
-	httpRoute.SetGroupVersionKind(HTTPRouteGVK)
+	return names
+}
 
-	if rg.ingressClass != "" {
-		httpRoute.Spec.ParentRefs = []gatewayv1.ParentReference{{Name: gatewayv1.ObjectName(rg.ingressClass)}}
+// listenerHostname returns the hostname of listener, which is empty for the
+// listeners without hostname.
+func listenerHostname(listener gatewayv1.Listener) string {
+	if listener.Hostname == nil {
+		return ""
 	}
-	if rg.host != "" {
-		httpRoute.Spec.Hostnames = []gatewayv1.Hostname{gatewayv1.Hostname(rg.host)}
+	return string(*listener.Hostname)
+}
+
+// listenerNamePrefix returns the prefix of the names of the listeners of the
+// hostname of listener, which is empty for the listeners without hostname.
+func listenerNamePrefix(listener gatewayv1.Listener) string {
+	if listener.Hostname == nil || *listener.Hostname == "" {
+		return ""
 	}
+	return NameFromHost(string(*listener.Hostname))
+}
+
+// toHTTPRoutes converts the group to HTTPRoutes with a rule per path match,
+// split by MaxHTTPRouteRules. The rules keep their order across the routes,
+// which sort by name in the same order, so that their precedence is
+// preserved.
+func (rg *ingressRuleGroup) toHTTPRoutes(options i2gw.ProviderImplementationSpecificOptions) ([]gatewayv1.HTTPRoute, field.ErrorList) {
+	ingressPathsByMatchKey := groupIngressPathsByMatchKey(rg.rules)
 
+	var rules []gatewayv1.HTTPRouteRule
 	var errors field.ErrorList
 	for _, key := range ingressPathsByMatchKey.keys {
 		paths := ingressPathsByMatchKey.data[key]
@@ -329,30 +718,57 @@ func (rg *ingressRuleGroup) toHTTPRoute(options i2gw.ProviderImplementationSpeci
 			Matches: []gatewayv1.HTTPRouteMatch{*match},
 		}
 
-		backendRefs, errs := rg.configureBackendRef(paths)
-		errors = append(errors, errs...)
-		hrRule.BackendRefs = backendRefs
+		hrRule.BackendRefs = rg.configureBackendRef(paths)
 
-		httpRoute.Spec.Rules = append(httpRoute.Spec.Rules, hrRule)
+		rules = append(rules, hrRule)
 	}
 
-	return httpRoute, errors
+	routeNames := rg.routeNames(len(rules))
+	httpRoutes := make([]gatewayv1.HTTPRoute, 0, len(routeNames))
+	for part, name := range routeNames {
+		httpRoute := gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: rg.namespace,
+			},
+			Spec: gatewayv1.HTTPRouteSpec{},
+			Status: gatewayv1.HTTPRouteStatus{
+				RouteStatus: gatewayv1.RouteStatus{
+					Parents: []gatewayv1.RouteParentStatus{},
+				},
+			},
+		}
+		httpRoute.SetGroupVersionKind(HTTPRouteGVK)
+
+		if rg.ingressClass != "" {
+			httpRoute.Spec.ParentRefs = []gatewayv1.ParentReference{{Name: gatewayv1.ObjectName(rg.ingressClass)}}
+		}
+		if rg.host != "" {
+			httpRoute.Spec.Hostnames = []gatewayv1.Hostname{gatewayv1.Hostname(rg.host)}
+		}
+		if start := part * MaxHTTPRouteRules; start < len(rules) {
+			// The rules are clipped, so that the parsers appending rules to
+			// a route do not overwrite the ones of the next.
+			httpRoute.Spec.Rules = slices.Clip(rules[start:min(start+MaxHTTPRouteRules, len(rules))])
+		}
+		httpRoutes = append(httpRoutes, httpRoute)
+	}
+
+	return httpRoutes, errors
 }
 
-func (rg *ingressRuleGroup) configureBackendRef(paths []ingressPath) ([]gatewayv1.HTTPBackendRef, field.ErrorList) {
-	var errors field.ErrorList
+func (rg *ingressRuleGroup) configureBackendRef(paths []ingressPath) []gatewayv1.HTTPBackendRef {
 	var backendRefs []gatewayv1.HTTPBackendRef
 
-	for i, path := range paths {
-		backendRef, err := toBackendRef(path.path.Backend, field.NewPath("paths", "backends").Index(i))
-		if err != nil {
-			errors = append(errors, err)
+	for _, path := range paths {
+		backendRef := ToBackendRef(path.path.Backend)
+		if backendRef == nil {
 			continue
 		}
 		backendRefs = append(backendRefs, gatewayv1.HTTPBackendRef{BackendRef: *backendRef})
 	}
 
-	return removeBackendRefsDuplicates(backendRefs), errors
+	return removeBackendRefsDuplicates(backendRefs)
 }
 
 func getPathMatchKey(ip ingressPath) pathMatchKey {
@@ -369,8 +785,8 @@ func toHTTPRouteMatch(routePath networkingv1.HTTPIngressPath, path *field.Path,
 
 	match := &gatewayv1.HTTPRouteMatch{Path: &gatewayv1.HTTPPathMatch{Value: &routePath.Path}}
 
-	if routePath.PathType == nil {
-		return nil, field.Invalid(path.Child("pathType"), routePath.PathType, "pathType is required")
+	if err := validatePathType(routePath, path, toImplementationSpecificPathMatch); err != nil {
+		return nil, err
 	}
 
 	switch *routePath.PathType {
@@ -379,42 +795,43 @@ func toHTTPRouteMatch(routePath networkingv1.HTTPIngressPath, path *field.Path,
 	case networkingv1.PathTypeExact:
 		match.Path.Type = &pmExact
 	// In case the path type is ImplementationSpecific, the path value and type
-	// will be set by the provider-specific customization function. If such function
-	// is not given by the provider, an error is returned.
+	// will be set by the provider-specific customization function, which
+	// validatePathType checks is given by the provider.
 	case networkingv1.PathTypeImplementationSpecific:
-		if toImplementationSpecificPathMatch != nil {
-			toImplementationSpecificPathMatch(match.Path)
-		} else {
-			return nil, field.Invalid(path.Child("pathType"), routePath.PathType, "implementationSpecific path type is not supported in generic translation, and your provider does not provide custom support to translate it")
-		}
-	default:
-		// default should never hit, as all the possible cases are already checked
-		// via proper switch cases.
-		return nil, field.Invalid(path.Child("pathType"), match.Path.Type, fmt.Sprintf("unsupported path match type: %s", *match.Path.Type))
-
+		toImplementationSpecificPathMatch(match.Path)
 	}
 
 	return match, nil
 }
 
-func toBackendRef(ib networkingv1.IngressBackend, path *field.Path) (*gatewayv1.BackendRef, *field.Error) {
-	if ib.Service != nil {
-		if ib.Service.Port.Name != "" {
-			fieldPath := path.Child("service", "port")
-			return nil, field.Invalid(fieldPath, "name", fmt.Sprintf("named ports not supported: %s", ib.Service.Port.Name))
+// validateIngressPath returns the errors of the Ingress path at fieldPath
+// which make it impossible to convert.
+func validateIngressPath(routePath networkingv1.HTTPIngressPath, fieldPath *field.Path, options i2gw.ProviderImplementationSpecificOptions) field.ErrorList {
+	var errs field.ErrorList
+	if err := validatePathType(routePath, fieldPath, options.ToImplementationSpecificHTTPPathTypeMatch); err != nil {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// validatePathType returns an error if the path has no path type, or an
+// ImplementationSpecific one which the provider does not convert.
+func validatePathType(routePath networkingv1.HTTPIngressPath, path *field.Path, toImplementationSpecificPathMatch i2gw.ImplementationSpecificHTTPPathTypeMatchConverter) *field.Error {
+	if routePath.PathType == nil {
+		return field.Invalid(path.Child("pathType"), routePath.PathType, "pathType is required")
+	}
+	switch *routePath.PathType {
+	case networkingv1.PathTypePrefix, networkingv1.PathTypeExact:
+		return nil
+	// In case the path type is ImplementationSpecific, the path value and type
+	// are set by the provider-specific customization function. If such
+	// function is not given by the provider, the path can not be converted.
+	case networkingv1.PathTypeImplementationSpecific:
+		if toImplementationSpecificPathMatch == nil {
+			return field.Invalid(path.Child("pathType"), routePath.PathType, "implementationSpecific path type is not supported in generic translation, and your provider does not provide custom support to translate it")
 		}
-		return &gatewayv1.BackendRef{
-			BackendObjectReference: gatewayv1.BackendObjectReference{
-				Name: gatewayv1.ObjectName(ib.Service.Name),
-				Port: (*gatewayv1.PortNumber)(&ib.Service.Port.Number),
-			},
-		}, nil
-	}
-	return &gatewayv1.BackendRef{
-		BackendObjectReference: gatewayv1.BackendObjectReference{
-			Group: (*gatewayv1.Group)(ib.Resource.APIGroup),
-			Kind:  (*gatewayv1.Kind)(&ib.Resource.Kind),
-			Name:  gatewayv1.ObjectName(ib.Resource.Name),
-		},
-	}, nil
+		return nil
+	default:
+		return field.Invalid(path.Child("pathType"), routePath.PathType, fmt.Sprintf("unsupported path match type: %s", *routePath.PathType))
+	}
 }
@@ -18,11 +18,15 @@ package common
 
 import (
 	"errors"
+	"fmt"
+	"slices"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/intermediate"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/naming"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
@@ -266,6 +270,10 @@ func Test_ToIR(t *testing.T) {
 									Port:     80,
 									Protocol: gatewayv1.HTTPProtocolType,
 									Hostname: PtrTo(gatewayv1.Hostname("example.net")),
+								}, {
+									Name:     "http",
+									Port:     80,
+									Protocol: gatewayv1.HTTPProtocolType,
 								}},
 							},
 						},
@@ -302,28 +310,6 @@ func Test_ToIR(t *testing.T) {
 							},
 						},
 					},
-					{Namespace: "different", Name: "net-default-backend"}: {
-						HTTPRoute: gatewayv1.HTTPRoute{
-							ObjectMeta: metav1.ObjectMeta{Name: "net-default-backend", Namespace: "different"},
-							Spec: gatewayv1.HTTPRouteSpec{
-								CommonRouteSpec: gatewayv1.CommonRouteSpec{
-									ParentRefs: []gatewayv1.ParentReference{{
-										Name: "example-proxy",
-									}},
-								},
-								Rules: []gatewayv1.HTTPRouteRule{{
-									BackendRefs: []gatewayv1.HTTPBackendRef{{
-										BackendRef: gatewayv1.BackendRef{
-											BackendObjectReference: gatewayv1.BackendObjectReference{
-												Name: "default",
-												Port: PtrTo(gatewayv1.PortNumber(8080)),
-											},
-										}},
-									}},
-								},
-							},
-						},
-					},
 				},
 			},
 			expectedErrors: field.ErrorList{},
@@ -430,6 +416,115 @@ func Test_ToIR(t *testing.T) {
 			},
 			expectedErrors: field.ErrorList{},
 		},
+		{
+			name: "invalid paths are skipped",
+			ingresses: []networkingv1.Ingress{{
+				ObjectMeta: metav1.ObjectMeta{Name: "skipped", Namespace: "test"},
+				Spec: networkingv1.IngressSpec{
+					Rules: []networkingv1.IngressRule{{
+						Host: "example.com",
+						IngressRuleValue: networkingv1.IngressRuleValue{
+							HTTP: &networkingv1.HTTPIngressRuleValue{
+								Paths: []networkingv1.HTTPIngressPath{{
+									Path:     "/foo",
+									PathType: &iPrefix,
+									Backend: networkingv1.IngressBackend{
+										Service: &networkingv1.IngressServiceBackend{
+											Name: "example",
+											Port: networkingv1.ServiceBackendPort{
+												Number: 3000,
+											},
+										},
+									},
+								}, {
+									Path: "/bar",
+									Backend: networkingv1.IngressBackend{
+										Service: &networkingv1.IngressServiceBackend{
+											Name: "example",
+											Port: networkingv1.ServiceBackendPort{
+												Number: 3000,
+											},
+										},
+									},
+								}},
+							},
+						},
+					}, {
+						Host: "example.net",
+						IngressRuleValue: networkingv1.IngressRuleValue{
+							HTTP: &networkingv1.HTTPIngressRuleValue{
+								Paths: []networkingv1.HTTPIngressPath{{
+									Path:     "/",
+									PathType: PtrTo(networkingv1.PathTypeImplementationSpecific),
+									Backend: networkingv1.IngressBackend{
+										Service: &networkingv1.IngressServiceBackend{
+											Name: "example",
+											Port: networkingv1.ServiceBackendPort{
+												Number: 3000,
+											},
+										},
+									},
+								}},
+							},
+						},
+					}},
+					IngressClassName: PtrTo("skipped"),
+				},
+			}},
+			expectedIR: intermediate.IR{
+				Gateways: map[types.NamespacedName]intermediate.GatewayContext{
+					{Namespace: "test", Name: "skipped"}: {
+						Gateway: gatewayv1.Gateway{
+							ObjectMeta: metav1.ObjectMeta{Name: "skipped", Namespace: "test"},
+							Spec: gatewayv1.GatewaySpec{
+								GatewayClassName: "skipped",
+								Listeners: []gatewayv1.Listener{{
+									Name:     "example-com-http",
+									Port:     80,
+									Protocol: gatewayv1.HTTPProtocolType,
+									Hostname: PtrTo(gatewayv1.Hostname("example.com")),
+								}},
+							},
+						},
+					},
+				},
+				HTTPRoutes: map[types.NamespacedName]intermediate.HTTPRouteContext{
+					{Namespace: "test", Name: "skipped-example-com"}: {
+						HTTPRoute: gatewayv1.HTTPRoute{
+							ObjectMeta: metav1.ObjectMeta{Name: "skipped-example-com", Namespace: "test"},
+							Spec: gatewayv1.HTTPRouteSpec{
+								CommonRouteSpec: gatewayv1.CommonRouteSpec{
+									ParentRefs: []gatewayv1.ParentReference{{
+										Name: "skipped",
+									}},
+								},
+								Hostnames: []gatewayv1.Hostname{"example.com"},
+								Rules: []gatewayv1.HTTPRouteRule{{
+									Matches: []gatewayv1.HTTPRouteMatch{{
+										Path: &gatewayv1.HTTPPathMatch{
+											Type:  &gPathPrefix,
+											Value: PtrTo("/foo"),
+										},
+									}},
+									BackendRefs: []gatewayv1.HTTPBackendRef{{
+										BackendRef: gatewayv1.BackendRef{
+											BackendObjectReference: gatewayv1.BackendObjectReference{
+												Name: "example",
+												Port: PtrTo(gatewayv1.PortNumber(3000)),
+											},
+										},
+									}},
+								}},
+							},
+						},
+					},
+				},
+			},
+			expectedErrors: field.ErrorList{
+				field.Invalid(field.NewPath("test/skipped", "spec", "rules").Index(0).Child("http", "paths").Index(1).Child("pathType"), nil, "pathType is required"),
+				field.Invalid(field.NewPath("test/skipped", "spec", "rules").Index(1).Child("http", "paths").Index(0).Child("pathType"), PtrTo(networkingv1.PathTypeImplementationSpecific), "implementationSpecific path type is not supported in generic translation, and your provider does not provide custom support to translate it"),
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -477,3 +572,458 @@ func Test_ToIR(t *testing.T) {
 		})
 	}
 }
+
+func Test_ToIR_sources(t *testing.T) {
+	iPrefix := networkingv1.PathTypePrefix
+	ingress := func(name, host string) networkingv1.Ingress {
+		return networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test"},
+			Spec: networkingv1.IngressSpec{
+				IngressClassName: PtrTo("ingress-nginx"),
+				Rules: []networkingv1.IngressRule{{
+					Host: host,
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{
+							Paths: []networkingv1.HTTPIngressPath{{
+								Path:     "/" + name,
+								PathType: &iPrefix,
+								Backend: networkingv1.IngressBackend{
+									Service: &networkingv1.IngressServiceBackend{
+										Name: name,
+										Port: networkingv1.ServiceBackendPort{Number: 80},
+									},
+								},
+							}},
+						},
+					},
+				}},
+			},
+		}
+	}
+	ingressRef := func(name string) intermediate.ObjectRef {
+		return intermediate.ObjectRef{Kind: "Ingress", Namespace: "test", Name: name}
+	}
+
+	ir, errs := ToIR([]networkingv1.Ingress{
+		ingress("foo", "example.com"),
+		ingress("bar", "example.com"),
+		ingress("baz", "other.com"),
+	}, i2gw.ProviderImplementationSpecificOptions{})
+	if len(errs) > 0 {
+		t.Fatalf("Expected no errors, got %+v", errs)
+	}
+
+	expectedSources := map[intermediate.ObjectRef][]intermediate.ObjectRef{
+		{Kind: "Gateway", Namespace: "test", Name: "ingress-nginx"}:     {ingressRef("bar"), ingressRef("baz"), ingressRef("foo")},
+		{Kind: "HTTPRoute", Namespace: "test", Name: "foo-example-com"}: {ingressRef("bar"), ingressRef("foo")},
+		{Kind: "HTTPRoute", Namespace: "test", Name: "baz-other-com"}:   {ingressRef("baz")},
+	}
+	if diff := cmp.Diff(expectedSources, ir.Sources); diff != "" {
+		t.Errorf("Unexpected sources (-want +got):\n%s", diff)
+	}
+
+	expectedRuleSources := map[intermediate.ObjectRef]map[int][]intermediate.RuleSource{
+		{Kind: "HTTPRoute", Namespace: "test", Name: "foo-example-com"}: {
+			0: {{Source: ingressRef("foo"), RuleIndex: 0, PathIndex: 0}},
+			1: {{Source: ingressRef("bar"), RuleIndex: 0, PathIndex: 0}},
+		},
+		{Kind: "HTTPRoute", Namespace: "test", Name: "baz-other-com"}: {
+			0: {{Source: ingressRef("baz"), RuleIndex: 0, PathIndex: 0}},
+		},
+	}
+	if diff := cmp.Diff(expectedRuleSources, ir.RuleSources); diff != "" {
+		t.Errorf("Unexpected rule sources (-want +got):\n%s", diff)
+	}
+}
+
+func Test_ToIR_splitRoutes(t *testing.T) {
+	iPrefix := networkingv1.PathTypePrefix
+	ingress := func(name string, paths ...string) networkingv1.Ingress {
+		ingress := networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test"},
+			Spec: networkingv1.IngressSpec{
+				IngressClassName: PtrTo("ingress-nginx"),
+				Rules: []networkingv1.IngressRule{{
+					Host: "example.com",
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{},
+					},
+				}},
+			},
+		}
+		for _, path := range paths {
+			ingress.Spec.Rules[0].HTTP.Paths = append(ingress.Spec.Rules[0].HTTP.Paths, networkingv1.HTTPIngressPath{
+				Path:     path,
+				PathType: &iPrefix,
+				Backend: networkingv1.IngressBackend{
+					Service: &networkingv1.IngressServiceBackend{
+						Name: name,
+						Port: networkingv1.ServiceBackendPort{Number: 80},
+					},
+				},
+			})
+		}
+		return ingress
+	}
+	var paths []string
+	for i := 0; i < 2*MaxHTTPRouteRules+2; i++ {
+		paths = append(paths, fmt.Sprintf("/%02d", i))
+	}
+	ingressRef := func(name string) intermediate.ObjectRef {
+		return intermediate.ObjectRef{Kind: "Ingress", Namespace: "test", Name: name}
+	}
+	routeRef := func(name string) intermediate.ObjectRef {
+		return intermediate.ObjectRef{Kind: "HTTPRoute", Namespace: "test", Name: name}
+	}
+
+	ir, errs := ToIR([]networkingv1.Ingress{
+		ingress("many", paths...),
+		ingress("other", "/other"),
+	}, i2gw.ProviderImplementationSpecificOptions{})
+	if len(errs) > 0 {
+		t.Fatalf("Expected no errors, got %+v", errs)
+	}
+
+	expectedKeys := []types.NamespacedName{
+		{Namespace: "test", Name: "many-example-com"},
+		{Namespace: "test", Name: "many-example-com-2"},
+		{Namespace: "test", Name: "many-example-com-3"},
+	}
+	keys := HTTPRouteKeys(&ir, "test", "ingress-nginx", "example.com")
+	if diff := cmp.Diff(expectedKeys, keys); diff != "" {
+		t.Fatalf("Unexpected HTTPRoute keys (-want +got):\n%s", diff)
+	}
+	if len(ir.HTTPRoutes) != len(expectedKeys) {
+		t.Errorf("Expected %d HTTPRoutes, got %d", len(expectedKeys), len(ir.HTTPRoutes))
+	}
+
+	// The rules keep their order across the routes.
+	var gotPaths []string
+	for i, key := range keys {
+		route := ir.HTTPRoutes[key].HTTPRoute
+		if len(route.Spec.Rules) > MaxHTTPRouteRules {
+			t.Errorf("Expected at most %d rules in HTTPRoute %s, got %d", MaxHTTPRouteRules, key, len(route.Spec.Rules))
+		}
+		if i > 0 && (route.Spec.Hostnames[0] != "example.com" || route.Spec.ParentRefs[0].Name != "ingress-nginx") {
+			t.Errorf("Expected HTTPRoute %s to have the hostname and parentRefs of the first, got %+v", key, route.Spec)
+		}
+		for _, rule := range route.Spec.Rules {
+			gotPaths = append(gotPaths, *rule.Matches[0].Path.Value)
+		}
+	}
+	if diff := cmp.Diff(append(paths, "/other"), gotPaths); diff != "" {
+		t.Errorf("Unexpected paths (-want +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff([]intermediate.ObjectRef{ingressRef("many")}, ir.Sources[routeRef("many-example-com-2")]); diff != "" {
+		t.Errorf("Unexpected sources of the second route (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]intermediate.ObjectRef{ingressRef("many"), ingressRef("other")}, ir.Sources[routeRef("many-example-com-3")]); diff != "" {
+		t.Errorf("Unexpected sources of the third route (-want +got):\n%s", diff)
+	}
+	expectedRuleSources := map[int][]intermediate.RuleSource{
+		0: {{Source: ingressRef("many"), RuleIndex: 0, PathIndex: 2 * MaxHTTPRouteRules}},
+		1: {{Source: ingressRef("many"), RuleIndex: 0, PathIndex: 2*MaxHTTPRouteRules + 1}},
+		2: {{Source: ingressRef("other"), RuleIndex: 0, PathIndex: 0}},
+	}
+	if diff := cmp.Diff(expectedRuleSources, ir.RuleSources[routeRef("many-example-com-3")]); diff != "" {
+		t.Errorf("Unexpected rule sources of the third route (-want +got):\n%s", diff)
+	}
+}
+
+func Test_ToIR_nameCollisions(t *testing.T) {
+	iPrefix := networkingv1.PathTypePrefix
+	rule := func(host string) networkingv1.IngressRule {
+		return networkingv1.IngressRule{
+			Host: host,
+			IngressRuleValue: networkingv1.IngressRuleValue{
+				HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: []networkingv1.HTTPIngressPath{{
+						Path:     "/",
+						PathType: &iPrefix,
+						Backend: networkingv1.IngressBackend{
+							Service: &networkingv1.IngressServiceBackend{
+								Name: "test",
+								Port: networkingv1.ServiceBackendPort{Number: 80},
+							},
+						},
+					}},
+				},
+			},
+		}
+	}
+
+	// a.b.com and a-b.com have the same name.
+	ir, errs := ToIR([]networkingv1.Ingress{{
+		ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "test"},
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: PtrTo("ingress-nginx"),
+			Rules:            []networkingv1.IngressRule{rule("a.b.com"), rule("a-b.com")},
+		},
+	}}, i2gw.ProviderImplementationSpecificOptions{})
+	if len(errs) > 0 {
+		t.Fatalf("Expected no errors, got %+v", errs)
+	}
+
+	for _, host := range []string{"a.b.com", "a-b.com"} {
+		keys := HTTPRouteKeys(&ir, "test", "ingress-nginx", host)
+		if len(keys) != 1 {
+			t.Fatalf("Expected 1 HTTPRoute for host %s, got %v", host, keys)
+		}
+		expectedName := naming.WithHash("a-a-b-com", "a/"+host)
+		if keys[0].Name != expectedName {
+			t.Errorf("Expected HTTPRoute %s for host %s, got %s", expectedName, host, keys[0].Name)
+		}
+	}
+
+	gateway := ir.Gateways[types.NamespacedName{Namespace: "test", Name: "ingress-nginx"}].Gateway
+	listenerNames := map[string]gatewayv1.SectionName{}
+	for _, listener := range gateway.Spec.Listeners {
+		listenerNames[string(*listener.Hostname)] = listener.Name
+	}
+	for _, host := range []string{"a.b.com", "a-b.com"} {
+		expectedName := gatewayv1.SectionName(naming.WithHash("a-b-com", host) + "-http")
+		if listenerNames[host] != expectedName {
+			t.Errorf("Expected listener %s for host %s, got %s", expectedName, host, listenerNames[host])
+		}
+	}
+	if len(listenerNames) != 2 {
+		t.Errorf("Expected 2 listeners, got %v", gateway.Spec.Listeners)
+	}
+}
+
+func Test_ToIR_ruleGroupHTTPRoutes(t *testing.T) {
+	iPrefix := networkingv1.PathTypePrefix
+	ingress := func(name, host string) networkingv1.Ingress {
+		return networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test"},
+			Spec: networkingv1.IngressSpec{
+				IngressClassName: PtrTo("ingress-nginx"),
+				Rules: []networkingv1.IngressRule{{
+					Host: host,
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{
+							Paths: []networkingv1.HTTPIngressPath{{
+								Path:     "/",
+								PathType: &iPrefix,
+								Backend: networkingv1.IngressBackend{
+									Service: &networkingv1.IngressServiceBackend{
+										Name: "test",
+										Port: networkingv1.ServiceBackendPort{Number: 80},
+									},
+								},
+							}},
+						},
+					},
+				}},
+			},
+		}
+	}
+
+	// The route of the host 2 of the Ingress a-b-com is named like the second
+	// route of the host b.com of the Ingress a.
+	ir, errs := ToIR([]networkingv1.Ingress{ingress("a", "b.com"), ingress("a-b-com", "2")}, i2gw.ProviderImplementationSpecificOptions{})
+	if len(errs) > 0 {
+		t.Fatalf("Expected no errors, got %+v", errs)
+	}
+
+	expectedKeys := map[string][]types.NamespacedName{
+		"b.com": {{Namespace: "test", Name: "a-b-com"}},
+		"2":     {{Namespace: "test", Name: "a-b-com-2"}},
+	}
+	for host, expected := range expectedKeys {
+		if diff := cmp.Diff(expected, HTTPRouteKeys(&ir, "test", "ingress-nginx", host)); diff != "" {
+			t.Errorf("Unexpected HTTPRoute keys of host %s (-want +got):\n%s", host, diff)
+		}
+	}
+}
+
+func Test_ToIR_defaultBackends(t *testing.T) {
+	iPrefix := networkingv1.PathTypePrefix
+	ingress := func(name string, created int, backend, host string, paths ...string) networkingv1.Ingress {
+		ingress := networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "test",
+				CreationTimestamp: metav1.Unix(int64(created), 0),
+			},
+			Spec: networkingv1.IngressSpec{
+				IngressClassName: PtrTo("nginx"),
+				DefaultBackend: &networkingv1.IngressBackend{
+					Service: &networkingv1.IngressServiceBackend{
+						Name: backend,
+						Port: networkingv1.ServiceBackendPort{Number: 80},
+					},
+				},
+			},
+		}
+		if len(paths) > 0 {
+			rule := networkingv1.IngressRule{
+				Host: host,
+				IngressRuleValue: networkingv1.IngressRuleValue{
+					HTTP: &networkingv1.HTTPIngressRuleValue{},
+				},
+			}
+			for _, path := range paths {
+				rule.HTTP.Paths = append(rule.HTTP.Paths, networkingv1.HTTPIngressPath{
+					Path:     path,
+					PathType: &iPrefix,
+					Backend:  *ingress.Spec.DefaultBackend,
+				})
+			}
+			ingress.Spec.Rules = []networkingv1.IngressRule{rule}
+		}
+		return ingress
+	}
+	ingressRef := func(name string) intermediate.ObjectRef {
+		return intermediate.ObjectRef{Kind: "Ingress", Namespace: "test", Name: name}
+	}
+	backendRef := func(name string) gatewayv1.BackendRef {
+		return gatewayv1.BackendRef{
+			BackendObjectReference: gatewayv1.BackendObjectReference{
+				Name: gatewayv1.ObjectName(name),
+				Port: PtrTo(gatewayv1.PortNumber(80)),
+			},
+		}
+	}
+	gatewayKey := types.NamespacedName{Namespace: "test", Name: "nginx"}
+
+	testCases := []struct {
+		name                   string
+		ingresses              []networkingv1.Ingress
+		expectedDefaultBackend *intermediate.DefaultBackendIR
+		// expectedListeners are the listeners the HTTPRoute of the default
+		// backend attaches to.
+		expectedListeners []gatewayv1.SectionName
+		expectedWarnings  []string
+	}{
+		{
+			name: "single default backend",
+			ingresses: []networkingv1.Ingress{
+				ingress("a", 1, "one", ""),
+			},
+			expectedDefaultBackend: &intermediate.DefaultBackendIR{
+				BackendRef: backendRef("one"),
+				HTTPRoute:  "a-default-backend",
+				Sources:    []intermediate.ObjectRef{ingressRef("a")},
+			},
+			expectedListeners: []gatewayv1.SectionName{"http"},
+		},
+		{
+			name: "oldest default backend",
+			ingresses: []networkingv1.Ingress{
+				ingress("c", 1, "one", ""),
+				ingress("b", 2, "two", ""),
+				ingress("a", 1, "one", ""),
+			},
+			expectedDefaultBackend: &intermediate.DefaultBackendIR{
+				BackendRef: backendRef("one"),
+				HTTPRoute:  "nginx-default-backend",
+				Sources:    []intermediate.ObjectRef{ingressRef("a"), ingressRef("c")},
+			},
+			expectedListeners: []gatewayv1.SectionName{"http"},
+			expectedWarnings: []string{
+				"the default backend two conflicts with the default backend one of the older Ingress test/a of the same class, it is not converted",
+				"the default backend one conflicts with the default backend two of the newer Ingress test/b of the same class, it is kept",
+			},
+		},
+		{
+			// The requests to /bar on example.com match no rule of the host,
+			// and reach the default backend on the listener of the host.
+			name: "default backend of the paths of a host without rule",
+			ingresses: []networkingv1.Ingress{
+				ingress("a", 1, "one", "example.com", "/foo"),
+			},
+			expectedDefaultBackend: &intermediate.DefaultBackendIR{
+				BackendRef: backendRef("one"),
+				HTTPRoute:  "a-default-backend",
+				Sources:    []intermediate.ObjectRef{ingressRef("a")},
+			},
+			expectedListeners: []gatewayv1.SectionName{"example-com-http", "http"},
+		},
+		{
+			name: "default backend shadowed by a rule without host",
+			ingresses: []networkingv1.Ingress{
+				ingress("a", 1, "one", "", "/"),
+			},
+			expectedDefaultBackend: &intermediate.DefaultBackendIR{
+				BackendRef: backendRef("one"),
+				Sources:    []intermediate.ObjectRef{ingressRef("a")},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ir, errs := ToIR(tc.ingresses, i2gw.ProviderImplementationSpecificOptions{})
+			if len(errs) > 0 {
+				t.Fatalf("Expected no errors, got %+v", errs)
+			}
+
+			conf := &i2gw.ProviderConf{Notifications: notifications.NewNotificationAggregator()}
+			NotifyDefaultBackendConflicts(conf, "test-provider", tc.ingresses)
+			var warnings []string
+			for _, notification := range conf.Notifications.Notifications["test-provider"] {
+				if notification.Type == notifications.WarningNotification {
+					warnings = append(warnings, notification.Message)
+				}
+			}
+			if diff := cmp.Diff(tc.expectedWarnings, warnings); diff != "" {
+				t.Errorf("Unexpected warnings (-want +got):\n%s", diff)
+			}
+
+			gateway, ok := ir.Gateways[gatewayKey]
+			if !ok {
+				t.Fatalf("Expected Gateway %s, got %v", gatewayKey, ir.Gateways)
+			}
+			if diff := cmp.Diff(tc.expectedDefaultBackend, gateway.DefaultBackend); diff != "" {
+				t.Errorf("Unexpected default backend (-want +got):\n%s", diff)
+			}
+			if !slices.ContainsFunc(gateway.Spec.Listeners, func(listener gatewayv1.Listener) bool { return listener.Hostname == nil }) {
+				t.Fatalf("Expected a listener without hostname, got %+v", gateway.Spec.Listeners)
+			}
+
+			routeKey := types.NamespacedName{Namespace: "test", Name: tc.expectedDefaultBackend.HTTPRoute}
+			if _, ok := ir.HTTPRoutes[routeKey]; ok {
+				t.Errorf("Expected the HTTPRoute %s of the default backend to be generated from the IR only", routeKey)
+			}
+			gatewayResources, errs := ToGatewayResources(ir)
+			if len(errs) > 0 {
+				t.Fatalf("Expected no errors, got %+v", errs)
+			}
+			route, ok := gatewayResources.HTTPRoutes[routeKey]
+			if ok != (tc.expectedDefaultBackend.HTTPRoute != "") {
+				t.Fatalf("Expected HTTPRoute %s to be generated: %t, got %v", routeKey, tc.expectedDefaultBackend.HTTPRoute != "", gatewayResources.HTTPRoutes)
+			}
+			if !ok {
+				return
+			}
+			var listeners []gatewayv1.SectionName
+			for _, listener := range gateway.Spec.Listeners {
+				if slices.ContainsFunc(route.Spec.ParentRefs, func(parentRef gatewayv1.ParentReference) bool {
+					return parentRef.Name == gatewayv1.ObjectName(gateway.Name) && (parentRef.SectionName == nil || *parentRef.SectionName == listener.Name)
+				}) && len(route.Spec.Hostnames) == 0 {
+					listeners = append(listeners, listener.Name)
+				}
+			}
+			if diff := cmp.Diff(tc.expectedListeners, listeners); diff != "" {
+				t.Errorf("Unexpected listeners of the HTTPRoute (-want +got):\n%s", diff)
+			}
+			expectedRules := []gatewayv1.HTTPRouteRule{{
+				Matches: []gatewayv1.HTTPRouteMatch{{
+					Path: &gatewayv1.HTTPPathMatch{
+						Type:  PtrTo(gatewayv1.PathMatchPathPrefix),
+						Value: PtrTo("/"),
+					},
+				}},
+				BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: tc.expectedDefaultBackend.BackendRef}},
+			}}
+			if diff := cmp.Diff(expectedRules, route.Spec.Rules); diff != "" {
+				t.Errorf("Unexpected rules of the HTTPRoute (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedDefaultBackend.Sources, ir.Sources[intermediate.ObjectRef{Kind: "HTTPRoute", Namespace: "test", Name: routeKey.Name}]); diff != "" {
+				t.Errorf("Unexpected sources of the HTTPRoute (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
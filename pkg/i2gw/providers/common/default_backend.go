@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
+	networkingv1 "k8s.io/api/networking/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// compareIngressAge orders the Ingresses by creation timestamp, and then by
+// name, so that the oldest one comes first.
+func compareIngressAge(aCreated metav1.Time, aName string, bCreated metav1.Time, bName string) int {
+	if !aCreated.Equal(&bCreated) {
+		if aCreated.Before(&bCreated) {
+			return -1
+		}
+		return 1
+	}
+	return cmp.Compare(aName, bName)
+}
+
+// NotifyDefaultBackendConflicts notifies as warnings of provider the
+// ingresses with a default backend different from the one of the oldest
+// Ingress of their namespace and class, on both Ingresses. Only the default
+// backend of the oldest Ingress is converted, see ToIR.
+func NotifyDefaultBackendConflicts(conf *i2gw.ProviderConf, provider i2gw.ProviderName, ingresses []networkingv1.Ingress) {
+	var withDefaultBackend []*networkingv1.Ingress
+	for i := range ingresses {
+		ingress := &ingresses[i]
+		if ingress.Spec.DefaultBackend == nil {
+			continue
+		}
+		if ToBackendRef(*ingress.Spec.DefaultBackend) == nil {
+			continue
+		}
+		withDefaultBackend = append(withDefaultBackend, ingress)
+	}
+	slices.SortStableFunc(withDefaultBackend, func(a, b *networkingv1.Ingress) int {
+		return compareIngressAge(a.CreationTimestamp, a.Name, b.CreationTimestamp, b.Name)
+	})
+
+	oldest := make(map[string]*networkingv1.Ingress)
+	for _, ingress := range withDefaultBackend {
+		gwKey := fmt.Sprintf("%s/%s", ingress.Namespace, GetIngressClass(*ingress))
+		kept, ok := oldest[gwKey]
+		if !ok {
+			oldest[gwKey] = ingress
+			continue
+		}
+		if apiequality.Semantic.DeepEqual(kept.Spec.DefaultBackend, ingress.Spec.DefaultBackend) {
+			continue
+		}
+		conf.Notify(provider, notifications.NewNotification(notifications.WarningNotification,
+			fmt.Sprintf("the default backend %s conflicts with the default backend %s of the older Ingress %s/%s of the same class, it is not converted",
+				backendName(*ingress.Spec.DefaultBackend), backendName(*kept.Spec.DefaultBackend), kept.Namespace, kept.Name), ingress))
+		conf.Notify(provider, notifications.NewNotification(notifications.WarningNotification,
+			fmt.Sprintf("the default backend %s conflicts with the default backend %s of the newer Ingress %s/%s of the same class, it is kept",
+				backendName(*kept.Spec.DefaultBackend), backendName(*ingress.Spec.DefaultBackend), ingress.Namespace, ingress.Name), kept))
+	}
+}
@@ -17,8 +17,11 @@ limitations under the License.
 package common
 
 import (
+	"fmt"
+
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/intermediate"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
@@ -35,12 +38,68 @@ func ToGatewayResources(ir intermediate.IR) (i2gw.GatewayResources, field.ErrorL
 		TCPRoutes:       ir.TCPRoutes,
 		UDPRoutes:       ir.UDPRoutes,
 		ReferenceGrants: ir.ReferenceGrants,
+		Sources:         ir.Sources,
+		RuleSources:     ir.RuleSources,
 	}
+	for key, httpRouteContext := range ir.HTTPRoutes {
+		gatewayResources.HTTPRoutes[key] = httpRouteContext.HTTPRoute
+	}
+	var errs field.ErrorList
 	for key, gatewayContext := range ir.Gateways {
 		gatewayResources.Gateways[key] = gatewayContext.Gateway
+		httpRoute := toDefaultBackendHTTPRoute(gatewayContext)
+		if httpRoute == nil {
+			continue
+		}
+		routeKey := types.NamespacedName{Namespace: httpRoute.Namespace, Name: httpRoute.Name}
+		if _, ok := gatewayResources.HTTPRoutes[routeKey]; ok {
+			fieldPath := field.NewPath(fmt.Sprintf("%s/%s", key.Namespace, key.Name))
+			errs = append(errs, field.Invalid(fieldPath, httpRoute.Name, "the HTTPRoute of the default backend collides with another HTTPRoute"))
+			continue
+		}
+		gatewayResources.HTTPRoutes[routeKey] = *httpRoute
 	}
-	for key, httpRouteContext := range ir.HTTPRoutes {
-		gatewayResources.HTTPRoutes[key] = httpRouteContext.HTTPRoute
+	return gatewayResources, errs
+}
+
+// toDefaultBackendHTTPRoute returns the HTTPRoute with the catch-all rule of
+// the default backend of the Gateway, or nil if it has no default backend or
+// if it is never used. The route has no hostnames and attaches to all the
+// listeners of the Gateway, so that the routes with hostnames and the more
+// specific paths take precedence over it, and the requests to a host which
+// match none of its rules reach the default backend.
+func toDefaultBackendHTTPRoute(gatewayContext intermediate.GatewayContext) *gatewayv1.HTTPRoute {
+	defaultBackend := gatewayContext.DefaultBackend
+	if defaultBackend == nil || defaultBackend.HTTPRoute == "" {
+		return nil
+	}
+	httpRoute := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      defaultBackend.HTTPRoute,
+			Namespace: gatewayContext.Namespace,
+		},
+		Spec: gatewayv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{{
+					Name: gatewayv1.ObjectName(gatewayContext.Name),
+				}},
+			},
+			Rules: []gatewayv1.HTTPRouteRule{{
+				Matches: []gatewayv1.HTTPRouteMatch{{
+					Path: &gatewayv1.HTTPPathMatch{
+						Type:  PtrTo(gatewayv1.PathMatchPathPrefix),
+						Value: PtrTo("/"),
+					},
+				}},
+				BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: defaultBackend.BackendRef}},
+			}},
+		},
+		Status: gatewayv1.HTTPRouteStatus{
+			RouteStatus: gatewayv1.RouteStatus{
+				Parents: []gatewayv1.RouteParentStatus{},
+			},
+		},
 	}
-	return gatewayResources, nil
+	httpRoute.SetGroupVersionKind(HTTPRouteGVK)
+	return httpRoute
 }
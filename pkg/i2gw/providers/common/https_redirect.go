@@ -0,0 +1,164 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/intermediate"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/naming"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// RedirectToHTTPS redirects the plain HTTP requests of the HTTPRoutes of keys,
+// i.e. the parts of the route of a host as returned by HTTPRouteKeys, to HTTPS
+// with the given status code, 301 or 302. The routes are attached by
+// sectionName to the HTTPS listeners of their hostname only, and a
+// redirect-only HTTPRoute is attached to the HTTP listeners instead. The routes
+// with no HTTPS listener to redirect to are left unchanged with a warning, see
+// HasHTTPSListener. The redirect route is named after the name ToIR reserved
+// for it, see intermediate.HTTPRouteContext.HTTPSRedirectRoute. It returns the
+// key of the redirect route, and whether one was added.
+func RedirectToHTTPS(conf *i2gw.ProviderConf, provider i2gw.ProviderName, ir *intermediate.IR, keys []types.NamespacedName, statusCode int) (types.NamespacedName, bool) {
+	if len(keys) == 0 {
+		return types.NamespacedName{}, false
+	}
+	routeContext, ok := ir.HTTPRoutes[keys[0]]
+	if !ok {
+		return types.NamespacedName{}, false
+	}
+	route := routeContext.HTTPRoute
+	httpRefs, httpsRefs, otherRefs := listenerParentRefs(ir, &route)
+	if len(httpsRefs) == 0 {
+		conf.Notify(provider, notifications.NewNotification(notifications.WarningNotification,
+			fmt.Sprintf("the HTTPRoute %s has no HTTPS listener to redirect its HTTP requests to, it is not redirected", keys[0]), &route))
+		return types.NamespacedName{}, false
+	}
+	if len(httpRefs) == 0 {
+		return types.NamespacedName{}, false
+	}
+
+	for _, key := range keys {
+		routeContext, ok := ir.HTTPRoutes[key]
+		if !ok {
+			continue
+		}
+		routeContext.Spec.ParentRefs = append(slices.Clone(httpsRefs), otherRefs...)
+		ir.HTTPRoutes[key] = routeContext
+	}
+
+	redirectKey := types.NamespacedName{Namespace: keys[0].Namespace, Name: routeContext.HTTPSRedirectRoute}
+	if redirectKey.Name == "" {
+		// The name of the redirect route of the routes which are not built by
+		// ToIR is not reserved, it gets a hash suffix if it is taken.
+		redirectKey.Name = naming.Name(keys[0].Name, "https-redirect")
+		if _, ok := ir.HTTPRoutes[redirectKey]; ok {
+			redirectKey.Name = naming.WithHash(redirectKey.Name, "httpsRedirect/"+keys[0].String())
+		}
+	}
+	redirectRoute := gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      redirectKey.Name,
+			Namespace: redirectKey.Namespace,
+		},
+		Spec: gatewayv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: httpRefs},
+			Hostnames:       route.Spec.Hostnames,
+			Rules: []gatewayv1.HTTPRouteRule{{
+				Filters: []gatewayv1.HTTPRouteFilter{{
+					Type: gatewayv1.HTTPRouteFilterRequestRedirect,
+					RequestRedirect: &gatewayv1.HTTPRequestRedirectFilter{
+						Scheme:     PtrTo("https"),
+						StatusCode: PtrTo(statusCode),
+					},
+				}},
+			}},
+		},
+		Status: gatewayv1.HTTPRouteStatus{
+			RouteStatus: gatewayv1.RouteStatus{
+				Parents: []gatewayv1.RouteParentStatus{},
+			},
+		},
+	}
+	redirectRoute.SetGroupVersionKind(HTTPRouteGVK)
+	ir.HTTPRoutes[redirectKey] = intermediate.HTTPRouteContext{HTTPRoute: redirectRoute}
+
+	if ir.Sources == nil {
+		ir.Sources = make(map[intermediate.ObjectRef][]intermediate.ObjectRef)
+	}
+	redirectRef := intermediate.ObjectRef{Kind: HTTPRouteGVK.Kind, Namespace: redirectKey.Namespace, Name: redirectKey.Name}
+	for _, key := range keys {
+		for _, source := range ir.Sources[intermediate.ObjectRef{Kind: HTTPRouteGVK.Kind, Namespace: key.Namespace, Name: key.Name}] {
+			intermediate.AddSource(ir.Sources, redirectRef, source)
+		}
+	}
+	return redirectKey, true
+}
+
+// HasHTTPSListener returns whether the HTTPRoute of key is attached to an
+// HTTPS listener of its hostnames, which its HTTP requests can be redirected
+// to by RedirectToHTTPS.
+func HasHTTPSListener(ir *intermediate.IR, key types.NamespacedName) bool {
+	routeContext, ok := ir.HTTPRoutes[key]
+	if !ok {
+		return false
+	}
+	_, httpsRefs, _ := listenerParentRefs(ir, &routeContext.HTTPRoute)
+	return len(httpsRefs) > 0
+}
+
+// listenerParentRefs splits the parentRefs of route into the ones of the HTTP
+// and of the HTTPS listeners of its hostname, by sectionName, and the ones of
+// the Gateways which are not part of the IR, which are kept as they are. The
+// route attaches to the listeners as in i2gw.AttachesToListener.
+func listenerParentRefs(ir *intermediate.IR, route *gatewayv1.HTTPRoute) (httpRefs, httpsRefs, otherRefs []gatewayv1.ParentReference) {
+	for _, parentRef := range route.Spec.ParentRefs {
+		if parentRef.Kind != nil && *parentRef.Kind != "Gateway" {
+			otherRefs = append(otherRefs, parentRef)
+			continue
+		}
+		namespace := route.Namespace
+		if parentRef.Namespace != nil {
+			namespace = string(*parentRef.Namespace)
+		}
+		gateway, ok := ir.Gateways[types.NamespacedName{Namespace: namespace, Name: string(parentRef.Name)}]
+		if !ok {
+			otherRefs = append(otherRefs, parentRef)
+			continue
+		}
+		for _, listener := range gateway.Spec.Listeners {
+			if !i2gw.AttachesToListener(parentRef, route.Spec.Hostnames, listener) {
+				continue
+			}
+			listenerRef := parentRef
+			listenerRef.SectionName = PtrTo(listener.Name)
+			listenerRef.Port = nil
+			switch listener.Protocol {
+			case gatewayv1.HTTPProtocolType:
+				httpRefs = append(httpRefs, listenerRef)
+			case gatewayv1.HTTPSProtocolType:
+				httpsRefs = append(httpsRefs, listenerRef)
+			}
+		}
+	}
+	return httpRefs, httpsRefs, otherRefs
+}
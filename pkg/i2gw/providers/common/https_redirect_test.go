@@ -0,0 +1,238 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/intermediate"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestRedirectToHTTPS(t *testing.T) {
+	iPrefix := networkingv1.PathTypePrefix
+	rule := func(host string) networkingv1.IngressRule {
+		return networkingv1.IngressRule{
+			Host: host,
+			IngressRuleValue: networkingv1.IngressRuleValue{
+				HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: []networkingv1.HTTPIngressPath{{
+						Path:     "/",
+						PathType: &iPrefix,
+						Backend: networkingv1.IngressBackend{
+							Service: &networkingv1.IngressServiceBackend{
+								Name: "test",
+								Port: networkingv1.ServiceBackendPort{Number: 80},
+							},
+						},
+					}},
+				},
+			},
+		}
+	}
+	ingresses := []networkingv1.Ingress{{
+		ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "test"},
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: PtrTo("nginx"),
+			TLS:              []networkingv1.IngressTLS{{Hosts: []string{"secure.example.com"}, SecretName: "secure"}},
+			Rules:            []networkingv1.IngressRule{rule("secure.example.com"), rule("plain.example.com")},
+		},
+	}}
+
+	ir, errs := ToIR(ingresses, i2gw.ProviderImplementationSpecificOptions{})
+	if len(errs) > 0 {
+		t.Fatalf("Expected no errors, got %+v", errs)
+	}
+	conf := &i2gw.ProviderConf{Notifications: notifications.NewNotificationAggregator()}
+
+	secureKeys := HTTPRouteKeys(&ir, "test", "nginx", "secure.example.com")
+	redirectKey, ok := RedirectToHTTPS(conf, "test-provider", &ir, secureKeys, 302)
+	expectedRedirectKey := types.NamespacedName{Namespace: "test", Name: "a-secure-example-com-https-redirect"}
+	if !ok || redirectKey != expectedRedirectKey {
+		t.Fatalf("Expected the redirect HTTPRoute %v, got %v, %t", expectedRedirectKey, redirectKey, ok)
+	}
+
+	expectedParentRefs := []gatewayv1.ParentReference{{Name: "nginx", SectionName: PtrTo[gatewayv1.SectionName]("secure-example-com-https")}}
+	if diff := cmp.Diff(expectedParentRefs, ir.HTTPRoutes[secureKeys[0]].Spec.ParentRefs); diff != "" {
+		t.Errorf("Unexpected parentRefs of the redirected HTTPRoute (-want +got):\n%s", diff)
+	}
+	expectedRedirectSpec := gatewayv1.HTTPRouteSpec{
+		CommonRouteSpec: gatewayv1.CommonRouteSpec{
+			ParentRefs: []gatewayv1.ParentReference{{Name: "nginx", SectionName: PtrTo[gatewayv1.SectionName]("secure-example-com-http")}},
+		},
+		Hostnames: []gatewayv1.Hostname{"secure.example.com"},
+		Rules: []gatewayv1.HTTPRouteRule{{
+			Filters: []gatewayv1.HTTPRouteFilter{{
+				Type: gatewayv1.HTTPRouteFilterRequestRedirect,
+				RequestRedirect: &gatewayv1.HTTPRequestRedirectFilter{
+					Scheme:     PtrTo("https"),
+					StatusCode: PtrTo(302),
+				},
+			}},
+		}},
+	}
+	if diff := cmp.Diff(expectedRedirectSpec, ir.HTTPRoutes[redirectKey].Spec); diff != "" {
+		t.Errorf("Unexpected redirect HTTPRoute (-want +got):\n%s", diff)
+	}
+	expectedSources := []intermediate.ObjectRef{{Kind: "Ingress", Namespace: "test", Name: "a"}}
+	if diff := cmp.Diff(expectedSources, ir.Sources[intermediate.ObjectRef{Kind: "HTTPRoute", Namespace: "test", Name: redirectKey.Name}]); diff != "" {
+		t.Errorf("Unexpected sources of the redirect HTTPRoute (-want +got):\n%s", diff)
+	}
+
+	// Redirecting again finds no HTTP listener left to redirect.
+	if _, ok := RedirectToHTTPS(conf, "test-provider", &ir, secureKeys, 302); ok {
+		t.Errorf("Expected the HTTPRoute to be redirected once")
+	}
+
+	plainKeys := HTTPRouteKeys(&ir, "test", "nginx", "plain.example.com")
+	plainRoute := ir.HTTPRoutes[plainKeys[0]].HTTPRoute
+	if _, ok := RedirectToHTTPS(conf, "test-provider", &ir, plainKeys, 301); ok {
+		t.Errorf("Expected no redirect HTTPRoute without an HTTPS listener")
+	}
+	if diff := cmp.Diff(plainRoute, ir.HTTPRoutes[plainKeys[0]].HTTPRoute); diff != "" {
+		t.Errorf("Expected the HTTPRoute without an HTTPS listener to be unchanged (-want +got):\n%s", diff)
+	}
+	got := conf.Notifications.Notifications["test-provider"]
+	expectedMessage := "the HTTPRoute test/a-plain-example-com has no HTTPS listener to redirect its HTTP requests to, it is not redirected"
+	if len(got) != 1 || got[0].Type != notifications.WarningNotification || got[0].Message != expectedMessage {
+		t.Errorf("Expected a warning %q, got %+v", expectedMessage, got)
+	}
+}
+
+func TestRedirectToHTTPS_reservedName(t *testing.T) {
+	iPrefix := networkingv1.PathTypePrefix
+	rule := func(host string) networkingv1.IngressRule {
+		return networkingv1.IngressRule{
+			Host: host,
+			IngressRuleValue: networkingv1.IngressRuleValue{
+				HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: []networkingv1.HTTPIngressPath{{
+						Path:     "/",
+						PathType: &iPrefix,
+						Backend: networkingv1.IngressBackend{
+							Service: &networkingv1.IngressServiceBackend{
+								Name: "test",
+								Port: networkingv1.ServiceBackendPort{Number: 80},
+							},
+						},
+					}},
+				},
+			},
+		}
+	}
+	// The route of the second Ingress is named like the redirect route of the
+	// first one.
+	ingresses := []networkingv1.Ingress{{
+		ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "test"},
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: PtrTo("nginx"),
+			TLS:              []networkingv1.IngressTLS{{Hosts: []string{"secure.example.com"}, SecretName: "secure"}},
+			Rules:            []networkingv1.IngressRule{rule("secure.example.com")},
+		},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{Name: "a-secure-example-com", Namespace: "test"},
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: PtrTo("nginx"),
+			Rules:            []networkingv1.IngressRule{rule("https-redirect")},
+		},
+	}}
+
+	ir, errs := ToIR(ingresses, i2gw.ProviderImplementationSpecificOptions{})
+	if len(errs) > 0 {
+		t.Fatalf("Expected no errors, got %+v", errs)
+	}
+	conf := &i2gw.ProviderConf{Notifications: notifications.NewNotificationAggregator()}
+
+	userKeys := HTTPRouteKeys(&ir, "test", "nginx", "https-redirect")
+	if len(userKeys) != 1 {
+		t.Fatalf("Expected the HTTPRoute of the host https-redirect, got %v", ir.HTTPRoutes)
+	}
+	userRoute := ir.HTTPRoutes[userKeys[0]].HTTPRoute
+
+	redirectKey, ok := RedirectToHTTPS(conf, "test-provider", &ir, HTTPRouteKeys(&ir, "test", "nginx", "secure.example.com"), 301)
+	if !ok {
+		t.Fatalf("Expected a redirect HTTPRoute")
+	}
+	if redirectKey == userKeys[0] {
+		t.Errorf("Expected the redirect HTTPRoute not to be named like the HTTPRoute %v", userKeys[0])
+	}
+	if len(ir.HTTPRoutes) != 3 {
+		t.Errorf("Expected 3 HTTPRoutes, got %v", ir.HTTPRoutes)
+	}
+	if diff := cmp.Diff(userRoute, ir.HTTPRoutes[userKeys[0]].HTTPRoute); diff != "" {
+		t.Errorf("Expected the HTTPRoute of the host https-redirect to be unchanged (-want +got):\n%s", diff)
+	}
+}
+
+func TestRedirectToHTTPS_tlsHostOnly(t *testing.T) {
+	iPrefix := networkingv1.PathTypePrefix
+	ingresses := []networkingv1.Ingress{{
+		ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "test"},
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: PtrTo("nginx"),
+			TLS:              []networkingv1.IngressTLS{{Hosts: []string{"secure.example.com"}, SecretName: "secure"}},
+			Rules: []networkingv1.IngressRule{{
+				IngressRuleValue: networkingv1.IngressRuleValue{
+					HTTP: &networkingv1.HTTPIngressRuleValue{
+						Paths: []networkingv1.HTTPIngressPath{{
+							Path:     "/",
+							PathType: &iPrefix,
+							Backend: networkingv1.IngressBackend{
+								Service: &networkingv1.IngressServiceBackend{
+									Name: "test",
+									Port: networkingv1.ServiceBackendPort{Number: 80},
+								},
+							},
+						}},
+					},
+				},
+			}},
+		},
+	}}
+
+	ir, errs := ToIR(ingresses, i2gw.ProviderImplementationSpecificOptions{})
+	if len(errs) > 0 {
+		t.Fatalf("Expected no errors, got %+v", errs)
+	}
+	conf := &i2gw.ProviderConf{Notifications: notifications.NewNotificationAggregator()}
+
+	// The route without hostnames is served by the listeners of the TLS host.
+	keys := HTTPRouteKeys(&ir, "test", "nginx", "")
+	redirectKey, ok := RedirectToHTTPS(conf, "test-provider", &ir, keys, 301)
+	expectedRedirectKey := types.NamespacedName{Namespace: "test", Name: "a-all-hosts-https-redirect"}
+	if !ok || redirectKey != expectedRedirectKey {
+		t.Fatalf("Expected the redirect HTTPRoute %v, got %v, %t", expectedRedirectKey, redirectKey, ok)
+	}
+
+	expectedParentRefs := []gatewayv1.ParentReference{{Name: "nginx", SectionName: PtrTo[gatewayv1.SectionName]("secure-example-com-https")}}
+	if diff := cmp.Diff(expectedParentRefs, ir.HTTPRoutes[keys[0]].Spec.ParentRefs); diff != "" {
+		t.Errorf("Unexpected parentRefs of the redirected HTTPRoute (-want +got):\n%s", diff)
+	}
+	expectedRedirectParentRefs := []gatewayv1.ParentReference{{Name: "nginx", SectionName: PtrTo[gatewayv1.SectionName]("secure-example-com-http")}}
+	if diff := cmp.Diff(expectedRedirectParentRefs, ir.HTTPRoutes[redirectKey].Spec.ParentRefs); diff != "" {
+		t.Errorf("Unexpected parentRefs of the redirect HTTPRoute (-want +got):\n%s", diff)
+	}
+	if got := conf.Notifications.Notifications["test-provider"]; len(got) > 0 {
+		t.Errorf("Expected no notifications, got %+v", got)
+	}
+}
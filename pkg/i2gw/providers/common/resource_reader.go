@@ -22,8 +22,11 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"os"
 
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
+
+	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -51,15 +54,59 @@ func ReadIngressesFromCluster(ctx context.Context, client client.Client, ingress
 	return ingresses, nil
 }
 
-func ReadIngressesFromFile(filename, namespace string, ingressClasses sets.Set[string]) (map[types.NamespacedName]*networkingv1.Ingress, error) {
-	stream, err := os.ReadFile(filename)
+// ReadServicesFromCluster reads the Services of the namespaces of the
+// ingresses from the cluster, which resolve the named ports of their backends.
+// Only these namespaces are listed, so that users allowed to read the
+// Ingresses of a single namespace can read its Services too.
+func ReadServicesFromCluster(ctx context.Context, cl client.Client, ingresses map[types.NamespacedName]*networkingv1.Ingress) (map[types.NamespacedName]*corev1.Service, error) {
+	namespaces := sets.New[string]()
+	for key := range ingresses {
+		namespaces.Insert(key.Namespace)
+	}
+
+	services := map[types.NamespacedName]*corev1.Service{}
+	for _, namespace := range sets.List(namespaces) {
+		var serviceList corev1.ServiceList
+		if err := cl.List(ctx, &serviceList, client.InNamespace(namespace)); err != nil {
+			return nil, fmt.Errorf("failed to get services of namespace %s from the cluster: %w", namespace, err)
+		}
+		for i, service := range serviceList.Items {
+			services[types.NamespacedName{Namespace: service.Namespace, Name: service.Name}] = &serviceList.Items[i]
+		}
+	}
+	return services, nil
+}
+
+// ReadServicesFromFiles reads the Services in the namespace of conf from the
+// files. A Service defined in several files is read from the last one.
+func ReadServicesFromFiles(conf *i2gw.ProviderConf, filenames []string) (map[types.NamespacedName]*corev1.Service, error) {
+	unstructuredObjects, err := ReadObjectsFromFiles(conf, filenames)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read file %v: %w", filename, err)
+		return nil, err
+	}
+
+	services := map[types.NamespacedName]*corev1.Service{}
+	for _, f := range unstructuredObjects {
+		if f.GroupVersionKind() == ServiceGVK {
+			var service corev1.Service
+			err = runtime.DefaultUnstructuredConverter.
+				FromUnstructured(f.UnstructuredContent(), &service)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse Service from %s: %w", SourceFile(f), err)
+			}
+			services[types.NamespacedName{Namespace: service.Namespace, Name: service.Name}] = &service
+		}
 	}
+	return services, nil
+}
 
-	unstructuredObjects, err := ExtractObjectsFromReader(bytes.NewReader(stream), namespace)
+// ReadIngressesFromFiles reads the Ingresses of the given classes in the
+// namespace of conf from the files. An Ingress defined in several files is
+// read from the last one.
+func ReadIngressesFromFiles(conf *i2gw.ProviderConf, filenames []string, ingressClasses sets.Set[string]) (map[types.NamespacedName]*networkingv1.Ingress, error) {
+	unstructuredObjects, err := ReadObjectsFromFiles(conf, filenames)
 	if err != nil {
-		return nil, fmt.Errorf("failed to extract objects: %w", err)
+		return nil, err
 	}
 
 	ingresses := map[types.NamespacedName]*networkingv1.Ingress{}
@@ -69,7 +116,7 @@ func ReadIngressesFromFile(filename, namespace string, ingressClasses sets.Set[s
 			err = runtime.DefaultUnstructuredConverter.
 				FromUnstructured(f.UnstructuredContent(), &ingress)
 			if err != nil {
-				return nil, err
+				return nil, fmt.Errorf("failed to parse Ingress from %s: %w", SourceFile(f), err)
 			}
 			if !ingressClasses.Has(GetIngressClass(ingress)) {
 				continue
@@ -81,6 +128,45 @@ func ReadIngressesFromFile(filename, namespace string, ingressClasses sets.Set[s
 	return ingresses, nil
 }
 
+// ReadObjectsFromFiles reads all objects in the namespace of conf from the
+// files, see ExtractObjectsFromReader. The files are read with the
+// ReadInputFile method of conf. Every object is annotated with the path of
+// the file it was read from, so that it can be reported to the user.
+func ReadObjectsFromFiles(conf *i2gw.ProviderConf, filenames []string) ([]*unstructured.Unstructured, error) {
+	var namespace string
+	if conf != nil {
+		namespace = conf.Namespace
+	}
+	var objects []*unstructured.Unstructured
+	for _, filename := range filenames {
+		stream, err := conf.ReadInputFile(filename)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file %v: %w", i2gw.InputFileDisplayName(filename), err)
+		}
+
+		fileObjects, err := ExtractObjectsFromReader(bytes.NewReader(stream), namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract objects from %v: %w", i2gw.InputFileDisplayName(filename), err)
+		}
+		for _, obj := range fileObjects {
+			annotations := obj.GetAnnotations()
+			if annotations == nil {
+				annotations = make(map[string]string)
+			}
+			annotations[notifications.SourceFileAnnotationKey] = i2gw.InputFileDisplayName(filename)
+			obj.SetAnnotations(annotations)
+		}
+		objects = append(objects, fileObjects...)
+	}
+	return objects, nil
+}
+
+// SourceFile returns the path of the file obj was read from, or an empty
+// string if it was not read from a file.
+func SourceFile(obj client.Object) string {
+	return obj.GetAnnotations()[notifications.SourceFileAnnotationKey]
+}
+
 // ExtractObjectsFromReader extracts all objects from a reader,
 // which is created from YAML or JSON input files.
 // It retrieves all objects, including nested ones if they are contained within a list.
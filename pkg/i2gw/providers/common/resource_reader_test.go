@@ -18,16 +18,26 @@ package common
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 )
 
 func Test_ExtractObjectsFromReader(t *testing.T) {
@@ -78,6 +88,109 @@ func Test_ExtractObjectsFromReader(t *testing.T) {
 	}
 }
 
+func Test_ReadObjectsFromFiles(t *testing.T) {
+	filenames := []string{"testdata/input-file.yaml", "testdata/input-file.json"}
+	inputFiles, err := i2gw.ReadInputFiles(filenames, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf := &i2gw.ProviderConf{InputFiles: inputFiles}
+	objects, err := ReadObjectsFromFiles(conf, filenames)
+	if err != nil {
+		t.Fatalf("Expected no error but got %v", err)
+	}
+
+	var gotSourceFiles []string
+	for _, obj := range objects {
+		sourceFile := SourceFile(obj)
+		if len(gotSourceFiles) == 0 || gotSourceFiles[len(gotSourceFiles)-1] != sourceFile {
+			gotSourceFiles = append(gotSourceFiles, sourceFile)
+		}
+	}
+	if diff := cmp.Diff(filenames, gotSourceFiles); diff != "" {
+		t.Errorf("Unexpected source files (-want +got):\n%s", diff)
+	}
+
+	// Files which are not input files of the conf are not read from the file
+	// system.
+	_, err = ReadObjectsFromFiles(&i2gw.ProviderConf{}, []string{"testdata/input-file.yaml"})
+	if !errors.Is(err, fs.ErrNotExist) || !strings.Contains(err.Error(), "testdata/input-file.yaml") {
+		t.Errorf("Expected a not found error mentioning the file, got %v", err)
+	}
+}
+
+func Test_ReadServicesFromFiles(t *testing.T) {
+	conf := &i2gw.ProviderConf{
+		Namespace: "test",
+		InputFiles: map[string][]byte{"services.yaml": []byte(`apiVersion: v1
+kind: Service
+metadata:
+  name: foo
+  namespace: test
+spec:
+  ports:
+  - name: http
+    port: 8080
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: bar
+  namespace: other
+---
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: foo
+  namespace: test
+`)},
+	}
+	services, err := ReadServicesFromFiles(conf, []string{"services.yaml"})
+	if err != nil {
+		t.Fatalf("Expected no error but got %v", err)
+	}
+	if len(services) != 1 {
+		t.Fatalf("Expected 1 Service, got %v", services)
+	}
+	service := services[types.NamespacedName{Namespace: "test", Name: "foo"}]
+	if service == nil || len(service.Spec.Ports) != 1 || service.Spec.Ports[0].Port != 8080 {
+		t.Errorf("Expected Service test/foo with port 8080, got %+v", service)
+	}
+}
+
+func Test_ReadServicesFromCluster(t *testing.T) {
+	service := func(namespace, name string) *corev1.Service {
+		return &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	}
+	var listedNamespaces []string
+	cl := fake.NewClientBuilder().
+		WithObjects(service("test", "foo"), service("other", "bar")).
+		WithInterceptorFuncs(interceptor.Funcs{
+			List: func(ctx context.Context, cl client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				listOpts := &client.ListOptions{}
+				listOpts.ApplyOptions(opts)
+				listedNamespaces = append(listedNamespaces, listOpts.Namespace)
+				return cl.List(ctx, list, opts...)
+			},
+		}).
+		Build()
+	ingresses := map[types.NamespacedName]*networkingv1.Ingress{
+		{Namespace: "test", Name: "a"}: {},
+		{Namespace: "test", Name: "b"}: {},
+	}
+
+	services, err := ReadServicesFromCluster(context.Background(), cl, ingresses)
+	if err != nil {
+		t.Fatalf("Expected no error but got %v", err)
+	}
+	if diff := cmp.Diff([]string{"test"}, listedNamespaces); diff != "" {
+		t.Errorf("Unexpected namespaces listed (-want +got):\n%s", diff)
+	}
+	if len(services) != 1 || services[types.NamespacedName{Namespace: "test", Name: "foo"}] == nil {
+		t.Errorf("Expected Service test/foo only, got %v", services)
+	}
+}
+
 func ingress(port int32, name, namespace string) networkingv1.Ingress {
 	iPrefix := networkingv1.PathTypePrefix
 	ingressClassName := fmt.Sprintf("ingressClass-%s", name)
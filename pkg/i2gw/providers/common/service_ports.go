@@ -0,0 +1,78 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"fmt"
+
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ResolveServicePorts returns the ingresses with the named ports of their
+// Service backends replaced by the numbers of the ports of the Services, which
+// are looked up in the namespaces of the ingresses. The ingresses are copied,
+// and left unchanged. The ports which can not be resolved, as their Service or
+// its port is missing, are notified as warnings of provider, and kept named,
+// so that their backends are dropped, see ToBackendRef.
+func ResolveServicePorts(conf *i2gw.ProviderConf, provider i2gw.ProviderName, ingresses []networkingv1.Ingress, services map[types.NamespacedName]*corev1.Service) []networkingv1.Ingress {
+	resolved := make([]networkingv1.Ingress, 0, len(ingresses))
+	for _, ingress := range ingresses {
+		ingress := *ingress.DeepCopy()
+		resolve := func(backend *networkingv1.IngressBackend) {
+			if backend == nil || backend.Service == nil || backend.Service.Port.Name == "" {
+				return
+			}
+			port, err := resolveServicePort(services, ingress.Namespace, backend.Service)
+			if err != nil {
+				conf.Notify(provider, notifications.NewNotification(notifications.WarningNotification,
+					fmt.Sprintf("%v, so the backend is dropped", err), &ingress))
+				return
+			}
+			backend.Service.Port = networkingv1.ServiceBackendPort{Number: port}
+		}
+		resolve(ingress.Spec.DefaultBackend)
+		for i := range ingress.Spec.Rules {
+			if ingress.Spec.Rules[i].HTTP == nil {
+				continue
+			}
+			for j := range ingress.Spec.Rules[i].HTTP.Paths {
+				resolve(&ingress.Spec.Rules[i].HTTP.Paths[j].Backend)
+			}
+		}
+		resolved = append(resolved, ingress)
+	}
+	return resolved
+}
+
+// resolveServicePort returns the number of the port of the backend Service
+// in namespace, which is named.
+func resolveServicePort(services map[types.NamespacedName]*corev1.Service, namespace string, backend *networkingv1.IngressServiceBackend) (int32, error) {
+	service, ok := services[types.NamespacedName{Namespace: namespace, Name: backend.Name}]
+	if !ok {
+		return 0, fmt.Errorf("the port %q of Service %s/%s can not be resolved, as the Service was not found", backend.Port.Name, namespace, backend.Name)
+	}
+	for _, port := range service.Spec.Ports {
+		if port.Name == backend.Port.Name {
+			return port.Port, nil
+		}
+	}
+	return 0, fmt.Errorf("the Service %s/%s has no port named %q", namespace, backend.Name, backend.Port.Name)
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestResolveServicePorts(t *testing.T) {
+	services := map[types.NamespacedName]*corev1.Service{
+		{Namespace: "test", Name: "foo"}: {
+			ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "test"},
+			Spec: corev1.ServiceSpec{
+				Ports: []corev1.ServicePort{{Name: "http", Port: 8080}, {Name: "grpc", Port: 9090}},
+			},
+		},
+	}
+	backend := func(name string, port networkingv1.ServiceBackendPort) *networkingv1.IngressBackend {
+		return &networkingv1.IngressBackend{
+			Service: &networkingv1.IngressServiceBackend{Name: name, Port: port},
+		}
+	}
+	ingress := func(defaultBackend, pathBackend *networkingv1.IngressBackend) networkingv1.Ingress {
+		return networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: "ingress", Namespace: "test"},
+			Spec: networkingv1.IngressSpec{
+				DefaultBackend: defaultBackend,
+				Rules: []networkingv1.IngressRule{{
+					Host: "example.com",
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{
+							Paths: []networkingv1.HTTPIngressPath{{
+								Path:     "/",
+								PathType: PtrTo(networkingv1.PathTypePrefix),
+								Backend:  *pathBackend,
+							}},
+						},
+					},
+				}},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name                  string
+		ingress               networkingv1.Ingress
+		expectedIngress       networkingv1.Ingress
+		expectedNotifications []string
+	}{
+		{
+			name:            "port numbers are kept",
+			ingress:         ingress(nil, backend("foo", networkingv1.ServiceBackendPort{Number: 80})),
+			expectedIngress: ingress(nil, backend("foo", networkingv1.ServiceBackendPort{Number: 80})),
+		},
+		{
+			name: "named ports are resolved",
+			ingress: ingress(
+				backend("foo", networkingv1.ServiceBackendPort{Name: "grpc"}),
+				backend("foo", networkingv1.ServiceBackendPort{Name: "http"}),
+			),
+			expectedIngress: ingress(
+				backend("foo", networkingv1.ServiceBackendPort{Number: 9090}),
+				backend("foo", networkingv1.ServiceBackendPort{Number: 8080}),
+			),
+		},
+		{
+			name:                  "missing Service",
+			ingress:               ingress(nil, backend("bar", networkingv1.ServiceBackendPort{Name: "http"})),
+			expectedIngress:       ingress(nil, backend("bar", networkingv1.ServiceBackendPort{Name: "http"})),
+			expectedNotifications: []string{`the port "http" of Service test/bar can not be resolved, as the Service was not found`},
+		},
+		{
+			name:                  "missing port",
+			ingress:               ingress(nil, backend("foo", networkingv1.ServiceBackendPort{Name: "https"})),
+			expectedIngress:       ingress(nil, backend("foo", networkingv1.ServiceBackendPort{Name: "https"})),
+			expectedNotifications: []string{`the Service test/foo has no port named "https"`},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			conf := &i2gw.ProviderConf{Notifications: notifications.NewNotificationAggregator()}
+			original := tc.ingress.DeepCopy()
+
+			resolved := ResolveServicePorts(conf, "test-provider", []networkingv1.Ingress{tc.ingress}, services)
+
+			if diff := cmp.Diff([]networkingv1.Ingress{tc.expectedIngress}, resolved); diff != "" {
+				t.Errorf("Unexpected Ingresses (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(*original, tc.ingress); diff != "" {
+				t.Errorf("Expected the Ingress to be unchanged (-want +got):\n%s", diff)
+			}
+			// The backends whose named port can not be resolved are dropped,
+			// as a backendRef to a Service requires a port, and the paths
+			// are still converted.
+			ir, errs := ToIR(resolved, i2gw.ProviderImplementationSpecificOptions{})
+			if len(errs) != 0 {
+				t.Errorf("Expected no conversion errors, got %+v", errs)
+			}
+			route := ir.HTTPRoutes[types.NamespacedName{Namespace: "test", Name: "ingress-example-com"}].HTTPRoute
+			if len(route.Spec.Rules) != 1 {
+				t.Fatalf("Expected 1 rule, got %+v", route.Spec.Rules)
+			}
+			if backendRefs := route.Spec.Rules[0].BackendRefs; (len(backendRefs) == 0) != (len(tc.expectedNotifications) > 0) {
+				t.Errorf("Expected the backendRef to be dropped only if its port is not resolved, got %+v", backendRefs)
+			}
+			got := conf.Notifications.Notifications["test-provider"]
+			if len(got) != len(tc.expectedNotifications) {
+				t.Fatalf("Expected %d notifications, got %+v", len(tc.expectedNotifications), got)
+			}
+			for i, message := range tc.expectedNotifications {
+				if got[i].Type != notifications.WarningNotification || !strings.HasPrefix(got[i].Message, message) {
+					t.Errorf("Expected a warning starting with %q, got %+v", message, got[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/intermediate"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// tlsForHost returns the TLS configurations of an Ingress which cover the host
+// of one of its rules, so that the HTTPS listener of every host gets the
+// certificates of its host only. A TLS configuration without hosts covers all
+// the hosts. The rules without host keep all the TLS configurations, as they
+// get a listener for every host of them, see ingressRuleGroup.listeners.
+func tlsForHost(tlsConfigs []networkingv1.IngressTLS, host string) []networkingv1.IngressTLS {
+	if host == "" {
+		return tlsConfigs
+	}
+	var covering []networkingv1.IngressTLS
+	for _, tls := range tlsConfigs {
+		if len(tls.Hosts) == 0 || slices.ContainsFunc(tls.Hosts, func(tlsHost string) bool { return TLSHostCovers(tlsHost, host) }) {
+			covering = append(covering, tls)
+		}
+	}
+	return covering
+}
+
+// TLSHostCovers returns whether the certificate of the TLS host tlsHost covers
+// host, i.e. whether they are equal or tlsHost is a wildcard host, e.g.
+// *.example.com, matching the first label of host, e.g. foo.example.com.
+func TLSHostCovers(tlsHost, host string) bool {
+	if strings.EqualFold(tlsHost, host) {
+		return true
+	}
+	suffix, ok := strings.CutPrefix(tlsHost, "*")
+	if !ok || !strings.HasPrefix(suffix, ".") {
+		return false
+	}
+	label, rest, ok := strings.Cut(host, ".")
+	return ok && label != "" && label != "*" && strings.EqualFold("."+rest, suffix)
+}
+
+// NotifyUnusedTLSHosts notifies as warnings of provider the TLS hosts of the
+// ingresses which get no HTTPS listener with their certificate in the Gateways
+// of ir, i.e. no listener of a hostname they cover, and are not converted. The
+// ingresses without Gateway in ir are not converted at all and are skipped.
+func NotifyUnusedTLSHosts(conf *i2gw.ProviderConf, provider i2gw.ProviderName, ingresses []networkingv1.Ingress, ir *intermediate.IR) {
+	for i := range ingresses {
+		ingress := &ingresses[i]
+		gatewayContext, ok := ir.Gateways[types.NamespacedName{Namespace: ingress.Namespace, Name: GetIngressClass(*ingress)}]
+		if !ok {
+			continue
+		}
+		for j, tls := range ingress.Spec.TLS {
+			certificateRef := gatewayv1.SecretObjectReference{Name: gatewayv1.ObjectName(tls.SecretName)}
+			for _, tlsHost := range tls.Hosts {
+				used := slices.ContainsFunc(gatewayContext.Spec.Listeners, func(listener gatewayv1.Listener) bool {
+					return listener.Hostname != nil && listener.TLS != nil &&
+						TLSHostCovers(tlsHost, string(*listener.Hostname)) &&
+						slices.Contains(listener.TLS.CertificateRefs, certificateRef)
+				})
+				if !used {
+					conf.Notify(provider, notifications.NewNotification(notifications.WarningNotification,
+						fmt.Sprintf("the TLS host %q of spec.tls[%d] gets no HTTPS listener, its certificate %q is not converted for it", tlsHost, j, tls.SecretName), ingress))
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestTLSHostCovers(t *testing.T) {
+	testCases := []struct {
+		tlsHost  string
+		host     string
+		expected bool
+	}{
+		{tlsHost: "example.com", host: "example.com", expected: true},
+		{tlsHost: "Example.com", host: "example.com", expected: true},
+		{tlsHost: "example.com", host: "foo.example.com", expected: false},
+		{tlsHost: "*.example.com", host: "foo.example.com", expected: true},
+		{tlsHost: "*.example.com", host: "*.example.com", expected: true},
+		{tlsHost: "*.example.com", host: "example.com", expected: false},
+		{tlsHost: "*.example.com", host: "foo.bar.example.com", expected: false},
+		{tlsHost: "*example.com", host: "fooexample.com", expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tlsHost+" "+tc.host, func(t *testing.T) {
+			if got := TLSHostCovers(tc.tlsHost, tc.host); got != tc.expected {
+				t.Errorf("Expected %t, got %t", tc.expected, got)
+			}
+		})
+	}
+}
+
+func Test_ToIR_tlsListeners(t *testing.T) {
+	iPrefix := networkingv1.PathTypePrefix
+	rule := func(host string) networkingv1.IngressRule {
+		return networkingv1.IngressRule{
+			Host: host,
+			IngressRuleValue: networkingv1.IngressRuleValue{
+				HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: []networkingv1.HTTPIngressPath{{
+						Path:     "/",
+						PathType: &iPrefix,
+						Backend: networkingv1.IngressBackend{
+							Service: &networkingv1.IngressServiceBackend{
+								Name: "test",
+								Port: networkingv1.ServiceBackendPort{Number: 80},
+							},
+						},
+					}},
+				},
+			},
+		}
+	}
+	ingresses := []networkingv1.Ingress{{
+		ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "test"},
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: PtrTo("nginx"),
+			TLS: []networkingv1.IngressTLS{
+				{Hosts: []string{"*.example.com"}, SecretName: "wildcard"},
+				{Hosts: []string{"example.net"}, SecretName: "net"},
+				{Hosts: []string{"unused.org"}, SecretName: "unused"},
+			},
+			Rules: []networkingv1.IngressRule{rule("foo.example.com"), rule("example.net"), rule("plain.org")},
+		},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "test"},
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: PtrTo("nginx"),
+			TLS:              []networkingv1.IngressTLS{{Hosts: []string{"*.example.com"}, SecretName: "wildcard"}},
+			Rules:            []networkingv1.IngressRule{rule("bar.example.com"), rule("foo.example.com")},
+		},
+	}}
+
+	ir, errs := ToIR(ingresses, i2gw.ProviderImplementationSpecificOptions{})
+	if len(errs) > 0 {
+		t.Fatalf("Expected no errors, got %+v", errs)
+	}
+
+	certificateRefs := map[gatewayv1.SectionName][]gatewayv1.SecretObjectReference{}
+	for _, listener := range ir.Gateways[types.NamespacedName{Namespace: "test", Name: "nginx"}].Spec.Listeners {
+		if listener.TLS != nil {
+			certificateRefs[listener.Name] = listener.TLS.CertificateRefs
+		}
+	}
+	expectedCertificateRefs := map[gatewayv1.SectionName][]gatewayv1.SecretObjectReference{
+		"bar-example-com-https": {{Name: "wildcard"}},
+		"foo-example-com-https": {{Name: "wildcard"}},
+		"example-net-https":     {{Name: "net"}},
+	}
+	if diff := cmp.Diff(expectedCertificateRefs, certificateRefs); diff != "" {
+		t.Errorf("Unexpected certificates of the HTTPS listeners (-want +got):\n%s", diff)
+	}
+
+	conf := &i2gw.ProviderConf{Notifications: notifications.NewNotificationAggregator()}
+	NotifyUnusedTLSHosts(conf, "test-provider", ingresses, &ir)
+	got := conf.Notifications.Notifications["test-provider"]
+	expectedMessage := `the TLS host "unused.org" of spec.tls[2] gets no HTTPS listener, its certificate "unused" is not converted for it`
+	if len(got) != 1 || got[0].Type != notifications.WarningNotification || got[0].Message != expectedMessage {
+		t.Errorf("Expected a warning %q, got %+v", expectedMessage, got)
+	}
+}
+
+func Test_ToIR_hostlessTLSListeners(t *testing.T) {
+	iPrefix := networkingv1.PathTypePrefix
+	rule := func(host string) networkingv1.IngressRule {
+		return networkingv1.IngressRule{
+			Host: host,
+			IngressRuleValue: networkingv1.IngressRuleValue{
+				HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: []networkingv1.HTTPIngressPath{{
+						Path:     "/",
+						PathType: &iPrefix,
+						Backend: networkingv1.IngressBackend{
+							Service: &networkingv1.IngressServiceBackend{
+								Name: "test",
+								Port: networkingv1.ServiceBackendPort{Number: 80},
+							},
+						},
+					}},
+				},
+			},
+		}
+	}
+	ingresses := []networkingv1.Ingress{{
+		ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "test"},
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: PtrTo("nginx"),
+			TLS: []networkingv1.IngressTLS{
+				{Hosts: []string{"a.com"}, SecretName: "a"},
+				{Hosts: []string{"b.com", "d.com"}, SecretName: "b"},
+				{Hosts: []string{"d.com"}, SecretName: "d"},
+			},
+			Rules: []networkingv1.IngressRule{rule("")},
+		},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{Name: "c", Namespace: "test"},
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: PtrTo("nginx"),
+			TLS:              []networkingv1.IngressTLS{{Hosts: []string{"c.com"}, SecretName: "c"}},
+			Rules:            []networkingv1.IngressRule{rule("b.com")},
+		},
+	}}
+
+	ir, errs := ToIR(ingresses, i2gw.ProviderImplementationSpecificOptions{})
+	if len(errs) > 0 {
+		t.Fatalf("Expected no errors, got %+v", errs)
+	}
+
+	certificateRefs := map[gatewayv1.SectionName][]gatewayv1.SecretObjectReference{}
+	for _, listener := range ir.Gateways[types.NamespacedName{Namespace: "test", Name: "nginx"}].Spec.Listeners {
+		if listener.TLS != nil {
+			certificateRefs[listener.Name] = listener.TLS.CertificateRefs
+		}
+	}
+	// b.com has its own rule, whose listener does not get the certificate of
+	// the other Ingress.
+	expectedCertificateRefs := map[gatewayv1.SectionName][]gatewayv1.SecretObjectReference{
+		"a-com-https": {{Name: "a"}},
+		"d-com-https": {{Name: "b"}, {Name: "d"}},
+	}
+	if diff := cmp.Diff(expectedCertificateRefs, certificateRefs); diff != "" {
+		t.Errorf("Unexpected certificates of the HTTPS listeners (-want +got):\n%s", diff)
+	}
+
+	conf := &i2gw.ProviderConf{Notifications: notifications.NewNotificationAggregator()}
+	NotifyUnusedTLSHosts(conf, "test-provider", ingresses, &ir)
+	var messages []string
+	for _, notification := range conf.Notifications.Notifications["test-provider"] {
+		messages = append(messages, notification.Message)
+	}
+	expectedMessages := []string{
+		`the TLS host "b.com" of spec.tls[1] gets no HTTPS listener, its certificate "b" is not converted for it`,
+		`the TLS host "c.com" of spec.tls[0] gets no HTTPS listener, its certificate "c" is not converted for it`,
+	}
+	if diff := cmp.Diff(expectedMessages, messages); diff != "" {
+		t.Errorf("Unexpected notifications (-want +got):\n%s", diff)
+	}
+}
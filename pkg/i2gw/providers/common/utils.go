@@ -18,11 +18,13 @@ package common
 
 import (
 	"fmt"
-	"regexp"
+	"strconv"
 
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/intermediate"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/naming"
 	networkingv1 "k8s.io/api/networking/v1"
 	networkingv1beta1 "k8s.io/api/networking/v1beta1"
-	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apimachinery/pkg/types"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
@@ -87,36 +89,65 @@ func GetRuleGroups(ingresses []networkingv1.Ingress) map[string]IngressRuleGroup
 	return ruleGroups
 }
 
+// NameFromHost returns the name of host, see naming.Host.
 func NameFromHost(host string) string {
-	// replace all special chars with -
-	reg, _ := regexp.Compile("[^a-zA-Z0-9]+")
-	step1 := reg.ReplaceAllString(host, "-")
-	// remove all - at start of string
-	reg2, _ := regexp.Compile("^[^a-zA-Z0-9]+")
-	step2 := reg2.ReplaceAllString(step1, "")
-	// if nothing left, return "all-hosts"
-	if len(host) == 0 || host == "*" {
-		return "all-hosts"
-	}
-	return step2
+	return naming.Host(host)
 }
 
+// RouteName returns the name of the routes of the host of the Ingress named
+// ingressName. The names of the routes of a namespace may collide, in which
+// case the converters add a hash suffix of routeNameInput to them.
 func RouteName(ingressName, host string) string {
-	return fmt.Sprintf("%s-%s", ingressName, NameFromHost(host))
+	return naming.Name(ingressName, NameFromHost(host))
+}
+
+// routeNameInput returns the input the names of the routes of the host of the
+// Ingress named ingressName are told apart by when they collide.
+func routeNameInput(ingressName, host string) string {
+	return ingressName + "/" + host
 }
 
-func ToBackendRef(ib networkingv1.IngressBackend, path *field.Path) (*gatewayv1.BackendRef, *field.Error) {
+// MaxHTTPRouteRules is the maximum number of rules an HTTPRoute can have. The
+// rules of a host beyond it are split across several HTTPRoutes, see
+// HTTPRouteKeys.
+const MaxHTTPRouteRules = 16
+
+// routePartName returns the name of the part-th of the parts HTTPRoutes the
+// rules of the route named name are split into. The first part keeps the
+// name, and the others are numbered from 2, padded so that the routes sort by
+// name in the order of their rules, as Gateway API breaks the precedence ties
+// between the rules of different routes by name.
+func routePartName(name string, part, parts int) string {
+	if part == 0 {
+		return name
+	}
+	return naming.Name(name, fmt.Sprintf("%0*d", len(strconv.Itoa(parts)), part+1))
+}
+
+// HTTPRouteKeys returns the keys of the HTTPRoutes generated for the rules of
+// the Ingresses of namespace and ingressClass with the given host, in the
+// order of their rules, as recorded by ToIR in ir: the route named after the
+// first of the Ingresses, followed by the routes its rules beyond
+// MaxHTTPRouteRules were split into. It returns nil if no route was generated.
+func HTTPRouteKeys(ir *intermediate.IR, namespace, ingressClass, host string) []types.NamespacedName {
+	return ir.RuleGroupHTTPRoutes[intermediate.RuleGroupKey{Namespace: namespace, IngressClass: ingressClass, Host: host}]
+}
+
+// ToBackendRef returns the BackendRef of the Ingress backend. The named ports
+// of Services are resolved beforehand by ResolveServicePorts, and the backends
+// of the ones it could not resolve, which it notified, are dropped: a
+// BackendRef to a Service requires a port number, so nil is returned for them.
+func ToBackendRef(ib networkingv1.IngressBackend) *gatewayv1.BackendRef {
 	if ib.Service != nil {
 		if ib.Service.Port.Name != "" {
-			fieldPath := path.Child("service", "port")
-			return nil, field.Invalid(fieldPath, "name", fmt.Sprintf("named ports not supported: %s", ib.Service.Port.Name))
+			return nil
 		}
 		return &gatewayv1.BackendRef{
 			BackendObjectReference: gatewayv1.BackendObjectReference{
 				Name: gatewayv1.ObjectName(ib.Service.Name),
 				Port: (*gatewayv1.PortNumber)(&ib.Service.Port.Number),
 			},
-		}, nil
+		}
 	}
 	return &gatewayv1.BackendRef{
 		BackendObjectReference: gatewayv1.BackendObjectReference{
@@ -124,7 +155,7 @@ func ToBackendRef(ib networkingv1.IngressBackend, path *field.Path) (*gatewayv1.
 			Kind:  (*gatewayv1.Kind)(&ib.Resource.Kind),
 			Name:  gatewayv1.ObjectName(ib.Resource.Name),
 		},
-	}, nil
+	}
 }
 
 type orderedIngressPathsByMatchKey struct {
@@ -140,7 +171,13 @@ func groupIngressPathsByMatchKey(rules []ingressRule) orderedIngressPathsByMatch
 	}
 
 	for i, ir := range rules {
+		if ir.rule.HTTP == nil {
+			continue
+		}
 		for j, path := range ir.rule.HTTP.Paths {
+			if ir.invalidPaths[j] {
+				continue
+			}
 			ip := ingressPath{ruleIdx: i, pathIdx: j, ruleType: "http", path: path}
 			pmKey := getPathMatchKey(ip)
 			if _, ok := ingressPathsByMatchKey.data[pmKey]; !ok {
@@ -43,7 +43,7 @@ func TestGroupIngressPathsByMatchKey(t *testing.T) {
 			name: "1 rule with 1 match",
 			rules: []ingressRule{
 				{
-					networkingv1.IngressRule{
+					rule: networkingv1.IngressRule{
 						IngressRuleValue: networkingv1.IngressRuleValue{
 							HTTP: &networkingv1.HTTPIngressRuleValue{
 								Paths: []networkingv1.HTTPIngressPath{
@@ -96,7 +96,7 @@ func TestGroupIngressPathsByMatchKey(t *testing.T) {
 			name: "1 rule, multiple matches, different path",
 			rules: []ingressRule{
 				{
-					networkingv1.IngressRule{
+					rule: networkingv1.IngressRule{
 						IngressRuleValue: networkingv1.IngressRuleValue{
 							HTTP: &networkingv1.HTTPIngressRuleValue{
 								Paths: []networkingv1.HTTPIngressPath{
@@ -181,7 +181,7 @@ func TestGroupIngressPathsByMatchKey(t *testing.T) {
 			name: "multiple rules with single matches, same path",
 			rules: []ingressRule{
 				{
-					networkingv1.IngressRule{
+					rule: networkingv1.IngressRule{
 						IngressRuleValue: networkingv1.IngressRuleValue{
 							HTTP: &networkingv1.HTTPIngressRuleValue{
 								Paths: []networkingv1.HTTPIngressPath{
@@ -203,7 +203,7 @@ func TestGroupIngressPathsByMatchKey(t *testing.T) {
 					},
 				},
 				{
-					networkingv1.IngressRule{
+					rule: networkingv1.IngressRule{
 						IngressRuleValue: networkingv1.IngressRuleValue{
 							HTTP: &networkingv1.HTTPIngressRuleValue{
 								Paths: []networkingv1.HTTPIngressPath{
@@ -273,7 +273,7 @@ func TestGroupIngressPathsByMatchKey(t *testing.T) {
 			name: "multiple rules with single matches, different path",
 			rules: []ingressRule{
 				{
-					networkingv1.IngressRule{
+					rule: networkingv1.IngressRule{
 						IngressRuleValue: networkingv1.IngressRuleValue{
 							HTTP: &networkingv1.HTTPIngressRuleValue{
 								Paths: []networkingv1.HTTPIngressPath{
@@ -295,7 +295,7 @@ func TestGroupIngressPathsByMatchKey(t *testing.T) {
 					},
 				},
 				{
-					networkingv1.IngressRule{
+					rule: networkingv1.IngressRule{
 						IngressRuleValue: networkingv1.IngressRuleValue{
 							HTTP: &networkingv1.HTTPIngressRuleValue{
 								Paths: []networkingv1.HTTPIngressPath{
@@ -368,7 +368,7 @@ func TestGroupIngressPathsByMatchKey(t *testing.T) {
 			name: "multiple rules with multiple matches, mixed paths",
 			rules: []ingressRule{
 				{
-					networkingv1.IngressRule{
+					rule: networkingv1.IngressRule{
 						IngressRuleValue: networkingv1.IngressRuleValue{
 							HTTP: &networkingv1.HTTPIngressRuleValue{
 								Paths: []networkingv1.HTTPIngressPath{
@@ -402,7 +402,7 @@ func TestGroupIngressPathsByMatchKey(t *testing.T) {
 					},
 				},
 				{
-					networkingv1.IngressRule{
+					rule: networkingv1.IngressRule{
 						IngressRuleValue: networkingv1.IngressRuleValue{
 							HTTP: &networkingv1.HTTPIngressRuleValue{
 								Paths: []networkingv1.HTTPIngressPath{
@@ -530,3 +530,23 @@ func TestGroupIngressPathsByMatchKey(t *testing.T) {
 		})
 	}
 }
+
+func TestRoutePartName(t *testing.T) {
+	testCases := []struct {
+		name     string
+		part     int
+		parts    int
+		expected string
+	}{
+		{name: "first part", part: 0, parts: 3, expected: "foo"},
+		{name: "second part", part: 1, parts: 3, expected: "foo-2"},
+		{name: "padded part", part: 1, parts: 12, expected: "foo-02"},
+		{name: "last padded part", part: 11, parts: 12, expected: "foo-12"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, routePartName("foo", tc.part, tc.parts))
+		})
+	}
+}
@@ -17,6 +17,9 @@ limitations under the License.
 package gce
 
 import (
+	"cmp"
+	"slices"
+
 	gkegatewayv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/intermediate"
@@ -24,17 +27,20 @@ import (
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/providers/common"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/providers/gce/extensions"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 )
 
-type irToGatewayResourcesConverter struct{}
+type irToGatewayResourcesConverter struct {
+	conf *i2gw.ProviderConf
+}
 
 // newIRToGatewayResourcesConverter returns an gce irToGatewayResourcesConverter instance.
-func newIRToGatewayResourcesConverter() irToGatewayResourcesConverter {
-	return irToGatewayResourcesConverter{}
+func newIRToGatewayResourcesConverter(conf *i2gw.ProviderConf) irToGatewayResourcesConverter {
+	return irToGatewayResourcesConverter{conf: conf}
 }
 
 func (c *irToGatewayResourcesConverter) irToGateway(ir intermediate.IR) (i2gw.GatewayResources, field.ErrorList) {
@@ -42,12 +48,21 @@ func (c *irToGatewayResourcesConverter) irToGateway(ir intermediate.IR) (i2gw.Ga
 	if len(errs) != 0 {
 		return i2gw.GatewayResources{}, errs
 	}
-	buildGceGatewayExtensions(ir, &gatewayResources)
-	buildGceServiceExtensions(ir, &gatewayResources)
+	buildGceGatewayExtensions(c.conf, ir, &gatewayResources)
+	buildGceServiceExtensions(c.conf, ir, &gatewayResources)
+	// The extensions are built from maps, sort them so that they are returned
+	// in the same order on every run.
+	slices.SortFunc(gatewayResources.GatewayExtensions, func(a, b unstructured.Unstructured) int {
+		return cmp.Or(
+			cmp.Compare(a.GetKind(), b.GetKind()),
+			cmp.Compare(a.GetNamespace(), b.GetNamespace()),
+			cmp.Compare(a.GetName(), b.GetName()),
+		)
+	})
 	return gatewayResources, nil
 }
 
-func buildGceGatewayExtensions(ir intermediate.IR, gatewayResources *i2gw.GatewayResources) {
+func buildGceGatewayExtensions(conf *i2gw.ProviderConf, ir intermediate.IR, gatewayResources *i2gw.GatewayResources) {
 	for gwyKey, gatewayContext := range ir.Gateways {
 		gwyPolicy := addGatewayPolicyIfConfigured(gwyKey, gatewayContext.ProviderSpecificIR)
 		if gwyPolicy == nil {
@@ -55,7 +70,7 @@ func buildGceGatewayExtensions(ir intermediate.IR, gatewayResources *i2gw.Gatewa
 		}
 		obj, err := i2gw.CastToUnstructured(gwyPolicy)
 		if err != nil {
-			notify(notifications.ErrorNotification, "Failed to cast GCPGatewayPolicy to unstructured", gwyPolicy)
+			notify(conf, notifications.ErrorNotification, "Failed to cast GCPGatewayPolicy to unstructured", gwyPolicy)
 			continue
 		}
 		gatewayResources.GatewayExtensions = append(gatewayResources.GatewayExtensions, *obj)
@@ -91,13 +106,13 @@ func addGatewayPolicyIfConfigured(gatewayNamespacedName types.NamespacedName, ga
 	return &gcpGatewayPolicy
 }
 
-func buildGceServiceExtensions(ir intermediate.IR, gatewayResources *i2gw.GatewayResources) {
+func buildGceServiceExtensions(conf *i2gw.ProviderConf, ir intermediate.IR, gatewayResources *i2gw.GatewayResources) {
 	for svcKey, serviceIR := range ir.Services {
 		bePolicy := addGCPBackendPolicyIfConfigured(svcKey, serviceIR)
 		if bePolicy != nil {
 			obj, err := i2gw.CastToUnstructured(bePolicy)
 			if err != nil {
-				notify(notifications.ErrorNotification, "Failed to cast GCPBackendPolicy to unstructured", bePolicy)
+				notify(conf, notifications.ErrorNotification, "Failed to cast GCPBackendPolicy to unstructured", bePolicy)
 				continue
 			}
 			gatewayResources.GatewayExtensions = append(gatewayResources.GatewayExtensions, *obj)
@@ -107,7 +122,7 @@ func buildGceServiceExtensions(ir intermediate.IR, gatewayResources *i2gw.Gatewa
 		if hcPolicy != nil {
 			obj, err := i2gw.CastToUnstructured(hcPolicy)
 			if err != nil {
-				notify(notifications.ErrorNotification, "Failed to cast HealthCheckPolicy to unstructured", hcPolicy)
+				notify(conf, notifications.ErrorNotification, "Failed to cast HealthCheckPolicy to unstructured", hcPolicy)
 				continue
 			}
 			gatewayResources.GatewayExtensions = append(gatewayResources.GatewayExtensions, *obj)
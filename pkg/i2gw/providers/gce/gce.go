@@ -23,6 +23,8 @@ import (
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/intermediate"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/providers/common"
+	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
 	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
@@ -32,6 +34,18 @@ const ProviderName = "gce"
 
 func init() {
 	i2gw.ProviderConstructorByName[ProviderName] = NewProvider
+	i2gw.RegisterProviderResourceKinds(ProviderName,
+		common.IngressGVK,
+		apiv1.SchemeGroupVersion.WithKind("Service"),
+		backendconfigv1.SchemeGroupVersion.WithKind("BackendConfig"),
+		frontendconfigv1beta1.SchemeGroupVersion.WithKind("FrontendConfig"),
+	)
+
+	// The BackendConfig annotations are read from Services, not Ingresses.
+	i2gw.RegisterProviderAnnotations(ProviderName, i2gw.ProviderAnnotations{
+		Prefixes: []string{"cloud.google.com/", "networking.gke.io/"},
+		Keys:     []string{frontendConfigKey},
+	})
 }
 
 // Provider implements the i2gw.Provider interface.
@@ -47,17 +61,17 @@ func NewProvider(conf *i2gw.ProviderConf) i2gw.Provider {
 	// so these resources can be recognized.
 	if conf.Client != nil {
 		if err := backendconfigv1.AddToScheme(conf.Client.Scheme()); err != nil {
-			notify(notifications.ErrorNotification, "Failed to add v1 BackendConfig Scheme")
+			notify(conf, notifications.ErrorNotification, "Failed to add v1 BackendConfig Scheme")
 		}
 		if err := frontendconfigv1beta1.AddToScheme(conf.Client.Scheme()); err != nil {
-			notify(notifications.ErrorNotification, "Failed to add v1beta1 FrontendConfig Scheme")
+			notify(conf, notifications.ErrorNotification, "Failed to add v1beta1 FrontendConfig Scheme")
 		}
 	}
 	return &Provider{
 		storage:          newResourcesStorage(),
 		reader:           newResourceReader(conf),
 		irConverter:      newResourcesToIRConverter(conf),
-		gatewayConverter: newIRToGatewayResourcesConverter(),
+		gatewayConverter: newIRToGatewayResourcesConverter(conf),
 	}
 }
 
@@ -71,8 +85,8 @@ func (p *Provider) ReadResourcesFromCluster(ctx context.Context) error {
 	return nil
 }
 
-func (p *Provider) ReadResourcesFromFile(_ context.Context, filename string) error {
-	storage, err := p.reader.readResourcesFromFile(filename)
+func (p *Provider) ReadResourcesFromFiles(_ context.Context, filenames []string) error {
+	storage, err := p.reader.readResourcesFromFiles(filenames)
 	if err != nil {
 		return fmt.Errorf("failed to read gce resources from file: %w", err)
 	}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/providers/common"
 	"k8s.io/klog/v2"
@@ -39,7 +40,7 @@ import (
 // | /v1                                   | /v1 Exact                              |
 // | /v1/                                  | /v1/ Exact                             |
 // | /v1/*                                 | /v1 Prefix                             |
-func implementationSpecificHTTPPathTypeMatch(path *gatewayv1.HTTPPathMatch) {
+func implementationSpecificHTTPPathTypeMatch(conf *i2gw.ProviderConf, path *gatewayv1.HTTPPathMatch) {
 	pmExact := gatewayv1.PathMatchExact
 	pmPrefix := gatewayv1.PathMatchPathPrefix
 
@@ -56,6 +57,6 @@ func implementationSpecificHTTPPathTypeMatch(path *gatewayv1.HTTPPathMatch) {
 	currentValue := *path.Value
 	path.Type = &pmPrefix
 	path.Value = common.PtrTo(strings.TrimSuffix(*path.Value, "/*"))
-	notify(notifications.WarningNotification, fmt.Sprintf("After conversion, ImplementationSpecific Path %s/* will additionally map to %s. See https://github.com/kubernetes-sigs/ingress2gateway/blob/main/pkg/i2gw/providers/gce/README.md for details.", currentValue, *path.Value))
+	notify(conf, notifications.WarningNotification, fmt.Sprintf("After conversion, ImplementationSpecific Path %s/* will additionally map to %s. See https://github.com/kubernetes-sigs/ingress2gateway/blob/main/pkg/i2gw/providers/gce/README.md for details.", currentValue, *path.Value))
 	klog.Warningf("After conversion, ImplementationSpecific Path %s/* will additionally map to %s. See https://github.com/kubernetes-sigs/ingress2gateway/blob/main/pkg/i2gw/providers/gce/README.md for details.", currentValue, *path.Value)
 }
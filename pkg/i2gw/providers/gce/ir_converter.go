@@ -18,6 +18,8 @@ package gce
 
 import (
 	"context"
+	"fmt"
+	"slices"
 
 	"encoding/json"
 
@@ -33,6 +35,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
 	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
 type contextKey int
@@ -54,7 +57,9 @@ func newResourcesToIRConverter(conf *i2gw.ProviderConf) resourcesToIRConverter {
 	return resourcesToIRConverter{
 		conf: conf,
 		implementationSpecificOptions: i2gw.ProviderImplementationSpecificOptions{
-			ToImplementationSpecificHTTPPathTypeMatch: implementationSpecificHTTPPathTypeMatch,
+			ToImplementationSpecificHTTPPathTypeMatch: func(path *gatewayv1.HTTPPathMatch) {
+				implementationSpecificHTTPPathTypeMatch(conf, path)
+			},
 		},
 		ctx: context.Background(),
 	}
@@ -71,24 +76,28 @@ func (c *resourcesToIRConverter) convertToIR(storage *storage) (intermediate.IR,
 		}
 		ingressList = append(ingressList, *ing)
 	}
+	ingressList = common.ResolveServicePorts(c.conf, ProviderName, ingressList, storage.Services)
+	common.NotifyDefaultBackendConflicts(c.conf, ProviderName, ingressList)
 
 	// Convert plain ingress resources to gateway resources, ignoring all
 	// provider-specific features.
 	ir, errs := common.ToIR(ingressList, c.implementationSpecificOptions)
-	if len(errs) > 0 {
+	if len(errs) > 0 && !c.conf.BestEffort {
 		return intermediate.IR{}, errs
 	}
+	common.NotifyUnusedTLSHosts(c.conf, ProviderName, ingressList, &ir)
+	c.conf.TraceIR(ProviderName, i2gw.TraceStageCommonToIR, ir)
 
-	errs = setGCEGatewayClasses(ingressList, ir.Gateways)
-	if len(errs) > 0 {
-		return intermediate.IR{}, errs
+	if gatewayClassErrs := setGCEGatewayClasses(ingressList, ir.Gateways); len(gatewayClassErrs) > 0 {
+		return intermediate.IR{}, append(errs, gatewayClassErrs...)
 	}
-	buildGceGatewayIR(c.ctx, storage, &ir)
-	buildGceServiceIR(c.ctx, storage, &ir)
+	buildGceGatewayIR(c.ctx, c.conf, storage, &ir)
+	redirectToHTTPS(c.conf, ingressList, &ir)
+	buildGceServiceIR(c.ctx, c.conf, storage, &ir)
 	return ir, errs
 }
 
-func buildGceGatewayIR(ctx context.Context, storage *storage, ir *intermediate.IR) {
+func buildGceGatewayIR(ctx context.Context, conf *i2gw.ProviderConf, storage *storage, ir *intermediate.IR) {
 	if ir.Gateways == nil {
 		ir.Gateways = make(map[types.NamespacedName]intermediate.GatewayContext)
 	}
@@ -98,7 +107,7 @@ func buildGceGatewayIR(ctx context.Context, storage *storage, ir *intermediate.I
 		if feConfig == nil {
 			continue
 		}
-		gceGatewayIR := feConfigToGceGatewayIR(feConfig)
+		gceGatewayIR := feConfigToGceGatewayIR(conf, feConfig)
 		gateways := feConfigToGwys[feConfigKey]
 
 		for _, gwyKey := range gateways {
@@ -140,28 +149,77 @@ func getFrontendConfigAnnotation(ing *networkingv1.Ingress) (string, bool) {
 	return val, true
 }
 
-func feConfigToGceGatewayIR(feConfig *frontendconfigv1beta1.FrontendConfig) intermediate.GceGatewayIR {
+func feConfigToGceGatewayIR(conf *i2gw.ProviderConf, feConfig *frontendconfigv1beta1.FrontendConfig) intermediate.GceGatewayIR {
 	var gceGatewayIR intermediate.GceGatewayIR
 	if feConfig.Spec.SslPolicy != nil {
 		gceGatewayIR.SslPolicy = extensions.BuildIRSslPolicyConfig(feConfig)
 	}
+	if feConfig.Spec.RedirectToHttps != nil && feConfig.Spec.RedirectToHttps.Enabled {
+		gceGatewayIR.EnableHTTPSRedirect = true
+		gceGatewayIR.HTTPSRedirectStatusCode = httpsRedirectStatusCode(conf, feConfig)
+	}
 	return gceGatewayIR
 }
 
+// httpsRedirectStatusCode returns the status code of the responseCodeName of
+// the HTTPS redirects of the FrontendConfig. The HTTPRoute redirects support
+// 301 and 302 only, so 307 and 308 fall back to the closest one of them.
+func httpsRedirectStatusCode(conf *i2gw.ProviderConf, feConfig *frontendconfigv1beta1.FrontendConfig) int {
+	switch responseCodeName := feConfig.Spec.RedirectToHttps.ResponseCodeName; responseCodeName {
+	case "", "MOVED_PERMANENTLY_DEFAULT":
+		return 301
+	case "FOUND":
+		return 302
+	case "TEMPORARY_REDIRECT":
+		notify(conf, notifications.WarningNotification, fmt.Sprintf("the HTTPS redirect response code %s (307) is not supported by HTTPRoute redirects, 302 is used instead", responseCodeName), feConfig)
+		return 302
+	case "PERMANENT_REDIRECT":
+		notify(conf, notifications.WarningNotification, fmt.Sprintf("the HTTPS redirect response code %s (308) is not supported by HTTPRoute redirects, 301 is used instead", responseCodeName), feConfig)
+		return 301
+	default:
+		notify(conf, notifications.WarningNotification, fmt.Sprintf("unknown HTTPS redirect response code %q, 301 is used instead", responseCodeName), feConfig)
+		return 301
+	}
+}
+
+// redirectToHTTPS redirects the HTTP requests of the routes of the Gateways
+// whose FrontendConfig enables HTTPS redirects to HTTPS.
+func redirectToHTTPS(conf *i2gw.ProviderConf, ingresses []networkingv1.Ingress, ir *intermediate.IR) {
+	ruleGroups := common.GetRuleGroups(ingresses)
+	rgKeys := make([]string, 0, len(ruleGroups))
+	for rgKey := range ruleGroups {
+		rgKeys = append(rgKeys, rgKey)
+	}
+	slices.Sort(rgKeys)
+	for _, rgKey := range rgKeys {
+		rg := ruleGroups[rgKey]
+		gatewayContext, ok := ir.Gateways[types.NamespacedName{Namespace: rg.Namespace, Name: rg.IngressClass}]
+		if !ok {
+			continue
+		}
+		gceGatewayIR := gatewayContext.ProviderSpecificIR.Gce
+		if gceGatewayIR == nil || !gceGatewayIR.EnableHTTPSRedirect {
+			continue
+		}
+		keys := common.HTTPRouteKeys(ir, rg.Namespace, rg.IngressClass, rg.Host)
+		common.RedirectToHTTPS(conf, ProviderName, ir, keys, gceGatewayIR.HTTPSRedirectStatusCode)
+	}
+}
+
 type serviceNames []types.NamespacedName
 
-func buildGceServiceIR(ctx context.Context, storage *storage, ir *intermediate.IR) {
+func buildGceServiceIR(ctx context.Context, conf *i2gw.ProviderConf, storage *storage, ir *intermediate.IR) {
 	if ir.Services == nil {
 		ir.Services = make(map[types.NamespacedName]intermediate.ProviderSpecificServiceIR)
 	}
 
-	beConfigToSvcs := getBackendConfigMapping(ctx, storage)
+	beConfigToSvcs := getBackendConfigMapping(ctx, conf, storage)
 	for beConfigKey, beConfig := range storage.BackendConfigs {
 		if beConfig == nil {
 			continue
 		}
 		if err := extensions.ValidateBeConfig(beConfig); err != nil {
-			notify(notifications.ErrorNotification, err.Error(), beConfig)
+			notify(conf, notifications.ErrorNotification, err.Error(), beConfig)
 			continue
 		}
 		gceServiceIR := beConfigToGceServiceIR(beConfig)
@@ -174,7 +232,7 @@ func buildGceServiceIR(ctx context.Context, storage *storage, ir *intermediate.I
 	}
 }
 
-func getBackendConfigMapping(ctx context.Context, storage *storage) map[types.NamespacedName]serviceNames {
+func getBackendConfigMapping(ctx context.Context, conf *i2gw.ProviderConf, storage *storage) map[types.NamespacedName]serviceNames {
 	beConfigToSvcs := make(map[types.NamespacedName]serviceNames)
 
 	for _, service := range storage.Services {
@@ -182,7 +240,7 @@ func getBackendConfigMapping(ctx context.Context, storage *storage) map[types.Na
 		ctx = context.WithValue(ctx, serviceKey, service)
 
 		// Read BackendConfig based on v1 BackendConfigKey.
-		beConfigName, exists := getBackendConfigName(ctx, service, backendConfigKey)
+		beConfigName, exists := getBackendConfigName(ctx, conf, service, backendConfigKey)
 		if exists {
 			beConfigKey := types.NamespacedName{Namespace: service.Namespace, Name: beConfigName}
 			beConfigToSvcs[beConfigKey] = append(beConfigToSvcs[beConfigKey], svc)
@@ -190,7 +248,7 @@ func getBackendConfigMapping(ctx context.Context, storage *storage) map[types.Na
 		}
 
 		// Read BackendConfig based on v1beta1 BackendConfigKey.
-		beConfigName, exists = getBackendConfigName(ctx, service, betaBackendConfigKey)
+		beConfigName, exists = getBackendConfigName(ctx, conf, service, betaBackendConfigKey)
 		if exists {
 			beConfigKey := types.NamespacedName{Namespace: service.Namespace, Name: beConfigName}
 			beConfigToSvcs[beConfigKey] = append(beConfigToSvcs[beConfigKey], svc)
@@ -202,13 +260,13 @@ func getBackendConfigMapping(ctx context.Context, storage *storage) map[types.Na
 
 // Get names of the BackendConfig in the cluster based on the BackendConfig
 // annotation on k8s Services.
-func getBackendConfigName(ctx context.Context, service *apiv1.Service, backendConfigKey string) (string, bool) {
+func getBackendConfigName(ctx context.Context, conf *i2gw.ProviderConf, service *apiv1.Service, backendConfigKey string) (string, bool) {
 	val, exists := getBackendConfigAnnotation(service, backendConfigKey)
 	if !exists {
 		return "", false
 	}
 
-	return parseBackendConfigName(ctx, val)
+	return parseBackendConfigName(ctx, conf, val)
 }
 
 // Get the backend config annotation from the K8s service if it exists.
@@ -228,22 +286,22 @@ type backendConfigs struct {
 // Parse the name of the BackendConfig based on the annotation.
 // If different BackendConfigs are used on the same service, pick the one with
 // the alphabetically smallest name.
-func parseBackendConfigName(ctx context.Context, val string) (string, bool) {
+func parseBackendConfigName(ctx context.Context, conf *i2gw.ProviderConf, val string) (string, bool) {
 	service := ctx.Value(serviceKey).(*apiv1.Service)
 
 	var configs backendConfigs
 	if err := json.Unmarshal([]byte(val), &configs); err != nil {
-		notify(notifications.ErrorNotification, "BackendConfig annotation is invalid json", service)
+		notify(conf, notifications.ErrorNotification, "BackendConfig annotation is invalid json", service)
 		return "", false
 	}
 
 	if configs.Default == "" && len(configs.Ports) == 0 {
-		notify(notifications.ErrorNotification, "No BackendConfig's found in annotation", service)
+		notify(conf, notifications.ErrorNotification, "No BackendConfig's found in annotation", service)
 		return "", false
 	}
 
 	if len(configs.Ports) != 0 {
-		notify(notifications.ErrorNotification, "HealthCheckPolicy and GCPBackendPolicy can only be attached on the whole service, so having a dedicate policy for each port is not yet supported. Picking the first BackendConfig to translate to corresponding Gateway policy.", service)
+		notify(conf, notifications.ErrorNotification, "HealthCheckPolicy and GCPBackendPolicy can only be attached on the whole service, so having a dedicate policy for each port is not yet supported. Picking the first BackendConfig to translate to corresponding Gateway policy.", service)
 		// Return the BackendConfig associated with the alphabetically smallest port.
 		var backendConfigName string
 		var lowestPort string
@@ -1019,6 +1019,32 @@ func getTestFrontendConfig(namespace, name string, spec frontendconfigv1beta1.Fr
 	}
 }
 
+func TestHTTPSRedirectStatusCode(t *testing.T) {
+	testCases := []struct {
+		responseCodeName string
+		expected         int
+	}{
+		{responseCodeName: "", expected: 301},
+		{responseCodeName: "MOVED_PERMANENTLY_DEFAULT", expected: 301},
+		{responseCodeName: "FOUND", expected: 302},
+		{responseCodeName: "TEMPORARY_REDIRECT", expected: 302},
+		{responseCodeName: "PERMANENT_REDIRECT", expected: 301},
+		{responseCodeName: "UNKNOWN", expected: 301},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.responseCodeName, func(t *testing.T) {
+			feConfig := getTestFrontendConfig(testNamespace, testFrontendConfigName, frontendconfigv1beta1.FrontendConfigSpec{
+				RedirectToHttps: &frontendconfigv1beta1.HttpsRedirectConfig{Enabled: true, ResponseCodeName: tc.responseCodeName},
+			})
+			gceGatewayIR := feConfigToGceGatewayIR(nil, feConfig)
+			if !gceGatewayIR.EnableHTTPSRedirect || gceGatewayIR.HTTPSRedirectStatusCode != tc.expected {
+				t.Errorf("Expected HTTPS redirects with status code %d, got %+v", tc.expected, gceGatewayIR)
+			}
+		})
+	}
+}
+
 func TestGetBackendConfigMapping(t *testing.T) {
 	t.Parallel()
 	testNamespace := "test-namespace"
@@ -1136,7 +1162,7 @@ func TestGetBackendConfigMapping(t *testing.T) {
 			}
 			gceProvider.storage.BackendConfigs = backendConfigs
 
-			beConfigToSvcs := getBackendConfigMapping(context.TODO(), gceProvider.storage)
+			beConfigToSvcs := getBackendConfigMapping(context.TODO(), nil, gceProvider.storage)
 			if !reflect.DeepEqual(beConfigToSvcs, tc.expectedBeConfigToSvcs) {
 				t.Errorf("Got BackendConfig mapping %v, expected %v", beConfigToSvcs, tc.expectedBeConfigToSvcs)
 			}
@@ -1232,7 +1258,7 @@ func TestGetBackendConfigName(t *testing.T) {
 		t.Run(tc.desc, func(t *testing.T) {
 			ctx := context.TODO()
 			ctx = context.WithValue(ctx, serviceKey, tc.service)
-			gotName, gotExists := getBackendConfigName(ctx, tc.service, tc.beConfigKey)
+			gotName, gotExists := getBackendConfigName(ctx, nil, tc.service, tc.beConfigKey)
 			if gotExists != tc.expectedExists {
 				t.Errorf("getBackendConfigName() got exist = %v, expected %v", gotExists, tc.expectedExists)
 			}
@@ -17,11 +17,12 @@ limitations under the License.
 package gce
 
 import (
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func notify(mType notifications.MessageType, message string, callingObject ...client.Object) {
+func notify(conf *i2gw.ProviderConf, mType notifications.MessageType, message string, callingObject ...client.Object) {
 	newNotification := notifications.Notification{Type: mType, Message: message, CallingObjects: callingObject}
-	notifications.NotificationAggr.DispatchNotification(newNotification, string(ProviderName))
+	conf.Notify(ProviderName, newNotification)
 }
@@ -17,10 +17,8 @@ limitations under the License.
 package gce
 
 import (
-	"bytes"
 	"context"
 	"fmt"
-	"os"
 
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/providers/common"
@@ -65,7 +63,7 @@ func (r *reader) readResourcesFromCluster(ctx context.Context) (*storage, error)
 	}
 	storage.Ingresses = ingresses
 
-	services, err := r.readServicesFromCluster(ctx)
+	services, err := common.ReadServicesFromCluster(ctx, r.conf.Client, ingresses)
 	if err != nil {
 		return nil, err
 	}
@@ -85,15 +83,10 @@ func (r *reader) readResourcesFromCluster(ctx context.Context) (*storage, error)
 	return storage, nil
 }
 
-func (r *reader) readResourcesFromFile(filename string) (*storage, error) {
-	stream, err := os.ReadFile(filename)
+func (r *reader) readResourcesFromFiles(filenames []string) (*storage, error) {
+	unstructuredObjects, err := common.ReadObjectsFromFiles(r.conf, filenames)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read file %v: %w", filename, err)
-	}
-
-	unstructuredObjects, err := common.ExtractObjectsFromReader(bytes.NewReader(stream), r.conf.Namespace)
-	if err != nil {
-		return nil, fmt.Errorf("failed to extract objects: %w", err)
+		return nil, err
 	}
 
 	storage, err := r.readUnstructuredObjects(unstructuredObjects)
@@ -104,19 +97,6 @@ func (r *reader) readResourcesFromFile(filename string) (*storage, error) {
 	return storage, nil
 }
 
-func (r *reader) readServicesFromCluster(ctx context.Context) (map[types.NamespacedName]*apiv1.Service, error) {
-	var serviceList apiv1.ServiceList
-	err := r.conf.Client.List(ctx, &serviceList)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get services from the cluster: %w", err)
-	}
-	services := make(map[types.NamespacedName]*apiv1.Service)
-	for i, service := range serviceList.Items {
-		services[types.NamespacedName{Namespace: service.Namespace, Name: service.Name}] = &serviceList.Items[i]
-	}
-	return services, nil
-}
-
 func (r *reader) readBackendConfigsFromCluster(ctx context.Context) (map[types.NamespacedName]*backendconfigv1.BackendConfig, error) {
 	var backendConfigList backendconfigv1.BackendConfigList
 	err := r.conf.Client.List(ctx, &backendConfigList)
@@ -18,8 +18,10 @@ package ingressnginx
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
 
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/intermediate"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/providers/common"
@@ -30,7 +32,7 @@ import (
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
-func canaryFeature(ingresses []networkingv1.Ingress, ir *intermediate.IR) field.ErrorList {
+func canaryFeature(conf *i2gw.ProviderConf, ingresses []networkingv1.Ingress, ir *intermediate.IR) field.ErrorList {
 	ruleGroups := common.GetRuleGroups(ingresses)
 
 	for _, rg := range ruleGroups {
@@ -52,25 +54,29 @@ func canaryFeature(ingresses []networkingv1.Ingress, ir *intermediate.IR) field.
 		}
 
 		if canaryEnabled {
-			for _, paths := range ingressPathsByMatchKey {
-				path := paths[0]
+			keys := common.HTTPRouteKeys(ir, rg.Namespace, rg.IngressClass, rg.Host)
+			if len(keys) == 0 {
+				// If there wasn't an HTTPRoute for this Ingress, we can skip it as something is wrong.
+				// All the available errors will be returned at the end.
+				continue
+			}
 
+			patched := false
+			for _, paths := range ingressPathsByMatchKey {
 				backendRefs, calculationErrs := calculateBackendRefWeight(paths)
 				errs = append(errs, calculationErrs...)
 
-				key := types.NamespacedName{Namespace: path.ingress.Namespace, Name: common.RouteName(rg.Name, rg.Host)}
-				httpRouteContext, ok := ir.HTTPRoutes[key]
-				if !ok {
-					// If there wasn't an HTTPRoute for this Ingress, we can skip it as something is wrong.
-					// All the available errors will be returned at the end.
-					continue
+				// The rules of the group may be split across several routes.
+				if patchHTTPRouteWithBackendRefs(ir.HTTPRoutes, keys, paths[0].path, backendRefs) {
+					patched = true
 				}
-
-				patchHTTPRouteWithBackendRefs(&httpRouteContext.HTTPRoute, backendRefs)
 			}
 			if len(errs) > 0 {
 				return errs
 			}
+			if patched {
+				notifyCanaryIngresses(conf, rg)
+			}
 		}
 	}
 
@@ -100,30 +106,67 @@ func getPathsByMatchGroups(rg common.IngressRuleGroup) (map[pathMatchKey][]ingre
 	return ingressPathsByMatchKey, nil
 }
 
-func patchHTTPRouteWithBackendRefs(httpRoute *gatewayv1.HTTPRoute, backendRefs []gatewayv1.HTTPBackendRef) {
-	var ruleExists bool
-	for _, backendRef := range backendRefs {
-
-		ruleExists = false
-
-		for _, rule := range httpRoute.Spec.Rules {
-			for i := range rule.BackendRefs {
-				if backendRef.Name == rule.BackendRefs[i].Name {
-					rule.BackendRefs[i].Weight = backendRef.Weight
-					ruleExists = true
-					break
+// patchHTTPRouteWithBackendRefs sets the weights of backendRefs on the rule
+// matching path, in whichever of the routes of a rule group at keys holds it.
+// The backends which are not in the rule yet are added to it, so that the
+// routes keep their number of rules. It returns whether a rule was patched.
+func patchHTTPRouteWithBackendRefs(httpRoutes map[types.NamespacedName]intermediate.HTTPRouteContext, keys []types.NamespacedName, path networkingv1.HTTPIngressPath, backendRefs []gatewayv1.HTTPBackendRef) bool {
+	for _, key := range keys {
+		httpRouteContext := httpRoutes[key]
+		for i, rule := range httpRouteContext.Spec.Rules {
+			if !ruleMatchesPath(rule, path) {
+				continue
+			}
+			for _, backendRef := range backendRefs {
+				idx := slices.IndexFunc(rule.BackendRefs, func(ruleBackendRef gatewayv1.HTTPBackendRef) bool {
+					return ruleBackendRef.Name == backendRef.Name
+				})
+				if idx < 0 {
+					rule.BackendRefs = append(rule.BackendRefs, backendRef)
+					continue
 				}
+				rule.BackendRefs[idx].Weight = backendRef.Weight
 			}
+			httpRouteContext.Spec.Rules[i] = rule
+			httpRoutes[key] = httpRouteContext
+			return true
 		}
+	}
+	return false
+}
 
-		if !ruleExists {
-			httpRoute.Spec.Rules = append(httpRoute.Spec.Rules, gatewayv1.HTTPRouteRule{
-				BackendRefs: []gatewayv1.HTTPBackendRef{backendRef},
-			})
+// ruleMatchesPath returns whether rule was converted from the Ingress path,
+// i.e. whether it matches the value, and the type unless it is
+// ImplementationSpecific, of the path.
+func ruleMatchesPath(rule gatewayv1.HTTPRouteRule, path networkingv1.HTTPIngressPath) bool {
+	var pathMatchType gatewayv1.PathMatchType
+	if path.PathType != nil {
+		switch *path.PathType {
+		case networkingv1.PathTypePrefix:
+			pathMatchType = gatewayv1.PathMatchPathPrefix
+		case networkingv1.PathTypeExact:
+			pathMatchType = gatewayv1.PathMatchExact
 		}
 	}
-	if ruleExists {
-		notify(notifications.InfoNotification, fmt.Sprintf("parsed canary annotations of ingress and patched %v fields", field.NewPath("httproute", "spec", "rules").Key("").Child("backendRefs")), httpRoute)
+	return slices.ContainsFunc(rule.Matches, func(match gatewayv1.HTTPRouteMatch) bool {
+		if match.Path == nil || match.Path.Value == nil || *match.Path.Value != path.Path {
+			return false
+		}
+		return pathMatchType == "" || (match.Path.Type != nil && *match.Path.Type == pathMatchType)
+	})
+}
+
+// notifyCanaryIngresses notifies once for every canary Ingress of the rule
+// group that its annotations were converted.
+func notifyCanaryIngresses(conf *i2gw.ProviderConf, rg common.IngressRuleGroup) {
+	notified := make(map[string]bool)
+	for _, rule := range rg.Rules {
+		ingress := rule.Ingress
+		if notified[ingress.Name] || ingress.Annotations["nginx.ingress.kubernetes.io/canary"] != "true" {
+			continue
+		}
+		notified[ingress.Name] = true
+		notify(conf, notifications.InfoNotification, fmt.Sprintf("parsed canary annotations of ingress and patched %v fields", field.NewPath("httproute", "spec", "rules").Key("").Child("backendRefs")), &ingress)
 	}
 }
 
@@ -136,10 +179,9 @@ func calculateBackendRefWeight(paths []ingressPath) ([]gatewayv1.HTTPBackendRef,
 	// This is the default value for nginx annotation nginx.ingress.kubernetes.io/canary-weight-total
 	var weightTotal = 100
 
-	for i, path := range paths {
-		backendRef, err := common.ToBackendRef(path.path.Backend, field.NewPath("paths", "backends").Index(i))
-		if err != nil {
-			errors = append(errors, err)
+	for _, path := range paths {
+		backendRef := common.ToBackendRef(path.path.Backend)
+		if backendRef == nil {
 			continue
 		}
 		if path.extra != nil && path.extra.canary != nil && path.extra.canary.enable {
@@ -20,10 +20,14 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/intermediate"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
@@ -324,3 +328,78 @@ func Test_parseCanaryAnnotations(t *testing.T) {
 		})
 	}
 }
+
+func Test_patchHTTPRouteWithBackendRefs(t *testing.T) {
+	prefix := networkingv1.PathTypePrefix
+	rule := func(path string, backends ...string) gatewayv1.HTTPRouteRule {
+		rule := gatewayv1.HTTPRouteRule{
+			Matches: []gatewayv1.HTTPRouteMatch{{
+				Path: &gatewayv1.HTTPPathMatch{Type: ptr.To(gatewayv1.PathMatchPathPrefix), Value: ptr.To(path)},
+			}},
+		}
+		for _, backend := range backends {
+			rule.BackendRefs = append(rule.BackendRefs, gatewayv1.HTTPBackendRef{
+				BackendRef: gatewayv1.BackendRef{BackendObjectReference: gatewayv1.BackendObjectReference{Name: gatewayv1.ObjectName(backend)}},
+			})
+		}
+		return rule
+	}
+	weighted := func(backend string, weight int32) gatewayv1.HTTPBackendRef {
+		return gatewayv1.HTTPBackendRef{BackendRef: gatewayv1.BackendRef{
+			BackendObjectReference: gatewayv1.BackendObjectReference{Name: gatewayv1.ObjectName(backend)},
+			Weight:                 ptr.To(weight),
+		}}
+	}
+
+	// The rule of the path is in the second of the split routes.
+	keys := []types.NamespacedName{{Namespace: "default", Name: "route"}, {Namespace: "default", Name: "route-2"}}
+	httpRoutes := map[types.NamespacedName]intermediate.HTTPRouteContext{
+		keys[0]: {HTTPRoute: gatewayv1.HTTPRoute{Spec: gatewayv1.HTTPRouteSpec{Rules: []gatewayv1.HTTPRouteRule{rule("/a", "production")}}}},
+		keys[1]: {HTTPRoute: gatewayv1.HTTPRoute{Spec: gatewayv1.HTTPRouteSpec{Rules: []gatewayv1.HTTPRouteRule{rule("/b", "production")}}}},
+	}
+
+	path := networkingv1.HTTPIngressPath{Path: "/b", PathType: &prefix}
+	if !patchHTTPRouteWithBackendRefs(httpRoutes, keys, path, []gatewayv1.HTTPBackendRef{weighted("production", 80), weighted("canary", 20)}) {
+		t.Fatalf("Expected the rule of %s to be patched", path.Path)
+	}
+
+	expectedRules := map[types.NamespacedName][]gatewayv1.HTTPRouteRule{
+		keys[0]: {rule("/a", "production")},
+		keys[1]: {{Matches: rule("/b").Matches, BackendRefs: []gatewayv1.HTTPBackendRef{weighted("production", 80), weighted("canary", 20)}}},
+	}
+	for _, key := range keys {
+		if diff := cmp.Diff(expectedRules[key], httpRoutes[key].Spec.Rules); diff != "" {
+			t.Errorf("Unexpected rules of %s (-want +got):\n%s", key, diff)
+		}
+	}
+
+	if patchHTTPRouteWithBackendRefs(httpRoutes, keys, networkingv1.HTTPIngressPath{Path: "/c", PathType: &prefix}, []gatewayv1.HTTPBackendRef{weighted("canary", 20)}) {
+		t.Errorf("Expected no rule to be patched for a path without rule")
+	}
+}
+
+func Test_auditCanaryByHeader(t *testing.T) {
+	ingress := networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "canary",
+			Namespace: "default",
+			Annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/canary":                 "true",
+				"nginx.ingress.kubernetes.io/canary-weight":          "20",
+				"nginx.ingress.kubernetes.io/canary-by-header":       "X-Canary",
+				"nginx.ingress.kubernetes.io/canary-by-header-value": "yes",
+			},
+		},
+	}
+
+	audits := i2gw.AuditIngresses([]networkingv1.Ingress{ingress}, []string{Name})
+	expected := []i2gw.IngressAudit{{
+		Ingress: types.NamespacedName{Namespace: "default", Name: "canary"},
+		DroppedAnnotations: map[i2gw.ProviderName][]string{
+			Name: {"nginx.ingress.kubernetes.io/canary-by-header", "nginx.ingress.kubernetes.io/canary-by-header-value"},
+		},
+	}}
+	if diff := cmp.Diff(expected, audits); diff != "" {
+		t.Errorf("Unexpected audits (-want +got):\n%s", diff)
+	}
+}
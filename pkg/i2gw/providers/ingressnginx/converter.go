@@ -25,14 +25,18 @@ import (
 
 // resourcesToIRConverter implements the ToIR function of i2gw.ResourcesToIRConverter interface.
 type resourcesToIRConverter struct {
+	conf *i2gw.ProviderConf
+
 	featureParsers []i2gw.FeatureParser
 }
 
 // newResourcesToIRConverter returns an ingress-nginx resourcesToIRConverter instance.
-func newResourcesToIRConverter() *resourcesToIRConverter {
+func newResourcesToIRConverter(conf *i2gw.ProviderConf) *resourcesToIRConverter {
 	return &resourcesToIRConverter{
+		conf: conf,
 		featureParsers: []i2gw.FeatureParser{
 			canaryFeature,
+			sslRedirectFeature,
 		},
 	}
 }
@@ -41,20 +45,20 @@ func (c *resourcesToIRConverter) convert(storage *storage) (intermediate.IR, fie
 
 	// TODO(liorliberman) temporary until we decide to change ToIR and featureParsers to get a map of [types.NamespacedName]*networkingv1.Ingress instead of a list
 	ingressList := storage.Ingresses.List()
+	ingressList = common.ResolveServicePorts(c.conf, Name, ingressList, storage.Services)
+	common.NotifyDefaultBackendConflicts(c.conf, Name, ingressList)
 
 	// Convert plain ingress resources to gateway resources, ignoring all
 	// provider-specific features.
 	ir, errs := common.ToIR(ingressList, i2gw.ProviderImplementationSpecificOptions{})
-	if len(errs) > 0 {
+	if len(errs) > 0 && !c.conf.BestEffort {
 		return intermediate.IR{}, errs
 	}
+	common.NotifyUnusedTLSHosts(c.conf, Name, ingressList, &ir)
+	c.conf.TraceIR(Name, i2gw.TraceStageCommonToIR, ir)
 
-	for _, parseFeatureFunc := range c.featureParsers {
-		// Apply the feature parsing function to the gateway resources, one by one.
-		parseErrs := parseFeatureFunc(ingressList, &ir)
-		// Append the parsing errors to the error list.
-		errs = append(errs, parseErrs...)
-	}
+	// Apply the feature parsing functions to the gateway resources, one by one.
+	errs = append(errs, i2gw.ParseFeatures(c.conf, Name, c.featureParsers, ingressList, &ir)...)
 
 	return ir, errs
 }
@@ -343,7 +343,8 @@ func Test_ToIR(t *testing.T) {
 							Spec: gatewayv1.HTTPRouteSpec{
 								CommonRouteSpec: gatewayv1.CommonRouteSpec{
 									ParentRefs: []gatewayv1.ParentReference{{
-										Name: "nginx",
+										Name:        "nginx",
+										SectionName: ptrTo(gatewayv1.SectionName("bar-example-com-https")),
 									}},
 								},
 								Hostnames: []gatewayv1.Hostname{"bar.example.com"},
@@ -374,7 +375,8 @@ func Test_ToIR(t *testing.T) {
 							Spec: gatewayv1.HTTPRouteSpec{
 								CommonRouteSpec: gatewayv1.CommonRouteSpec{
 									ParentRefs: []gatewayv1.ParentReference{{
-										Name: "nginx",
+										Name:        "nginx",
+										SectionName: ptrTo(gatewayv1.SectionName("foo-example-com-https")),
 									}},
 								},
 								Hostnames: []gatewayv1.Hostname{"foo.example.com"},
@@ -419,6 +421,52 @@ func Test_ToIR(t *testing.T) {
 							},
 						},
 					},
+					{Namespace: "default", Name: "example-ingress-bar-example-com-https-redirect"}: {
+						HTTPRoute: gatewayv1.HTTPRoute{
+							ObjectMeta: metav1.ObjectMeta{Name: "example-ingress-bar-example-com-https-redirect", Namespace: "default"},
+							Spec: gatewayv1.HTTPRouteSpec{
+								CommonRouteSpec: gatewayv1.CommonRouteSpec{
+									ParentRefs: []gatewayv1.ParentReference{{
+										Name:        "nginx",
+										SectionName: ptrTo(gatewayv1.SectionName("bar-example-com-http")),
+									}},
+								},
+								Hostnames: []gatewayv1.Hostname{"bar.example.com"},
+								Rules: []gatewayv1.HTTPRouteRule{{
+									Filters: []gatewayv1.HTTPRouteFilter{{
+										Type: gatewayv1.HTTPRouteFilterRequestRedirect,
+										RequestRedirect: &gatewayv1.HTTPRequestRedirectFilter{
+											Scheme:     ptrTo("https"),
+											StatusCode: ptrTo(301),
+										},
+									}},
+								}},
+							},
+						},
+					},
+					{Namespace: "default", Name: "example-ingress-foo-example-com-https-redirect"}: {
+						HTTPRoute: gatewayv1.HTTPRoute{
+							ObjectMeta: metav1.ObjectMeta{Name: "example-ingress-foo-example-com-https-redirect", Namespace: "default"},
+							Spec: gatewayv1.HTTPRouteSpec{
+								CommonRouteSpec: gatewayv1.CommonRouteSpec{
+									ParentRefs: []gatewayv1.ParentReference{{
+										Name:        "nginx",
+										SectionName: ptrTo(gatewayv1.SectionName("foo-example-com-http")),
+									}},
+								},
+								Hostnames: []gatewayv1.Hostname{"foo.example.com"},
+								Rules: []gatewayv1.HTTPRouteRule{{
+									Filters: []gatewayv1.HTTPRouteFilter{{
+										Type: gatewayv1.HTTPRouteFilterRequestRedirect,
+										RequestRedirect: &gatewayv1.HTTPRequestRedirectFilter{
+											Scheme:     ptrTo("https"),
+											StatusCode: ptrTo(301),
+										},
+									}},
+								}},
+							},
+						},
+					},
 				},
 			},
 			expectedErrors: field.ErrorList{},
@@ -32,6 +32,18 @@ const NginxIngressClass = "nginx"
 
 func init() {
 	i2gw.ProviderConstructorByName[Name] = NewProvider
+	i2gw.RegisterProviderResourceKinds(Name, common.IngressGVK, common.ServiceGVK)
+
+	i2gw.RegisterProviderAnnotations(Name, i2gw.ProviderAnnotations{
+		Prefixes: []string{"nginx.ingress.kubernetes.io/"},
+		Keys: []string{
+			"nginx.ingress.kubernetes.io/canary",
+			"nginx.ingress.kubernetes.io/canary-weight",
+			"nginx.ingress.kubernetes.io/canary-weight-total",
+			"nginx.ingress.kubernetes.io/ssl-redirect",
+			"nginx.ingress.kubernetes.io/force-ssl-redirect",
+		},
+	})
 }
 
 // Provider implements the i2gw.Provider interface.
@@ -46,7 +58,7 @@ func NewProvider(conf *i2gw.ProviderConf) i2gw.Provider {
 	return &Provider{
 		storage:                newResourcesStorage(),
 		resourceReader:         newResourceReader(conf),
-		resourcesToIRConverter: newResourcesToIRConverter(),
+		resourcesToIRConverter: newResourcesToIRConverter(conf),
 	}
 }
 
@@ -71,8 +83,8 @@ func (p *Provider) ReadResourcesFromCluster(ctx context.Context) error {
 	return nil
 }
 
-func (p *Provider) ReadResourcesFromFile(_ context.Context, filename string) error {
-	storage, err := p.resourceReader.readResourcesFromFile(filename)
+func (p *Provider) ReadResourcesFromFiles(_ context.Context, filenames []string) error {
+	storage, err := p.resourceReader.readResourcesFromFiles(filenames)
 	if err != nil {
 		return fmt.Errorf("failed to read resources from file: %w", err)
 	}
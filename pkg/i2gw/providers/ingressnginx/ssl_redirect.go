@@ -0,0 +1,80 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingressnginx
+
+import (
+	"fmt"
+
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/intermediate"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/providers/common"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	sslRedirectAnnotation      = "nginx.ingress.kubernetes.io/ssl-redirect"
+	forceSSLRedirectAnnotation = "nginx.ingress.kubernetes.io/force-ssl-redirect"
+)
+
+// sslRedirectStatusCode is the status code of the redirects to HTTPS.
+// ingress-nginx redirects with 308 by default, which the RequestRedirect
+// filter of HTTPRoutes does not support, so the permanent 301 is used instead.
+const sslRedirectStatusCode = 301
+
+// sslRedirectFeature redirects the HTTP requests of the hosts with TLS to
+// HTTPS, as ingress-nginx does by default, unless an Ingress of the host sets
+// the ssl-redirect annotation to "false". The force-ssl-redirect annotation
+// redirects the host even if it has no TLS in its Ingresses, which needs an
+// HTTPS listener for it on the Gateway.
+func sslRedirectFeature(conf *i2gw.ProviderConf, ingresses []networkingv1.Ingress, ir *intermediate.IR) field.ErrorList {
+	ruleGroups := common.GetRuleGroups(ingresses)
+	for _, rg := range ruleGroups {
+		keys := common.HTTPRouteKeys(ir, rg.Namespace, rg.IngressClass, rg.Host)
+		if len(keys) == 0 {
+			continue
+		}
+		var forced bool
+		var disabledBy *networkingv1.Ingress
+		for _, rule := range rg.Rules {
+			ingress := rule.Ingress
+			switch {
+			case ingress.Annotations[forceSSLRedirectAnnotation] == "true":
+				forced = true
+			case ingress.Annotations[sslRedirectAnnotation] == "false":
+				disabledBy = &ingress
+			}
+		}
+		if !forced && !common.HasHTTPSListener(ir, keys[0]) {
+			continue
+		}
+		if disabledBy != nil {
+			if forced {
+				notify(conf, notifications.WarningNotification, fmt.Sprintf("the %q annotation of ingress disables the redirect of the HTTP requests of the HTTPRoute %v to HTTPS, which other Ingresses of the host force, they are not redirected", sslRedirectAnnotation, keys[0]), disabledBy)
+			}
+			continue
+		}
+		redirectKey, ok := common.RedirectToHTTPS(conf, Name, ir, keys, sslRedirectStatusCode)
+		if !ok {
+			continue
+		}
+		httpRoute := ir.HTTPRoutes[redirectKey]
+		notify(conf, notifications.InfoNotification, fmt.Sprintf("redirected the HTTP requests of HTTPRoute %v to HTTPS with the status code %d, set the %q annotation to \"false\" to keep serving them", keys[0], sslRedirectStatusCode, sslRedirectAnnotation), &httpRoute)
+	}
+	return nil
+}
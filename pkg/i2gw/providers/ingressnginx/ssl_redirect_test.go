@@ -0,0 +1,140 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingressnginx
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/providers/common"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func Test_sslRedirectFeature(t *testing.T) {
+	iPrefix := networkingv1.PathTypePrefix
+	ingress := func(annotations map[string]string, tls bool) networkingv1.Ingress {
+		ingress := networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default", Annotations: annotations},
+			Spec: networkingv1.IngressSpec{
+				IngressClassName: ptr.To("nginx"),
+				Rules: []networkingv1.IngressRule{{
+					Host: "example.com",
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{
+							Paths: []networkingv1.HTTPIngressPath{{
+								Path:     "/",
+								PathType: &iPrefix,
+								Backend: networkingv1.IngressBackend{
+									Service: &networkingv1.IngressServiceBackend{
+										Name: "example",
+										Port: networkingv1.ServiceBackendPort{Number: 80},
+									},
+								},
+							}},
+						},
+					},
+				}},
+			},
+		}
+		if tls {
+			ingress.Spec.TLS = []networkingv1.IngressTLS{{Hosts: []string{"example.com"}, SecretName: "example"}}
+		}
+		return ingress
+	}
+	routeKey := types.NamespacedName{Namespace: "default", Name: "example-example-com"}
+	redirectKey := types.NamespacedName{Namespace: "default", Name: "example-example-com-https-redirect"}
+
+	testCases := []struct {
+		name               string
+		ingress            networkingv1.Ingress
+		expectedParentRefs []gatewayv1.ParentReference
+		expectedRedirect   bool
+		expectedWarnings   int
+	}{
+		{
+			name:               "host with TLS is redirected by default",
+			ingress:            ingress(nil, true),
+			expectedParentRefs: []gatewayv1.ParentReference{{Name: "nginx", SectionName: ptr.To[gatewayv1.SectionName]("example-com-https")}},
+			expectedRedirect:   true,
+		},
+		{
+			name:               "ssl-redirect disabled",
+			ingress:            ingress(map[string]string{sslRedirectAnnotation: "false"}, true),
+			expectedParentRefs: []gatewayv1.ParentReference{{Name: "nginx"}},
+		},
+		{
+			name:               "host without TLS",
+			ingress:            ingress(nil, false),
+			expectedParentRefs: []gatewayv1.ParentReference{{Name: "nginx"}},
+		},
+		{
+			name:               "force-ssl-redirect without HTTPS listener",
+			ingress:            ingress(map[string]string{forceSSLRedirectAnnotation: "true"}, false),
+			expectedParentRefs: []gatewayv1.ParentReference{{Name: "nginx"}},
+			expectedWarnings:   1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ingresses := []networkingv1.Ingress{tc.ingress}
+			ir, errs := common.ToIR(ingresses, i2gw.ProviderImplementationSpecificOptions{})
+			if len(errs) > 0 {
+				t.Fatalf("Expected no errors, got %+v", errs)
+			}
+			conf := &i2gw.ProviderConf{Notifications: notifications.NewNotificationAggregator()}
+			if errs := sslRedirectFeature(conf, ingresses, &ir); len(errs) > 0 {
+				t.Fatalf("Expected no errors, got %+v", errs)
+			}
+
+			if diff := cmp.Diff(tc.expectedParentRefs, ir.HTTPRoutes[routeKey].Spec.ParentRefs); diff != "" {
+				t.Errorf("Unexpected parentRefs (-want +got):\n%s", diff)
+			}
+			redirectRoute, ok := ir.HTTPRoutes[redirectKey]
+			if ok != tc.expectedRedirect {
+				t.Fatalf("Expected the redirect HTTPRoute to be generated: %t, got %v", tc.expectedRedirect, ir.HTTPRoutes)
+			}
+			if ok {
+				expectedFilters := []gatewayv1.HTTPRouteFilter{{
+					Type: gatewayv1.HTTPRouteFilterRequestRedirect,
+					RequestRedirect: &gatewayv1.HTTPRequestRedirectFilter{
+						Scheme:     ptr.To("https"),
+						StatusCode: ptr.To(sslRedirectStatusCode),
+					},
+				}}
+				if diff := cmp.Diff(expectedFilters, redirectRoute.Spec.Rules[0].Filters); diff != "" {
+					t.Errorf("Unexpected filters of the redirect HTTPRoute (-want +got):\n%s", diff)
+				}
+			}
+			var warnings int
+			for _, notification := range conf.Notifications.Notifications[Name] {
+				if notification.Type == notifications.WarningNotification {
+					warnings++
+				}
+			}
+			if warnings != tc.expectedWarnings {
+				t.Errorf("Expected %d warnings, got %+v", tc.expectedWarnings, conf.Notifications.Notifications[Name])
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ package ingressnginx
 import (
 	"sort"
 
+	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
@@ -29,6 +30,7 @@ type OrderedIngressMap struct {
 }
 type storage struct {
 	Ingresses OrderedIngressMap
+	Services  map[types.NamespacedName]*corev1.Service
 }
 
 func newResourcesStorage() *storage {
@@ -37,6 +39,7 @@ func newResourcesStorage() *storage {
 			ingressNames:   []types.NamespacedName{},
 			ingressObjects: map[types.NamespacedName]*networkingv1.Ingress{},
 		},
+		Services: map[types.NamespacedName]*corev1.Service{},
 	}
 }
 
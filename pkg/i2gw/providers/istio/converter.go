@@ -21,9 +21,13 @@ import (
 	"fmt"
 	"net"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/intermediate"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/naming"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/providers/common"
 	istiov1beta1 "istio.io/api/networking/v1beta1"
@@ -45,13 +49,16 @@ const (
 )
 
 type resourcesToIRConverter struct {
+	conf *i2gw.ProviderConf
+
 	// gw -> namespace -> hosts; stores hosts allowed by each Gateway
 	gwAllowedHosts map[types.NamespacedName]map[string]sets.Set[string]
 	ctx            context.Context
 }
 
-func newResourcesToIRConverter() resourcesToIRConverter {
+func newResourcesToIRConverter(conf *i2gw.ProviderConf) resourcesToIRConverter {
 	return resourcesToIRConverter{
+		conf:           conf,
 		gwAllowedHosts: make(map[types.NamespacedName]map[string]sets.Set[string]),
 		ctx:            context.Background(),
 	}
@@ -154,7 +161,7 @@ func (c *resourcesToIRConverter) convertGateway(gw *istioclientv1beta1.Gateway,
 
 		serverPort := server.GetPort()
 		if serverPort == nil {
-			notify(notifications.ErrorNotification, fmt.Sprintf("port is nil, path %v", serverFieldPath), gw)
+			notify(c.conf, notifications.ErrorNotification, fmt.Sprintf("port is nil, path %v", serverFieldPath), gw)
 			klog.Error(field.Invalid(serverFieldPath, nil, "port is nil"))
 			continue
 		}
@@ -162,7 +169,7 @@ func (c *resourcesToIRConverter) convertGateway(gw *istioclientv1beta1.Gateway,
 		portFieldPath := serverFieldPath.Child("Port")
 
 		if serverPort.GetName() != "" {
-			notify(notifications.WarningNotification, fmt.Sprintf("ignoring field: %v", portFieldPath.Child("Name")), gw)
+			notifyIgnoredField(c.conf, notifications.WarningNotification, portFieldPath.Child("Name"), gw)
 			klog.Infof("ignoring field: %v", portFieldPath.Child("Name"))
 		}
 
@@ -193,7 +200,7 @@ func (c *resourcesToIRConverter) convertGateway(gw *istioclientv1beta1.Gateway,
 			case istiov1beta1.ServerTLSSettings_SIMPLE, istiov1beta1.ServerTLSSettings_MUTUAL:
 				tlsMode = gatewayv1.TLSModeTerminate
 			case istiov1beta1.ServerTLSSettings_ISTIO_MUTUAL, istiov1beta1.ServerTLSSettings_OPTIONAL_MUTUAL:
-				notify(notifications.WarningNotification, fmt.Sprintf("the istio server is ignored as there's no direct translation for this TLS istio protocol: %v", tlsFieldPath.Child("Mode").Key(serverTLSMode.String())), gw)
+				notify(c.conf, notifications.WarningNotification, fmt.Sprintf("the istio server is ignored as there's no direct translation for this TLS istio protocol: %v", tlsFieldPath.Child("Mode").Key(serverTLSMode.String())), gw)
 				klog.Warningf("the istio server is ignored as there's no direct translation for this TLS istio protocol: %v", tlsFieldPath.Child("Mode").Key(serverTLSMode.String()))
 				continue
 			default:
@@ -201,53 +208,53 @@ func (c *resourcesToIRConverter) convertGateway(gw *istioclientv1beta1.Gateway,
 			}
 
 			if serverTLS.GetHttpsRedirect() {
-				notify(notifications.WarningNotification, fmt.Sprintf("ignoring field: %v", tlsFieldPath.Child("HttpsRedirect")), gw)
+				notifyIgnoredField(c.conf, notifications.WarningNotification, tlsFieldPath.Child("HttpsRedirect"), gw)
 				klog.Infof("ignoring field: %v", tlsFieldPath.Child("HttpsRedirect"))
 			}
 			if serverTLS.GetServerCertificate() != "" {
-				notify(notifications.WarningNotification, fmt.Sprintf("ignoring field: %v", tlsFieldPath.Child("ServerCertificate")), gw)
+				notifyIgnoredField(c.conf, notifications.WarningNotification, tlsFieldPath.Child("ServerCertificate"), gw)
 				klog.Infof("ignoring field: %v", tlsFieldPath.Child("ServerCertificate"))
 			}
 			if serverTLS.GetPrivateKey() != "" {
-				notify(notifications.WarningNotification, fmt.Sprintf("ignoring field: %v", tlsFieldPath.Child("PrivateKey")), gw)
+				notifyIgnoredField(c.conf, notifications.WarningNotification, tlsFieldPath.Child("PrivateKey"), gw)
 				klog.Infof("ignoring field: %v", tlsFieldPath.Child("PrivateKey"))
 			}
 			if serverTLS.GetCaCertificates() != "" {
-				notify(notifications.WarningNotification, fmt.Sprintf("ignoring field: %v", tlsFieldPath.Child("CaCertificates")), gw)
+				notifyIgnoredField(c.conf, notifications.WarningNotification, tlsFieldPath.Child("CaCertificates"), gw)
 				klog.Infof("ignoring field: %v", tlsFieldPath.Child("CaCertificates"))
 			}
 			if len(serverTLS.GetSubjectAltNames()) > 0 {
-				notify(notifications.WarningNotification, fmt.Sprintf("ignoring field: %v", tlsFieldPath.Child("SubjectAltNames")), gw)
+				notifyIgnoredField(c.conf, notifications.WarningNotification, tlsFieldPath.Child("SubjectAltNames"), gw)
 				klog.Infof("ignoring field: %v", tlsFieldPath.Child("SubjectAltNames"))
 			}
 			if serverTLS.GetCredentialName() != "" {
-				notify(notifications.WarningNotification, fmt.Sprintf("ignoring field: %v", tlsFieldPath.Child("CredentialName")), gw)
+				notifyIgnoredField(c.conf, notifications.WarningNotification, tlsFieldPath.Child("CredentialName"), gw)
 				klog.Infof("ignoring field: %v", tlsFieldPath.Child("CredentialName"))
 			}
 			if len(serverTLS.GetVerifyCertificateSpki()) > 0 {
-				notify(notifications.WarningNotification, fmt.Sprintf("ignoring field: %v", tlsFieldPath.Child("VerifyCertificateSpki")), gw)
+				notifyIgnoredField(c.conf, notifications.WarningNotification, tlsFieldPath.Child("VerifyCertificateSpki"), gw)
 				klog.Infof("ignoring field: %v", tlsFieldPath.Child("VerifyCertificateSpki"))
 			}
 			if len(serverTLS.GetVerifyCertificateHash()) > 0 {
-				notify(notifications.WarningNotification, fmt.Sprintf("ignoring field: %v", tlsFieldPath.Child("VerifyCertificateHash")), gw)
+				notifyIgnoredField(c.conf, notifications.WarningNotification, tlsFieldPath.Child("VerifyCertificateHash"), gw)
 				klog.Infof("ignoring field: %v", tlsFieldPath.Child("VerifyCertificateHash"))
 			}
 			if serverTLS.GetMinProtocolVersion() != 0 {
-				notify(notifications.WarningNotification, fmt.Sprintf("ignoring field: %v", tlsFieldPath.Child("MinProtocolVersion")), gw)
+				notifyIgnoredField(c.conf, notifications.WarningNotification, tlsFieldPath.Child("MinProtocolVersion"), gw)
 				klog.Infof("ignoring field: %v", tlsFieldPath.Child("MinProtocolVersion"))
 			}
 			if serverTLS.GetMaxProtocolVersion() != 0 {
-				notify(notifications.WarningNotification, fmt.Sprintf("ignoring field: %v", tlsFieldPath.Child("MaxProtocolVersion")), gw)
+				notifyIgnoredField(c.conf, notifications.WarningNotification, tlsFieldPath.Child("MaxProtocolVersion"), gw)
 				klog.Infof("ignoring field: %v", tlsFieldPath.Child("MaxProtocolVersion"))
 			}
 			if len(serverTLS.GetCipherSuites()) > 0 {
-				notify(notifications.WarningNotification, fmt.Sprintf("ignoring field: %v", tlsFieldPath.Child("CipherSuites")), gw)
+				notifyIgnoredField(c.conf, notifications.WarningNotification, tlsFieldPath.Child("CipherSuites"), gw)
 				klog.Infof("ignoring field: %v", tlsFieldPath.Child("CipherSuites"))
 			}
 		}
 
 		if server.GetBind() != "" {
-			notify(notifications.WarningNotification, fmt.Sprintf("ignoring field: %v", serverFieldPath.Child("Bind").Key(server.GetBind())), gw)
+			notifyIgnoredField(c.conf, notifications.WarningNotification, serverFieldPath.Child("Bind").Key(server.GetBind()), gw)
 			klog.Infof("ignoring field: %v", serverFieldPath.Child("Bind").Key(server.GetBind()))
 		}
 
@@ -265,7 +272,7 @@ func (c *resourcesToIRConverter) convertGateway(gw *istioclientv1beta1.Gateway,
 			namespace, dnsName, ok := strings.Cut(host, "/")
 			if !ok {
 				// The default, if no `namespace/` is specified, is `*/`, that is, select services from any namespace.
-				notify(notifications.InfoNotification, fmt.Sprintf("no namespace specified for host \"%v\", selecting services from all namespaces", host), gw)
+				notify(c.conf, notifications.InfoNotification, fmt.Sprintf("no namespace specified for host \"%v\", selecting services from all namespaces", host), gw)
 				namespace, dnsName = "*", host
 			}
 
@@ -286,12 +293,21 @@ func (c *resourcesToIRConverter) convertGateway(gw *istioclientv1beta1.Gateway,
 			gwListenerName = strings.Replace(gwListenerName, "*", "wildcard", -1)
 
 			// listener name should match RFC 1123 subdomain requirement: lowercase alphanumeric characters, '-' or '.', and must start and end with a lowercase alphanumeric character
-			gwListener.Name = gatewayv1.SectionName(gwListenerName)
+			gwListener.Name = gatewayv1.SectionName(naming.Name(gwListenerName))
 
 			listeners = append(listeners, gwListener)
 		}
 	}
 
+	// the servers of different ports may have the same protocol and hosts
+	listenerNames := naming.NewScope()
+	for _, listener := range listeners {
+		listenerNames.Add(string(listener.Name), fmt.Sprintf("%v/%v", listener.Port, listener.Name))
+	}
+	for i, listener := range listeners {
+		listeners[i].Name = gatewayv1.SectionName(listenerNames.Name(string(listener.Name), fmt.Sprintf("%v/%v", listener.Port, listener.Name)))
+	}
+
 	if len(errList) > 0 {
 		return nil, errList
 	}
@@ -310,7 +326,7 @@ func (c *resourcesToIRConverter) convertGateway(gw *istioclientv1beta1.Gateway,
 			Namespace:       gw.Namespace,
 			Name:            gw.Name,
 			Labels:          gw.Labels,
-			Annotations:     gw.Annotations,
+			Annotations:     generatedAnnotations(gw.Annotations),
 			OwnerReferences: gw.OwnerReferences,
 			Finalizers:      gw.Finalizers,
 		},
@@ -320,7 +336,7 @@ func (c *resourcesToIRConverter) convertGateway(gw *istioclientv1beta1.Gateway,
 		},
 	}
 
-	notify(notifications.InfoNotification, fmt.Sprintf("successfully converted to Kubernetes Gateway \"%v/%v\"", gateway.Namespace, gateway.Name), gw)
+	notify(c.conf, notifications.InfoNotification, fmt.Sprintf("successfully converted to Kubernetes Gateway \"%v/%v\"", gateway.Namespace, gateway.Name), gw)
 
 	return &gateway, nil
 }
@@ -329,21 +345,21 @@ var hostnameRegexp = regexp.MustCompile(`^(\*\.)?[a-z0-9]([-a-z0-9]*[a-z0-9])?(\
 
 // convertHostnames set istio hostnames as is, without extra filters.
 // If it's not a fqdn, it would be rejected by K8S API implementation
-func convertHostnames(ctx context.Context, hosts []string, fieldPath *field.Path) []gatewayv1.Hostname {
+func (c *resourcesToIRConverter) convertHostnames(ctx context.Context, hosts []string, fieldPath *field.Path) []gatewayv1.Hostname {
 	var resHostnames []gatewayv1.Hostname
 	vs := ctx.Value(virtualServiceKey).(*istioclientv1beta1.VirtualService)
 	for i, host := range hosts {
 		// '*' is valid in istio, but not in HTTPRoute
 		hostsFieldPath := fieldPath.Child("Hosts").Key(fmt.Sprintf("%v", i))
 		if !hostnameRegexp.MatchString(host) {
-			notify(notifications.WarningNotification, fmt.Sprintf("ignoring host %s, which is not allowed in Gateway API HTTPRoute, path %v", host, hostsFieldPath), vs)
+			notify(c.conf, notifications.WarningNotification, fmt.Sprintf("ignoring host %s, which is not allowed in Gateway API HTTPRoute, path %v", host, hostsFieldPath), vs)
 			klog.Warningf("ignoring host %s, which is not allowed in Gateway API HTTPRoute", host)
 			continue
 		}
 
 		// IP addresses are not allowed in Gateway API
 		if net.ParseIP(host) != nil {
-			notify(notifications.WarningNotification, fmt.Sprintf("ignoring host %s, which is an IP address, path %v", host, hostsFieldPath), vs)
+			notify(c.conf, notifications.WarningNotification, fmt.Sprintf("ignoring host %s, which is an IP address, path %v", host, hostsFieldPath), vs)
 			klog.Warningf("ignoring host %s, which is an IP address", host)
 			continue
 		}
@@ -357,7 +373,7 @@ func (c *resourcesToIRConverter) convertVsHTTPRoutes(virtualService metav1.Objec
 	var errList field.ErrorList
 	var resHTTPRoutes []*gatewayv1.HTTPRoute
 
-	allowedHostnames := convertHostnames(c.ctx, istioHTTPHosts, fieldPath)
+	allowedHostnames := c.convertHostnames(c.ctx, istioHTTPHosts, fieldPath)
 	vs := c.ctx.Value(virtualServiceKey).(*istioclientv1beta1.VirtualService)
 
 	for i, httpRoute := range istioHTTPRoutes {
@@ -378,39 +394,39 @@ func (c *resourcesToIRConverter) convertVsHTTPRoutes(virtualService metav1.Objec
 			httpMatchFieldPath := httpRouteFieldPath.Child("HTTPMatchRequest").Key(httpMatchFieldName)
 
 			if match.GetScheme() != nil {
-				notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", httpMatchFieldPath.Child("Scheme").Key(match.GetScheme().String())), vs)
+				notifyIgnoredField(c.conf, notifications.InfoNotification, httpMatchFieldPath.Child("Scheme").Key(match.GetScheme().String()), vs)
 				klog.Infof("ignoring field: %v", httpMatchFieldPath.Child("Scheme").Key(match.GetScheme().String()))
 			}
 			if match.GetAuthority() != nil {
-				notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", httpMatchFieldPath.Child("Authority").Key(match.GetAuthority().String())), vs)
+				notifyIgnoredField(c.conf, notifications.InfoNotification, httpMatchFieldPath.Child("Authority").Key(match.GetAuthority().String()), vs)
 				klog.Infof("ignoring field: %v", httpMatchFieldPath.Child("Authority").Key(match.GetAuthority().String()))
 			}
 			if match.GetPort() != 0 {
-				notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", httpMatchFieldPath.Child("Port").Key(fmt.Sprintf("%v", match.GetPort()))), vs)
+				notifyIgnoredField(c.conf, notifications.InfoNotification, httpMatchFieldPath.Child("Port").Key(fmt.Sprintf("%v", match.GetPort())), vs)
 				klog.Infof("ignoring field: %v", httpMatchFieldPath.Child("Port").Key(fmt.Sprintf("%v", match.GetPort())))
 			}
 			if len(match.GetSourceLabels()) > 0 {
-				notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", httpMatchFieldPath.Child("SourceLabels")), vs)
+				notifyIgnoredField(c.conf, notifications.InfoNotification, httpMatchFieldPath.Child("SourceLabels"), vs)
 				klog.Infof("ignoring field: %v", httpMatchFieldPath.Child("SourceLabels"))
 			}
 			if match.GetIgnoreUriCase() {
-				notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", httpMatchFieldPath.Child("IgnoreUriCase")), vs)
+				notifyIgnoredField(c.conf, notifications.InfoNotification, httpMatchFieldPath.Child("IgnoreUriCase"), vs)
 				klog.Infof("ignoring field: %v", httpMatchFieldPath.Child("IgnoreUriCase"))
 			}
 			if len(match.GetWithoutHeaders()) > 0 {
-				notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", httpMatchFieldPath.Child("WithoutHeaders")), vs)
+				notifyIgnoredField(c.conf, notifications.InfoNotification, httpMatchFieldPath.Child("WithoutHeaders"), vs)
 				klog.Infof("ignoring field: %v", httpMatchFieldPath.Child("WithoutHeaders"))
 			}
 			if match.GetSourceNamespace() != "" {
-				notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", httpMatchFieldPath.Child("SourceNamespace")), vs)
+				notifyIgnoredField(c.conf, notifications.InfoNotification, httpMatchFieldPath.Child("SourceNamespace"), vs)
 				klog.Infof("ignoring field: %v", httpMatchFieldPath.Child("SourceNamespace"))
 			}
 			if match.GetStatPrefix() != "" {
-				notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", httpMatchFieldPath.Child("StatPrefix")), vs)
+				notifyIgnoredField(c.conf, notifications.InfoNotification, httpMatchFieldPath.Child("StatPrefix"), vs)
 				klog.Infof("ignoring field: %v", httpMatchFieldPath.Child("StatPrefix"))
 			}
 			if len(match.GetGateways()) > 0 {
-				notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", httpMatchFieldPath.Child("Gateways")), vs)
+				notifyIgnoredField(c.conf, notifications.InfoNotification, httpMatchFieldPath.Child("Gateways"), vs)
 				klog.Infof("ignoring field: %v", httpMatchFieldPath.Child("Gateways"))
 			}
 
@@ -433,7 +449,7 @@ func (c *resourcesToIRConverter) convertVsHTTPRoutes(virtualService metav1.Objec
 					matchType = gatewayv1.PathMatchRegularExpression
 					value = matchURI.GetRegex()
 				default:
-					notify(notifications.ErrorNotification, fmt.Sprintf("Unsupported Uri match type, path %v", httpMatchFieldPath.Child("Uri")), vs)
+					notify(c.conf, notifications.ErrorNotification, fmt.Sprintf("Unsupported Uri match type, path %v", httpMatchFieldPath.Child("Uri")), vs)
 					klog.Error(field.Invalid(httpMatchFieldPath.Child("Uri"), matchURI, "unsupported Uri match type %v"))
 				}
 
@@ -445,7 +461,8 @@ func (c *resourcesToIRConverter) convertVsHTTPRoutes(virtualService metav1.Objec
 				}
 			}
 
-			for header, headerMatch := range match.GetHeaders() {
+			for _, header := range sortedKeys(match.GetHeaders()) {
+				headerMatch := match.GetHeaders()[header]
 				var (
 					matchType gatewayv1.HeaderMatchType
 					value     string
@@ -459,7 +476,7 @@ func (c *resourcesToIRConverter) convertVsHTTPRoutes(virtualService metav1.Objec
 					matchType = gatewayv1.HeaderMatchRegularExpression
 					value = headerMatch.GetRegex()
 				default:
-					notify(notifications.ErrorNotification, fmt.Sprintf("Unsupported Headers match type, path %v", httpMatchFieldPath.Child("Headers")), vs)
+					notify(c.conf, notifications.ErrorNotification, fmt.Sprintf("Unsupported Headers match type, path %v", httpMatchFieldPath.Child("Headers")), vs)
 					klog.Error(field.Invalid(httpMatchFieldPath.Child("Headers"), headerMatch, "unsupported Headers match type"))
 				}
 
@@ -472,7 +489,8 @@ func (c *resourcesToIRConverter) convertVsHTTPRoutes(virtualService metav1.Objec
 				}
 			}
 
-			for query, queryMatch := range match.GetQueryParams() {
+			for _, query := range sortedKeys(match.GetQueryParams()) {
+				queryMatch := match.GetQueryParams()[query]
 				var (
 					matchType gatewayv1.QueryParamMatchType
 					value     string
@@ -486,7 +504,7 @@ func (c *resourcesToIRConverter) convertVsHTTPRoutes(virtualService metav1.Objec
 					matchType = gatewayv1.QueryParamMatchRegularExpression
 					value = queryMatch.GetRegex()
 				default:
-					notify(notifications.ErrorNotification, fmt.Sprintf("Unsupported QueryParams match type, path %v", httpMatchFieldPath.Child("QueryParams")), vs)
+					notify(c.conf, notifications.ErrorNotification, fmt.Sprintf("Unsupported QueryParams match type, path %v", httpMatchFieldPath.Child("QueryParams")), vs)
 					klog.Error(field.Invalid(httpMatchFieldPath.Child("QueryParams"), queryMatch, "unsupported QueryParams match type"))
 				}
 
@@ -504,7 +522,7 @@ func (c *resourcesToIRConverter) convertVsHTTPRoutes(virtualService metav1.Objec
 				case *istiov1beta1.StringMatch_Exact:
 					gwHTTPRouteMatch.Method = common.PtrTo[gatewayv1.HTTPMethod](gatewayv1.HTTPMethod(matchMethod.GetExact()))
 				default:
-					notify(notifications.ErrorNotification, fmt.Sprintf("Unsupported Method match type, path %v", httpMatchFieldPath.Child("Method")), vs)
+					notify(c.conf, notifications.ErrorNotification, fmt.Sprintf("Unsupported Method match type, path %v", httpMatchFieldPath.Child("Method")), vs)
 					klog.Error(field.Invalid(httpMatchFieldPath.Child("Method"), matchMethod, "unsupported Method match type"))
 				}
 			}
@@ -516,11 +534,11 @@ func (c *resourcesToIRConverter) convertVsHTTPRoutes(virtualService metav1.Objec
 			routeDestinationFieldPath := httpRouteFieldPath.Child("HTTPRouteDestination").Index(j)
 
 			if routeDestination.GetHeaders() != nil {
-				notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", routeDestinationFieldPath.Child("Headers")), vs)
+				notifyIgnoredField(c.conf, notifications.InfoNotification, routeDestinationFieldPath.Child("Headers"), vs)
 				klog.Infof("ignoring field: %v", routeDestinationFieldPath.Child("Headers"))
 			}
 
-			backendObjRef := destination2backendObjRef(c.ctx, routeDestination.GetDestination(), virtualService.Namespace, routeDestinationFieldPath)
+			backendObjRef := c.destination2backendObjRef(c.ctx, routeDestination.GetDestination(), virtualService.Namespace, routeDestinationFieldPath)
 			if backendObjRef != nil {
 				backendRefs = append(backendRefs, gatewayv1.HTTPBackendRef{
 					BackendRef: gatewayv1.BackendRef{
@@ -535,11 +553,11 @@ func (c *resourcesToIRConverter) convertVsHTTPRoutes(virtualService metav1.Objec
 			redirectFieldPath := httpRouteFieldPath.Child("HTTPRedirect")
 
 			if routeRedirect.GetAuthority() != "" {
-				notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", redirectFieldPath.Child("Authority")), vs)
+				notifyIgnoredField(c.conf, notifications.InfoNotification, redirectFieldPath.Child("Authority"), vs)
 				klog.Infof("ignoring field: %v", redirectFieldPath.Child("Authority"))
 			}
 			if _, ok := routeRedirect.GetRedirectPort().(*istiov1beta1.HTTPRedirect_DerivePort); ok {
-				notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", redirectFieldPath.Child("DerivePort")), vs)
+				notifyIgnoredField(c.conf, notifications.InfoNotification, redirectFieldPath.Child("DerivePort"), vs)
 				klog.Infof("ignoring field: %v", redirectFieldPath.Child("DerivePort"))
 			}
 
@@ -578,23 +596,23 @@ func (c *resourcesToIRConverter) convertVsHTTPRoutes(virtualService metav1.Objec
 		}
 
 		if httpRoute.GetDirectResponse() != nil {
-			notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", httpRouteFieldPath.Child("DirectResponse")), vs)
+			notifyIgnoredField(c.conf, notifications.InfoNotification, httpRouteFieldPath.Child("DirectResponse"), vs)
 			klog.Infof("ignoring field: %v", httpRouteFieldPath.Child("DirectResponse"))
 		}
 		if httpRoute.GetDelegate() != nil {
-			notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", httpRouteFieldPath.Child("Delegate")), vs)
+			notifyIgnoredField(c.conf, notifications.InfoNotification, httpRouteFieldPath.Child("Delegate"), vs)
 			klog.Infof("ignoring field: %v", httpRouteFieldPath.Child("Delegate"))
 		}
 		if httpRoute.GetRetries() != nil {
-			notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", httpRouteFieldPath.Child("Retries")), vs)
+			notifyIgnoredField(c.conf, notifications.InfoNotification, httpRouteFieldPath.Child("Retries"), vs)
 			klog.Infof("ignoring field: %v", httpRouteFieldPath.Child("Retries"))
 		}
 		if httpRoute.GetFault() != nil {
-			notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", httpRouteFieldPath.Child("Fault")), vs)
+			notifyIgnoredField(c.conf, notifications.InfoNotification, httpRouteFieldPath.Child("Fault"), vs)
 			klog.Infof("ignoring field: %v", httpRouteFieldPath.Child("Fault"))
 		}
 		if httpRoute.GetCorsPolicy() != nil {
-			notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", httpRouteFieldPath.Child("CorsPolicy")), vs)
+			notifyIgnoredField(c.conf, notifications.InfoNotification, httpRouteFieldPath.Child("CorsPolicy"), vs)
 			klog.Infof("ignoring field: %v", httpRouteFieldPath.Child("CorsPolicy"))
 		}
 
@@ -606,7 +624,7 @@ func (c *resourcesToIRConverter) convertVsHTTPRoutes(virtualService metav1.Objec
 		if mirror := httpRoute.GetMirror(); mirror != nil {
 			routeDestinationFieldPath := httpRouteFieldPath.Child("Mirror")
 
-			backendObjRef := destination2backendObjRef(c.ctx, mirror, virtualService.Namespace, routeDestinationFieldPath)
+			backendObjRef := c.destination2backendObjRef(c.ctx, mirror, virtualService.Namespace, routeDestinationFieldPath)
 			if backendObjRef != nil {
 				gwHTTPRouteFilters = append(gwHTTPRouteFilters, gatewayv1.HTTPRouteFilter{
 					Type: gatewayv1.HTTPRouteFilterRequestMirror,
@@ -621,11 +639,11 @@ func (c *resourcesToIRConverter) convertVsHTTPRoutes(virtualService metav1.Objec
 			routeDestinationFieldPath := httpRouteFieldPath.Child("Mirrors").Index(j)
 
 			if mirror.GetPercentage() != nil {
-				notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", routeDestinationFieldPath.Child("Percentage")), vs)
+				notifyIgnoredField(c.conf, notifications.InfoNotification, routeDestinationFieldPath.Child("Percentage"), vs)
 				klog.Infof("ignoring field: %v", routeDestinationFieldPath.Child("Percentage"))
 			}
 
-			backendObjRef := destination2backendObjRef(c.ctx, mirror.GetDestination(), virtualService.Namespace, routeDestinationFieldPath)
+			backendObjRef := c.destination2backendObjRef(c.ctx, mirror.GetDestination(), virtualService.Namespace, routeDestinationFieldPath)
 			if backendObjRef != nil {
 				gwHTTPRouteFilters = append(gwHTTPRouteFilters, gatewayv1.HTTPRouteFilter{
 					Type: gatewayv1.HTTPRouteFilterRequestMirror,
@@ -668,9 +686,9 @@ func (c *resourcesToIRConverter) convertVsHTTPRoutes(virtualService metav1.Objec
 			}
 		}
 
-		routeName := fmt.Sprintf("%v-idx-%v", virtualService.Name, i)
+		routeName := naming.Name(virtualService.Name, "idx", strconv.Itoa(i))
 		if httpRoute.GetName() != "" {
-			routeName = fmt.Sprintf("%v-%v", virtualService.Name, httpRoute.GetName())
+			routeName = naming.Name(virtualService.Name, httpRoute.GetName())
 		}
 
 		createHTTPRouteParams := createHTTPRouteParams{
@@ -678,7 +696,7 @@ func (c *resourcesToIRConverter) convertVsHTTPRoutes(virtualService metav1.Objec
 				Namespace:       virtualService.Namespace,
 				Name:            routeName,
 				Labels:          virtualService.Labels,
-				Annotations:     virtualService.Annotations,
+				Annotations:     generatedAnnotations(virtualService.Annotations),
 				OwnerReferences: virtualService.OwnerReferences,
 				Finalizers:      virtualService.Finalizers,
 			},
@@ -693,14 +711,14 @@ func (c *resourcesToIRConverter) convertVsHTTPRoutes(virtualService metav1.Objec
 			httpRoutesWithRewrites := c.createHTTPRoutesWithRewrite(createHTTPRouteParams, httpRoute.GetRewrite(), httpRouteFieldPath.Child("HTTPRewrite"))
 			resHTTPRoutes = append(resHTTPRoutes, httpRoutesWithRewrites...)
 			for _, httpRoute := range httpRoutesWithRewrites {
-				notify(notifications.InfoNotification, fmt.Sprintf("successfully converted to HTTPRoute \"%v/%v\"", httpRoute.Namespace, httpRoute.Name), vs)
+				notify(c.conf, notifications.InfoNotification, fmt.Sprintf("successfully converted to HTTPRoute \"%v/%v\"", httpRoute.Namespace, httpRoute.Name), vs)
 			}
 			continue
 		}
 
 		httpRoute := c.createHTTPRoute(createHTTPRouteParams)
 		resHTTPRoutes = append(resHTTPRoutes, httpRoute)
-		notify(notifications.InfoNotification, fmt.Sprintf("successfully converted to HTTPRoute \"%v/%v\"", httpRoute.Namespace, httpRoute.Name), vs)
+		notify(c.conf, notifications.InfoNotification, fmt.Sprintf("successfully converted to HTTPRoute \"%v/%v\"", httpRoute.Namespace, httpRoute.Name), vs)
 	}
 
 	if len(errList) > 0 {
@@ -761,11 +779,11 @@ func (c *resourcesToIRConverter) createHTTPRoutesWithRewrite(params createHTTPRo
 	}
 
 	if rewrite.GetAuthority() != "" {
-		notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", fieldPath.Child("Authority")), vs)
+		notifyIgnoredField(c.conf, notifications.InfoNotification, fieldPath.Child("Authority"), vs)
 		klog.Infof("ignoring field: %v", fieldPath.Child("Authority"))
 	}
 	if rewrite.GetUriRegexRewrite() != nil {
-		notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", fieldPath.Child("UriRegexRewrite")), vs)
+		notifyIgnoredField(c.conf, notifications.InfoNotification, fieldPath.Child("UriRegexRewrite"), vs)
 		klog.Infof("ignoring field: %v", fieldPath.Child("UriRegexRewrite"))
 	}
 
@@ -834,7 +852,7 @@ func (c *resourcesToIRConverter) convertVsTLSRoutes(virtualService metav1.Object
 
 		var backendRefs []gatewayv1.BackendRef
 		for _, destination := range route.GetRoute() {
-			backendObjRef := destination2backendObjRef(c.ctx, destination.GetDestination(), virtualService.Namespace, tlsRouteFieldPath)
+			backendObjRef := c.destination2backendObjRef(c.ctx, destination.GetDestination(), virtualService.Namespace, tlsRouteFieldPath)
 			if backendObjRef != nil {
 				backendRefs = append(backendRefs, gatewayv1.BackendRef{
 					BackendObjectReference: *backendObjRef,
@@ -853,30 +871,30 @@ func (c *resourcesToIRConverter) convertVsTLSRoutes(virtualService metav1.Object
 			tlsMatchFieldPath := tlsRouteFieldPath.Child("TLSMatchAttributes").Index(j)
 
 			if len(match.GetDestinationSubnets()) > 0 {
-				notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", tlsMatchFieldPath.Child("DestinationSubnets")), vs)
+				notifyIgnoredField(c.conf, notifications.InfoNotification, tlsMatchFieldPath.Child("DestinationSubnets"), vs)
 				klog.Infof("ignoring field: %v", tlsMatchFieldPath.Child("DestinationSubnets"))
 			}
 			if match.GetPort() != 0 {
-				notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", tlsMatchFieldPath.Child("Port")), vs)
+				notifyIgnoredField(c.conf, notifications.InfoNotification, tlsMatchFieldPath.Child("Port"), vs)
 				klog.Infof("ignoring field: %v", tlsMatchFieldPath.Child("Port"))
 			}
 			if len(match.GetSourceLabels()) > 0 {
-				notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", tlsMatchFieldPath.Child("SourceLabels")), vs)
+				notifyIgnoredField(c.conf, notifications.InfoNotification, tlsMatchFieldPath.Child("SourceLabels"), vs)
 				klog.Infof("ignoring field: %v", tlsMatchFieldPath.Child("SourceLabels"))
 			}
 			if len(match.GetGateways()) > 0 {
-				notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", tlsMatchFieldPath.Child("Gateways")), vs)
+				notifyIgnoredField(c.conf, notifications.InfoNotification, tlsMatchFieldPath.Child("Gateways"), vs)
 				klog.Infof("ignoring field: %v", tlsMatchFieldPath.Child("Gateways"))
 			}
 			if match.GetSourceNamespace() != "" {
-				notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", tlsMatchFieldPath.Child("SourceNamespace")), vs)
+				notifyIgnoredField(c.conf, notifications.InfoNotification, tlsMatchFieldPath.Child("SourceNamespace"), vs)
 				klog.Infof("ignoring field: %v", tlsMatchFieldPath.Child("SourceNamespace"))
 			}
 		}
 
 		apiVersion, kind := common.TLSRouteGVK.ToAPIVersionAndKind()
 
-		routeName := fmt.Sprintf("%v-idx-%v", virtualService.Name, i)
+		routeName := naming.Name(virtualService.Name, "idx", strconv.Itoa(i))
 
 		tlsRoute := &gatewayv1alpha2.TLSRoute{
 			TypeMeta: metav1.TypeMeta{
@@ -887,7 +905,7 @@ func (c *resourcesToIRConverter) convertVsTLSRoutes(virtualService metav1.Object
 				Namespace:       virtualService.Namespace,
 				Name:            routeName,
 				Labels:          virtualService.Labels,
-				Annotations:     virtualService.Annotations,
+				Annotations:     generatedAnnotations(virtualService.Annotations),
 				OwnerReferences: virtualService.OwnerReferences,
 				Finalizers:      virtualService.Finalizers,
 			},
@@ -901,7 +919,7 @@ func (c *resourcesToIRConverter) convertVsTLSRoutes(virtualService metav1.Object
 			},
 		}
 		resTLSRoutes = append(resTLSRoutes, tlsRoute)
-		notify(notifications.InfoNotification, fmt.Sprintf("successfully converted to TLSRoute \"%v/%v\"", tlsRoute.Namespace, tlsRoute.Name), vs)
+		notify(c.conf, notifications.InfoNotification, fmt.Sprintf("successfully converted to TLSRoute \"%v/%v\"", tlsRoute.Namespace, tlsRoute.Name), vs)
 	}
 
 	return resTLSRoutes
@@ -916,7 +934,7 @@ func (c *resourcesToIRConverter) convertVsTCPRoutes(virtualService metav1.Object
 
 		var backendRefs []gatewayv1.BackendRef
 		for _, destination := range route.GetRoute() {
-			backendObjRef := destination2backendObjRef(c.ctx, destination.GetDestination(), virtualService.Namespace, tcpRouteFieldPath)
+			backendObjRef := c.destination2backendObjRef(c.ctx, destination.GetDestination(), virtualService.Namespace, tcpRouteFieldPath)
 			if backendObjRef != nil {
 				backendRefs = append(backendRefs, gatewayv1.BackendRef{
 					BackendObjectReference: *backendObjRef,
@@ -929,34 +947,34 @@ func (c *resourcesToIRConverter) convertVsTCPRoutes(virtualService metav1.Object
 			tcpMatchFieldPath := tcpRouteFieldPath.Child("L4MatchAttributes").Index(j)
 
 			if len(match.GetDestinationSubnets()) > 0 {
-				notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", tcpMatchFieldPath.Child("DestinationSubnets")), vs)
+				notifyIgnoredField(c.conf, notifications.InfoNotification, tcpMatchFieldPath.Child("DestinationSubnets"), vs)
 				klog.Infof("ignoring field: %v", tcpMatchFieldPath.Child("DestinationSubnets"))
 			}
 			if match.GetPort() != 0 {
-				notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", tcpMatchFieldPath.Child("Port")), vs)
+				notifyIgnoredField(c.conf, notifications.InfoNotification, tcpMatchFieldPath.Child("Port"), vs)
 				klog.Infof("ignoring field: %v", tcpMatchFieldPath.Child("Port"))
 			}
 			if match.GetSourceSubnet() != "" {
-				notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", tcpMatchFieldPath.Child("SourceSubnet")), vs)
+				notifyIgnoredField(c.conf, notifications.InfoNotification, tcpMatchFieldPath.Child("SourceSubnet"), vs)
 				klog.Infof("ignoring field: %v", tcpMatchFieldPath.Child("SourceSubnet"))
 			}
 			if len(match.GetSourceLabels()) > 0 {
-				notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", tcpMatchFieldPath.Child("SourceLabels")), vs)
+				notifyIgnoredField(c.conf, notifications.InfoNotification, tcpMatchFieldPath.Child("SourceLabels"), vs)
 				klog.Infof("ignoring field: %v", tcpMatchFieldPath.Child("SourceLabels"))
 			}
 			if match.GetSourceNamespace() != "" {
-				notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", tcpMatchFieldPath.Child("SourceNamespace")), vs)
+				notifyIgnoredField(c.conf, notifications.InfoNotification, tcpMatchFieldPath.Child("SourceNamespace"), vs)
 				klog.Infof("ignoring field: %v", tcpMatchFieldPath.Child("SourceNamespace"))
 			}
 			if len(match.GetGateways()) > 0 {
-				notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", tcpMatchFieldPath.Child("Gateways")), vs)
+				notifyIgnoredField(c.conf, notifications.InfoNotification, tcpMatchFieldPath.Child("Gateways"), vs)
 				klog.Infof("ignoring field: %v", tcpMatchFieldPath.Child("Gateways"))
 			}
 		}
 
 		apiVersion, kind := common.TCPRouteGVK.ToAPIVersionAndKind()
 
-		routeName := fmt.Sprintf("%v-idx-%v", virtualService.Name, i)
+		routeName := naming.Name(virtualService.Name, "idx", strconv.Itoa(i))
 
 		tcpRoute := &gatewayv1alpha2.TCPRoute{
 			TypeMeta: metav1.TypeMeta{
@@ -967,7 +985,7 @@ func (c *resourcesToIRConverter) convertVsTCPRoutes(virtualService metav1.Object
 				Namespace:       virtualService.Namespace,
 				Name:            routeName,
 				Labels:          virtualService.Labels,
-				Annotations:     virtualService.Annotations,
+				Annotations:     generatedAnnotations(virtualService.Annotations),
 				OwnerReferences: virtualService.OwnerReferences,
 				Finalizers:      virtualService.Finalizers,
 			},
@@ -980,7 +998,7 @@ func (c *resourcesToIRConverter) convertVsTCPRoutes(virtualService metav1.Object
 			},
 		}
 		resTCPRoutes = append(resTCPRoutes, tcpRoute)
-		notify(notifications.InfoNotification, fmt.Sprintf("successfully converted to TCPRoute \"%v/%v\"", tcpRoute.Namespace, tcpRoute.Name), vs)
+		notify(c.conf, notifications.InfoNotification, fmt.Sprintf("successfully converted to TCPRoute \"%v/%v\"", tcpRoute.Namespace, tcpRoute.Name), vs)
 	}
 
 	return resTCPRoutes
@@ -995,14 +1013,14 @@ func (c *resourcesToIRConverter) isVirtualServiceAllowedForGateway(gateway types
 
 	isAllowedNamespace := vsAllowedNamespaces.HasAny(gateway.Namespace, "*") || (vsAllowedNamespaces.Has(".") && vs.Namespace == gateway.Namespace)
 	if !isAllowedNamespace {
-		notify(notifications.WarningNotification, fmt.Sprintf("gateway from vs.Spec.Gateways %q is not visible in vs.ExportTo %v, parentRefs are not generated for this host, path: %v", gateway.String(), vs.Spec.GetExportTo(), fieldPath), vs)
+		notify(c.conf, notifications.WarningNotification, fmt.Sprintf("gateway from vs.Spec.Gateways %q is not visible in vs.ExportTo %v, parentRefs are not generated for this host, path: %v", gateway.String(), vs.Spec.GetExportTo(), fieldPath), vs)
 		klog.Warningf("gateway from vs.Spec.Gateways %q is not visible in vs.ExportTo %v, parentRefs are not generated for this host, path: %v", gateway.String(), vs.Spec.GetExportTo(), fieldPath)
 		return false
 	}
 
 	allowedHosts, ok := c.gwAllowedHosts[gateway]
 	if !ok {
-		notify(notifications.WarningNotification, fmt.Sprintf("no info about gateway %v allowed hosts, parentRefs won't be generated to it, path: %v", gateway.String(), fieldPath), vs)
+		notify(c.conf, notifications.WarningNotification, fmt.Sprintf("no info about gateway %v allowed hosts, parentRefs won't be generated to it, path: %v", gateway.String(), fieldPath), vs)
 		klog.Warningf("no info about gateway %v allowed hosts, parentRefs won't be generated to it, path: %v", gateway.String(), fieldPath)
 		return false
 	}
@@ -1010,24 +1028,24 @@ func (c *resourcesToIRConverter) isVirtualServiceAllowedForGateway(gateway types
 	for _, host := range vs.Spec.GetHosts() {
 		hosts, ok := allowedHosts[vs.Namespace]
 		if ok && matchAny(hosts.UnsortedList(), host) {
-			notify(notifications.InfoNotification, fmt.Sprintf("host for gateway \"%v\" matched from same namespace as VirtualService \"%v\", namesapce: %v", gateway, vs.Name, vs.Namespace), vs)
+			notify(c.conf, notifications.InfoNotification, fmt.Sprintf("host for gateway \"%v\" matched from same namespace as VirtualService \"%v\", namesapce: %v", gateway, vs.Name, vs.Namespace), vs)
 			return true
 		}
 
 		hosts, ok = allowedHosts["."]
 		if ok && vs.Namespace == gateway.Namespace && matchAny(hosts.UnsortedList(), host) {
-			notify(notifications.InfoNotification, fmt.Sprintf("host for gateway \"%v\" matched from the current namespace", gateway), vs)
+			notify(c.conf, notifications.InfoNotification, fmt.Sprintf("host for gateway \"%v\" matched from the current namespace", gateway), vs)
 			return true
 		}
 
 		hosts, ok = allowedHosts["*"]
 		if ok && matchAny(hosts.UnsortedList(), host) {
-			notify(notifications.InfoNotification, fmt.Sprintf("host for gateway \"%v\" matched from all namespaces", gateway), vs)
+			notify(c.conf, notifications.InfoNotification, fmt.Sprintf("host for gateway \"%v\" matched from all namespaces", gateway), vs)
 			return true
 		}
 	}
 
-	notify(notifications.WarningNotification, fmt.Sprintf("no host in vs.Spec.Hosts matched any gateway.allowedHosts, parentRefs are not generated for this VirtualService, path: %v", fieldPath), vs)
+	notify(c.conf, notifications.WarningNotification, fmt.Sprintf("no host in vs.Spec.Hosts matched any gateway.allowedHosts, parentRefs are not generated for this VirtualService, path: %v", fieldPath), vs)
 	klog.Warningf("no host in vs.Spec.Hosts matched any gateway.allowedHosts, parentRefs are not generated for this VirtualService, path: %v", fieldPath)
 	return false
 }
@@ -1056,7 +1074,7 @@ func (c *resourcesToIRConverter) generateReferences(vs *istioclientv1beta1.Virtu
 		}
 
 		if !ok {
-			notify(notifications.InfoNotification, fmt.Sprintf("namespace of \"%v\" gateway taken from namesapce of VirtualService", gwName), vs)
+			notify(c.conf, notifications.InfoNotification, fmt.Sprintf("namespace of \"%v\" gateway taken from namesapce of VirtualService", gwName), vs)
 		}
 
 		g := gatewayv1.Group(common.GatewayGVK.Group)
@@ -1081,11 +1099,11 @@ func (c *resourcesToIRConverter) generateReferences(vs *istioclientv1beta1.Virtu
 			})
 
 			referenceGrants = append(referenceGrants, referenceGrant)
-			notify(notifications.InfoNotification, fmt.Sprintf("successfully created reference grant from %v to %v namespace", vs.Namespace, gateway.Namespace), vs, referenceGrant)
+			notify(c.conf, notifications.InfoNotification, fmt.Sprintf("successfully created reference grant from %v to %v namespace", vs.Namespace, gateway.Namespace), vs, referenceGrant)
 		}
 
 		parentRefs = append(parentRefs, parentRef)
-		notify(notifications.InfoNotification, fmt.Sprintf("generated new Parent Reference %v", parentRef.Name), vs)
+		notify(c.conf, notifications.InfoNotification, fmt.Sprintf("generated new Parent Reference %v", parentRef.Name), vs)
 	}
 
 	return parentRefs, referenceGrants
@@ -1148,6 +1166,24 @@ func (c *resourcesToIRConverter) generateReferenceGrant(params generateReference
 	}
 }
 
+// generatedAnnotations returns the annotations copied from an istio object to
+// the objects generated from it, leaving out the ones added by the reader.
+func generatedAnnotations(annotations map[string]string) map[string]string {
+	if _, ok := annotations[notifications.SourceFileAnnotationKey]; !ok {
+		return annotations
+	}
+	res := make(map[string]string, len(annotations)-1)
+	for k, v := range annotations {
+		if k != notifications.SourceFileAnnotationKey {
+			res[k] = v
+		}
+	}
+	if len(res) == 0 {
+		return nil
+	}
+	return res
+}
+
 func parseK8SServiceFromDomain(domain string, fallbackNamespace string) (string, string) {
 	ns := "default"
 	if fallbackNamespace != "" {
@@ -1166,16 +1202,16 @@ func parseK8SServiceFromDomain(domain string, fallbackNamespace string) (string,
 	return name, namespace
 }
 
-func destination2backendObjRef(ctx context.Context, destination *istiov1beta1.Destination, vsNamespace string, fieldPath *field.Path) *gatewayv1.BackendObjectReference {
+func (c *resourcesToIRConverter) destination2backendObjRef(ctx context.Context, destination *istiov1beta1.Destination, vsNamespace string, fieldPath *field.Path) *gatewayv1.BackendObjectReference {
 	vs := ctx.Value(virtualServiceKey).(*istioclientv1beta1.VirtualService)
 	if destination == nil {
-		notify(notifications.InfoNotification, fmt.Sprintf("destination is nil: %v", fieldPath), vs)
+		notify(c.conf, notifications.InfoNotification, fmt.Sprintf("destination is nil: %v", fieldPath), vs)
 		klog.Infof("destination is nil: %v", fieldPath)
 		return nil
 	}
 
 	if destination.GetSubset() != "" {
-		notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", fieldPath.Child("Destination", "Subset")), vs)
+		notifyIgnoredField(c.conf, notifications.InfoNotification, fieldPath.Child("Destination", "Subset"), vs)
 		klog.Infof("ignoring field: %v", fieldPath.Child("Destination", "Subset"))
 	}
 
@@ -1204,16 +1240,27 @@ func makeHeaderFilter(headers map[string]string) []gatewayv1.HTTPHeader {
 
 	res := make([]gatewayv1.HTTPHeader, 0, len(headers))
 
-	for header, value := range headers {
+	for _, header := range sortedKeys(headers) {
 		res = append(res, gatewayv1.HTTPHeader{
 			Name:  gatewayv1.HTTPHeaderName(header),
-			Value: value,
+			Value: headers[header],
 		})
 	}
 
 	return res
 }
 
+// sortedKeys returns the keys of m in a sorted order, so that the fields built
+// from Istio maps are generated in the same order on every run.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
 // checks if host overlaps with any of the hosts
 func matchAny(hosts []string, host string) bool {
 	for _, h := range hosts {
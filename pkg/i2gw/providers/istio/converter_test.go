@@ -326,7 +326,7 @@ func Test_resourcesToIRConverter_convertGateway(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			c := newResourcesToIRConverter()
+			c := newResourcesToIRConverter(nil)
 			got, errList := c.convertGateway(tt.args.gw, field.NewPath(""))
 			if tt.wantError && len(errList) == 0 {
 				t.Errorf("resourcesToIRConverter.convertGateway().errList = %+v, wantError %+v", errList, tt.wantError)
@@ -2181,7 +2181,7 @@ func Test_convertHostnames(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			ctx := context.WithValue(context.Background(), virtualServiceKey, tc.virtualService)
-			actual := convertHostnames(ctx, tc.hostnames, field.NewPath(""))
+			actual := (&resourcesToIRConverter{}).convertHostnames(ctx, tc.hostnames, field.NewPath(""))
 			if !apiequality.Semantic.DeepEqual(actual, tc.expected) {
 				t.Errorf("convertHostnames() = %v, want %v", actual, tc.expected)
 			}
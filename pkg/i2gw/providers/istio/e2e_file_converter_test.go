@@ -49,9 +49,13 @@ func TestFileConversion(t *testing.T) {
 			return nil
 		}
 
-		istioProvider := NewProvider(&i2gw.ProviderConf{})
+		inputFiles, err := i2gw.ReadInputFiles([]string{path}, nil)
+		if err != nil {
+			t.Fatalf("Failed to read input file %v: %v", d.Name(), err.Error())
+		}
+		istioProvider := NewProvider(&i2gw.ProviderConf{InputFiles: inputFiles})
 
-		err = istioProvider.ReadResourcesFromFile(ctx, path)
+		err = istioProvider.ReadResourcesFromFiles(ctx, []string{path})
 		if err != nil {
 			t.Fatalf("Failed to read input from file %v: %v", d.Name(), err.Error())
 		}
@@ -23,6 +23,7 @@ import (
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/intermediate"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/providers/common"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
@@ -31,6 +32,10 @@ const ProviderName = "istio"
 
 func init() {
 	i2gw.ProviderConstructorByName[ProviderName] = NewProvider
+	i2gw.RegisterProviderResourceKinds(ProviderName,
+		schema.FromAPIVersionAndKind(APIVersion, GatewayKind),
+		schema.FromAPIVersionAndKind(APIVersion, VirtualServiceKind),
+	)
 }
 
 type Provider struct {
@@ -44,7 +49,7 @@ func NewProvider(conf *i2gw.ProviderConf) i2gw.Provider {
 	return &Provider{
 		storage:                newResourcesStorage(),
 		reader:                 newResourceReader(conf),
-		resourcesToIRConverter: newResourcesToIRConverter(),
+		resourcesToIRConverter: newResourcesToIRConverter(conf),
 	}
 }
 
@@ -68,8 +73,8 @@ func (p *Provider) ReadResourcesFromCluster(ctx context.Context) error {
 	return nil
 }
 
-func (p *Provider) ReadResourcesFromFile(ctx context.Context, filename string) error {
-	storage, err := p.reader.readResourcesFromFile(ctx, filename)
+func (p *Provider) ReadResourcesFromFiles(ctx context.Context, filenames []string) error {
+	storage, err := p.reader.readResourcesFromFiles(ctx, filenames)
 	if err != nil {
 		return fmt.Errorf("failed to read resources from file: %w", err)
 	}
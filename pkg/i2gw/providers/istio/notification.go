@@ -17,11 +17,23 @@ limitations under the License.
 package istio
 
 import (
+	"fmt"
+
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func notify(mType notifications.MessageType, message string, callingObject ...client.Object) {
+func notify(conf *i2gw.ProviderConf, mType notifications.MessageType, message string, callingObject ...client.Object) {
 	newNotification := notifications.NewNotification(mType, message, callingObject...)
-	notifications.NotificationAggr.DispatchNotification(newNotification, string(ProviderName))
+	conf.Notify(ProviderName, newNotification)
+}
+
+// notifyIgnoredField notifies that the field at fieldPath of the calling
+// objects has no equivalent in the generated objects.
+func notifyIgnoredField(conf *i2gw.ProviderConf, mType notifications.MessageType, fieldPath *field.Path, callingObject ...client.Object) {
+	newNotification := notifications.NewNotification(mType, fmt.Sprintf("ignoring field: %v", fieldPath), callingObject...)
+	newNotification.FieldPath = fieldPath.String()
+	conf.Notify(ProviderName, newNotification)
 }
@@ -17,11 +17,9 @@ limitations under the License.
 package istio
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 	"log"
-	"os"
 
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/providers/common"
@@ -61,15 +59,10 @@ func (r *reader) readResourcesFromCluster(ctx context.Context) (*storage, error)
 	return res, nil
 }
 
-func (r *reader) readResourcesFromFile(_ context.Context, filename string) (*storage, error) {
-	stream, err := os.ReadFile(filename)
+func (r *reader) readResourcesFromFiles(_ context.Context, filenames []string) (*storage, error) {
+	unstructuredObjects, err := common.ReadObjectsFromFiles(r.conf, filenames)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read file %v: %w", filename, err)
-	}
-
-	unstructuredObjects, err := common.ExtractObjectsFromReader(bytes.NewReader(stream), r.conf.Namespace)
-	if err != nil {
-		return nil, fmt.Errorf("failed to extract objects: %w", err)
+		return nil, err
 	}
 
 	storage, err := r.readUnstructuredObjects(unstructuredObjects)
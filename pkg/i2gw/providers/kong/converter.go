@@ -28,13 +28,16 @@ import (
 
 // resourcesToIRConverter implements the ToIR function of i2gw.ResourcesToIRConverter interface.
 type resourcesToIRConverter struct {
+	conf *i2gw.ProviderConf
+
 	featureParsers                []i2gw.FeatureParser
 	implementationSpecificOptions i2gw.ProviderImplementationSpecificOptions
 }
 
 // newResourcesToIRConverter returns an kong converter instance.
-func newResourcesToIRConverter() *resourcesToIRConverter {
+func newResourcesToIRConverter(conf *i2gw.ProviderConf) *resourcesToIRConverter {
 	return &resourcesToIRConverter{
+		conf: conf,
 		featureParsers: []i2gw.FeatureParser{
 			headerMatchingFeature,
 			methodMatchingFeature,
@@ -51,37 +54,38 @@ func (c *resourcesToIRConverter) convert(storage *storage) (intermediate.IR, fie
 	for _, ingress := range storage.Ingresses {
 		ingressList = append(ingressList, *ingress)
 	}
+	ingressList = common.ResolveServicePorts(c.conf, Name, ingressList, storage.Services)
+	common.NotifyDefaultBackendConflicts(c.conf, Name, ingressList)
 
 	// Convert plain ingress resources to gateway resources, ignoring all
 	// provider-specific features.
 	ir, errorList := common.ToIR(ingressList, c.implementationSpecificOptions)
-	if len(errorList) > 0 {
+	if len(errorList) > 0 && !c.conf.BestEffort {
 		return intermediate.IR{}, errorList
 	}
+	common.NotifyUnusedTLSHosts(c.conf, Name, ingressList, &ir)
 
 	tcpGatewayIR, notificationsAggregator, errs := crds.TCPIngressToGatewayIR(storage.TCPIngresses)
 	if len(errs) > 0 {
 		errorList = append(errorList, errs...)
 	}
 
-	dispatchNotification(notificationsAggregator)
+	dispatchNotification(c.conf, notificationsAggregator)
 
-	if len(errorList) > 0 {
+	if len(errorList) > 0 && !c.conf.BestEffort {
 		return intermediate.IR{}, errorList
 	}
 
 	ir, errs = intermediate.MergeIRs(ir, tcpGatewayIR)
 
 	if len(errs) > 0 {
-		return intermediate.IR{}, errs
+		return intermediate.IR{}, append(errorList, errs...)
 	}
 
-	for _, parseFeatureFunc := range c.featureParsers {
-		// Apply the feature parsing function to the gateway resources, one by one.
-		errs = parseFeatureFunc(ingressList, &ir)
-		// Append the parsing errors to the error list.
-		errorList = append(errorList, errs...)
-	}
+	c.conf.TraceIR(Name, i2gw.TraceStageCommonToIR, ir)
+
+	// Apply the feature parsing functions to the gateway resources, one by one.
+	errorList = append(errorList, i2gw.ParseFeatures(c.conf, Name, c.featureParsers, ingressList, &ir)...)
 
 	return ir, errorList
 }
@@ -34,6 +34,8 @@ type tcpIngressRuleGroup struct {
 	port         int
 	tls          []kongv1beta1.IngressTLS
 	rules        []ingressRule
+	// sources holds the names of the TCPIngresses contributing to the group.
+	sources []string
 }
 
 type ingressRule struct {
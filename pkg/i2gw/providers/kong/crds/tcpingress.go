@@ -18,10 +18,12 @@ package crds
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/intermediate"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/naming"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/providers/common"
 	networkingv1beta1 "k8s.io/api/networking/v1beta1"
@@ -74,6 +76,7 @@ func TCPIngressToGatewayIR(ingresses []kongv1beta1.TCPIngress) (intermediate.IR,
 		Gateways:  gatewayByKey,
 		TCPRoutes: tcpRouteByKey,
 		TLSRoutes: tlsRouteByKey,
+		Sources:   aggregator.sources(),
 	}, notificationsAggregator, nil
 }
 
@@ -110,6 +113,86 @@ func (a *tcpIngressAggregator) addIngressRule(namespace, name, ingressClass stri
 		rg.tls = append(rg.tls, iSpec.TLS...)
 	}
 	rg.rules = append(rg.rules, ingressRule{rule: rule})
+	if !slices.Contains(rg.sources, name) {
+		rg.sources = append(rg.sources, name)
+	}
+}
+
+// sources returns the TCPIngresses each generated route and Gateway was
+// built from.
+func (a *tcpIngressAggregator) sources() map[intermediate.ObjectRef][]intermediate.ObjectRef {
+	sources := make(map[intermediate.ObjectRef][]intermediate.ObjectRef)
+	routeNames := a.routeNames()
+	for rgk, rg := range a.ruleGroups {
+		routeKind := common.TCPRouteGVK.Kind
+		if len(rg.tls) > 0 {
+			routeKind = common.TLSRouteGVK.Kind
+		}
+		routeRef := intermediate.ObjectRef{Kind: routeKind, Namespace: rg.namespace, Name: routeNames[rgk]}
+		gatewayRef := intermediate.ObjectRef{Kind: common.GatewayGVK.Kind, Namespace: rg.namespace, Name: rg.ingressClass}
+		for _, name := range rg.sources {
+			tcpIngressRef := intermediate.ObjectRef{Kind: "TCPIngress", Namespace: rg.namespace, Name: name}
+			intermediate.AddSource(sources, routeRef, tcpIngressRef)
+			intermediate.AddSource(sources, gatewayRef, tcpIngressRef)
+		}
+	}
+	return sources
+}
+
+// routeNames returns the names of the routes of the rule groups. The rule
+// groups of the same TCPIngress and host differ by port, so the names of the
+// routes of a namespace are checked for collisions.
+func (a *tcpIngressAggregator) routeNames() map[ruleGroupKey]string {
+	scopes := make(map[string]*naming.Scope)
+	for rgk, rg := range a.ruleGroups {
+		if scopes[rg.namespace] == nil {
+			scopes[rg.namespace] = naming.NewScope()
+		}
+		scopes[rg.namespace].Add(common.RouteName(rg.name, rg.host), string(rgk))
+	}
+	names := make(map[ruleGroupKey]string, len(a.ruleGroups))
+	for rgk, rg := range a.ruleGroups {
+		names[rgk] = scopes[rg.namespace].Name(common.RouteName(rg.name, rg.host), string(rgk))
+	}
+	return names
+}
+
+// sectionNames returns the names of the listeners of the rule groups, which
+// are checked for collisions in every Gateway.
+func (a *tcpIngressAggregator) sectionNames() map[ruleGroupKey]gatewayv1.SectionName {
+	scopes := make(map[string]*naming.Scope)
+	for rgk, rg := range a.ruleGroups {
+		gwKey := fmt.Sprintf("%s/%s", rg.namespace, rg.ingressClass)
+		if scopes[gwKey] == nil {
+			scopes[gwKey] = naming.NewScope()
+		}
+		scopes[gwKey].Add(rg.sectionName(), string(rgk))
+	}
+	names := make(map[ruleGroupKey]gatewayv1.SectionName, len(a.ruleGroups))
+	for rgk, rg := range a.ruleGroups {
+		gwKey := fmt.Sprintf("%s/%s", rg.namespace, rg.ingressClass)
+		names[rgk] = gatewayv1.SectionName(scopes[gwKey].Name(rg.sectionName(), string(rgk)))
+	}
+	return names
+}
+
+// listenerHostname returns the hostname of the listener of the rule group,
+// which is the one of its TLS configuration if it has no host.
+func (rg *tcpIngressRuleGroup) listenerHostname() string {
+	if rg.host == "" && len(rg.tls) == 1 && len(rg.tls[0].Hosts) == 1 {
+		return rg.tls[0].Hosts[0]
+	}
+	return rg.host
+}
+
+// sectionName returns the name of the listener of the rule group, before
+// collisions are checked.
+func (rg *tcpIngressRuleGroup) sectionName() string {
+	protocol := "tcp"
+	if len(rg.tls) > 0 {
+		protocol = "tls"
+	}
+	return string(*buildSectionName(protocol, common.NameFromHost(rg.listenerHostname()), strconv.Itoa(rg.port)))
 }
 
 func (a *tcpIngressAggregator) toRoutesAndGateways() ([]gatewayv1alpha2.TCPRoute, []gatewayv1alpha2.TLSRoute, []gatewayv1.Gateway, field.ErrorList) {
@@ -119,12 +202,20 @@ func (a *tcpIngressAggregator) toRoutesAndGateways() ([]gatewayv1alpha2.TCPRoute
 	var errors field.ErrorList
 	listenersByNamespacedGateway := map[string][]gatewayv1.Listener{}
 
-	for _, rg := range a.ruleGroups {
-		listener := gatewayv1.Listener{}
-		if rg.host != "" {
-			listener.Hostname = (*gatewayv1.Hostname)(&rg.host)
-		} else if len(rg.tls) == 1 && len(rg.tls[0].Hosts) == 1 {
-			listener.Hostname = (*gatewayv1.Hostname)(&rg.tls[0].Hosts[0])
+	// Sort the rulegroups to iterate the map in a sorted order.
+	ruleGroupsKeys := make([]ruleGroupKey, 0, len(a.ruleGroups))
+	for k := range a.ruleGroups {
+		ruleGroupsKeys = append(ruleGroupsKeys, k)
+	}
+	slices.Sort(ruleGroupsKeys)
+
+	routeNames := a.routeNames()
+	sectionNames := a.sectionNames()
+	for _, rgk := range ruleGroupsKeys {
+		rg := a.ruleGroups[rgk]
+		listener := gatewayv1.Listener{Name: sectionNames[rgk]}
+		if hostname := rg.listenerHostname(); hostname != "" {
+			listener.Hostname = (*gatewayv1.Hostname)(&hostname)
 		}
 		if len(rg.tls) > 0 {
 			listener.TLS = &gatewayv1.GatewayTLSConfig{
@@ -144,15 +235,24 @@ func (a *tcpIngressAggregator) toRoutesAndGateways() ([]gatewayv1alpha2.TCPRoute
 		listenersByNamespacedGateway[gwKey] = append(listenersByNamespacedGateway[gwKey], listener)
 		var errs field.ErrorList
 		if listener.TLS == nil {
-			tcpRoutes = append(tcpRoutes, rg.toTCPRoute())
+			tcpRoutes = append(tcpRoutes, rg.toTCPRoute(routeNames[rgk], listener.Name))
 		} else {
-			tlsRoutes = append(tlsRoutes, rg.toTLSRoute())
+			tlsRoutes = append(tlsRoutes, rg.toTLSRoute(routeNames[rgk], listener.Name))
 		}
 		errors = append(errors, errs...)
 	}
 
+	// Sort the gateway keys, so that the gateways are returned in a sorted
+	// order.
+	gwKeys := make([]string, 0, len(listenersByNamespacedGateway))
+	for gwKey := range listenersByNamespacedGateway {
+		gwKeys = append(gwKeys, gwKey)
+	}
+	slices.Sort(gwKeys)
+
 	gatewaysByKey := map[string]*gatewayv1.Gateway{}
-	for gwKey, listeners := range listenersByNamespacedGateway {
+	for _, gwKey := range gwKeys {
+		listeners := listenersByNamespacedGateway[gwKey]
 		parts := strings.Split(gwKey, "/")
 		if len(parts) != 2 {
 			errors = append(errors, field.Invalid(field.NewPath(""), "", fmt.Sprintf("error generating Gateway listeners for key: %s", gwKey)))
@@ -173,16 +273,12 @@ func (a *tcpIngressAggregator) toRoutesAndGateways() ([]gatewayv1alpha2.TCPRoute
 			gatewaysByKey[gwKey] = gateway
 		}
 		for _, listener := range listeners {
-			hostname := ""
-			if listener.Hostname != nil {
-				hostname = string(*listener.Hostname)
-			}
 			if listener.TLS != nil {
 				gateway.Spec.Listeners = append(gateway.Spec.Listeners, gatewayv1.Listener{
 					Hostname: listener.Hostname,
 					Protocol: gatewayv1.TLSProtocolType,
 					Port:     listener.Port,
-					Name:     *buildSectionName("tls", common.NameFromHost(hostname), strconv.Itoa(int(listener.Port))),
+					Name:     listener.Name,
 					TLS:      listener.TLS,
 				})
 			} else {
@@ -190,24 +286,26 @@ func (a *tcpIngressAggregator) toRoutesAndGateways() ([]gatewayv1alpha2.TCPRoute
 					Hostname: listener.Hostname,
 					Protocol: gatewayv1.TCPProtocolType,
 					Port:     listener.Port,
-					Name:     *buildSectionName("tcp", common.NameFromHost(hostname), strconv.Itoa(int(listener.Port))),
+					Name:     listener.Name,
 				})
 			}
 		}
 	}
 
 	var gateways []gatewayv1.Gateway
-	for _, gw := range gatewaysByKey {
-		gateways = append(gateways, *gw)
+	for _, gwKey := range gwKeys {
+		if gw, ok := gatewaysByKey[gwKey]; ok {
+			gateways = append(gateways, *gw)
+		}
 	}
 
 	return tcpRoutes, tlsRoutes, gateways, errors
 }
 
-func (rg *tcpIngressRuleGroup) toTCPRoute() gatewayv1alpha2.TCPRoute {
+func (rg *tcpIngressRuleGroup) toTCPRoute(name string, sectionName gatewayv1.SectionName) gatewayv1alpha2.TCPRoute {
 	tcpRoute := gatewayv1alpha2.TCPRoute{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      common.RouteName(rg.name, rg.host),
+			Name:      name,
 			Namespace: rg.namespace,
 		},
 		Spec: gatewayv1alpha2.TCPRouteSpec{},
@@ -223,7 +321,7 @@ func (rg *tcpIngressRuleGroup) toTCPRoute() gatewayv1alpha2.TCPRoute {
 		tcpRoute.Spec.ParentRefs = []gatewayv1.ParentReference{
 			{
 				Name:        gatewayv1.ObjectName(rg.ingressClass),
-				SectionName: buildSectionName("tcp", common.NameFromHost(rg.host), strconv.Itoa(rg.port)),
+				SectionName: &sectionName,
 			},
 		}
 	}
@@ -246,10 +344,10 @@ func (rg *tcpIngressRuleGroup) toTCPRoute() gatewayv1alpha2.TCPRoute {
 	return tcpRoute
 }
 
-func (rg *tcpIngressRuleGroup) toTLSRoute() gatewayv1alpha2.TLSRoute {
+func (rg *tcpIngressRuleGroup) toTLSRoute(name string, sectionName gatewayv1.SectionName) gatewayv1alpha2.TLSRoute {
 	tlsRoute := gatewayv1alpha2.TLSRoute{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      common.RouteName(rg.name, rg.host),
+			Name:      name,
 			Namespace: rg.namespace,
 		},
 		Spec: gatewayv1alpha2.TLSRouteSpec{},
@@ -265,7 +363,7 @@ func (rg *tcpIngressRuleGroup) toTLSRoute() gatewayv1alpha2.TLSRoute {
 		tlsRoute.Spec.ParentRefs = []gatewayv1.ParentReference{
 			{
 				Name:        gatewayv1.ObjectName(rg.ingressClass),
-				SectionName: buildSectionName("tls", common.NameFromHost(rg.host), strconv.Itoa(rg.port)),
+				SectionName: &sectionName,
 			},
 		}
 	}
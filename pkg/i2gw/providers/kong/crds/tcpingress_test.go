@@ -274,3 +274,44 @@ func TestTCPIngressToGatewayAPI(t *testing.T) {
 		})
 	}
 }
+
+func TestTCPIngressToGatewayAPIListenerOrder(t *testing.T) {
+	tcpIngress := kongv1beta1.TCPIngress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "sample",
+			Namespace: "default",
+			Annotations: map[string]string{
+				"kubernetes.io/ingress.class": "kong",
+			},
+		},
+		Spec: kongv1beta1.TCPIngressSpec{
+			Rules: []kongv1beta1.IngressRule{
+				{Host: "c.example.com", Port: 8888, Backend: kongv1beta1.IngressBackend{ServiceName: "tcp-echo", ServicePort: 1025}},
+				{Host: "a.example.com", Port: 8888, Backend: kongv1beta1.IngressBackend{ServiceName: "tcp-echo", ServicePort: 1025}},
+				{Host: "b.example.com", Port: 8888, Backend: kongv1beta1.IngressBackend{ServiceName: "tcp-echo", ServicePort: 1025}},
+			},
+		},
+	}
+	expectedListeners := []gatewayv1.SectionName{
+		"tcp-a-example-com-8888",
+		"tcp-b-example-com-8888",
+		"tcp-c-example-com-8888",
+	}
+
+	// The rule groups are held in a map, so the conversion is repeated to
+	// catch a random order.
+	for i := 0; i < 10; i++ {
+		ir, _, errs := TCPIngressToGatewayIR([]kongv1beta1.TCPIngress{tcpIngress})
+		if len(errs) != 0 {
+			t.Fatalf("Expected no errors, got %+v", errs)
+		}
+		gatewayContext := ir.Gateways[types.NamespacedName{Namespace: "default", Name: "kong"}]
+		var gotListeners []gatewayv1.SectionName
+		for _, listener := range gatewayContext.Gateway.Spec.Listeners {
+			gotListeners = append(gotListeners, listener.Name)
+		}
+		if diff := cmp.Diff(expectedListeners, gotListeners); diff != "" {
+			t.Fatalf("Unexpected listeners order (-want +got): %s", diff)
+		}
+	}
+}
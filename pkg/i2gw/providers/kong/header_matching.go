@@ -20,11 +20,11 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/intermediate"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/providers/common"
 	networkingv1 "k8s.io/api/networking/v1"
-	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
@@ -38,25 +38,27 @@ import (
 //
 // All the values defined for each annotation name, and separated by comma, MUST be ORed.
 // All the annotation names MUST be ANDed, with the respective values.
-func headerMatchingFeature(ingresses []networkingv1.Ingress, ir *intermediate.IR) field.ErrorList {
+func headerMatchingFeature(conf *i2gw.ProviderConf, ingresses []networkingv1.Ingress, ir *intermediate.IR) field.ErrorList {
 	ruleGroups := common.GetRuleGroups(ingresses)
 	for _, rg := range ruleGroups {
 		for _, rule := range rg.Rules {
 			headerskeys, headersValues := parseHeadersAnnotations(rule.Ingress.Annotations)
-			key := types.NamespacedName{Namespace: rule.Ingress.Namespace, Name: common.RouteName(rg.Name, rg.Host)}
-			httpRouteContext, ok := ir.HTTPRoutes[key]
-			if !ok {
+			keys := common.HTTPRouteKeys(ir, rule.Ingress.Namespace, rg.IngressClass, rg.Host)
+			if len(keys) == 0 {
 				return field.ErrorList{field.InternalError(nil, fmt.Errorf("HTTPRoute does not exist - this should never happen"))}
 			}
 
-			patchHTTPRouteHeaderMatching(&httpRouteContext.HTTPRoute, headerskeys, headersValues)
+			for _, key := range keys {
+				httpRouteContext := ir.HTTPRoutes[key]
+				patchHTTPRouteHeaderMatching(conf, &httpRouteContext.HTTPRoute, headerskeys, headersValues)
+			}
 		}
 
 	}
 	return nil
 }
 
-func patchHTTPRouteHeaderMatching(httpRoute *gatewayv1.HTTPRoute, headerNames []string, headerValues [][]string) {
+func patchHTTPRouteHeaderMatching(conf *i2gw.ProviderConf, httpRoute *gatewayv1.HTTPRoute, headerNames []string, headerValues [][]string) {
 	for i := range httpRoute.Spec.Rules {
 		newMatches := []gatewayv1.HTTPRouteMatch{}
 		for _, match := range httpRoute.Spec.Rules[i].Matches {
@@ -83,7 +85,7 @@ func patchHTTPRouteHeaderMatching(httpRoute *gatewayv1.HTTPRoute, headerNames []
 		}
 		httpRoute.Spec.Rules[i].Matches = newMatches
 		if len(newMatches) > 0 {
-			notify(notifications.InfoNotification, fmt.Sprintf("parsed \"%v\" annotation of ingress and patched %v fields", kongAnnotation(headersKey), field.NewPath("httproute", "spec", "rules").Key("").Child("matches")), httpRoute)
+			notify(conf, notifications.InfoNotification, fmt.Sprintf("parsed \"%v\" annotation of ingress and patched %v fields", kongAnnotation(headersKey), field.NewPath("httproute", "spec", "rules").Key("").Child("matches")), httpRoute)
 		}
 	}
 }
@@ -249,7 +249,7 @@ func TestHeaderMatchingFeature(t *testing.T) {
 				t.Errorf("Expected no errors, got %d: %+v", len(errs), errs)
 			}
 
-			errs = headerMatchingFeature(tc.ingresses, &gatewayResources)
+			errs = headerMatchingFeature(nil, tc.ingresses, &gatewayResources)
 			if len(errs) != len(tc.expectedErrors) {
 				t.Errorf("Expected %d errors, got %d: %+v", len(tc.expectedErrors), len(errs), errs)
 			} else {
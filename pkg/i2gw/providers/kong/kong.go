@@ -32,6 +32,13 @@ const KongIngressClass = "kong"
 
 func init() {
 	i2gw.ProviderConstructorByName[Name] = NewProvider
+	i2gw.RegisterProviderResourceKinds(Name, common.IngressGVK, common.ServiceGVK, tcpIngressGVK)
+
+	i2gw.RegisterProviderAnnotations(Name, i2gw.ProviderAnnotations{
+		Prefixes:    []string{annotationPrefix + "/"},
+		Keys:        []string{kongAnnotation(methodsKey), kongAnnotation(pluginsKey)},
+		KeyPrefixes: []string{kongAnnotation(headersKey) + "."},
+	})
 }
 
 // Provider implements the i2gw.Provider interface.
@@ -45,7 +52,7 @@ type Provider struct {
 func NewProvider(conf *i2gw.ProviderConf) i2gw.Provider {
 	return &Provider{
 		resourceReader:         newResourceReader(conf),
-		resourcesToIRConverter: newResourcesToIRConverter(),
+		resourcesToIRConverter: newResourcesToIRConverter(conf),
 	}
 }
 
@@ -68,8 +75,8 @@ func (p *Provider) ReadResourcesFromCluster(ctx context.Context) error {
 	return nil
 }
 
-func (p *Provider) ReadResourcesFromFile(_ context.Context, filename string) error {
-	storage, err := p.readResourcesFromFile(filename)
+func (p *Provider) ReadResourcesFromFiles(_ context.Context, filenames []string) error {
+	storage, err := p.readResourcesFromFiles(filenames)
 	if err != nil {
 		return err
 	}
@@ -21,11 +21,11 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/intermediate"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/providers/common"
 	networkingv1 "k8s.io/api/networking/v1"
-	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
@@ -37,26 +37,28 @@ import (
 // konghq.com/methods: "GET,POST"
 //
 // All the values defined and separated by comma, MUST be ORed.
-func methodMatchingFeature(ingresses []networkingv1.Ingress, ir *intermediate.IR) field.ErrorList {
+func methodMatchingFeature(conf *i2gw.ProviderConf, ingresses []networkingv1.Ingress, ir *intermediate.IR) field.ErrorList {
 	ruleGroups := common.GetRuleGroups(ingresses)
 	for _, rg := range ruleGroups {
 		for _, rule := range rg.Rules {
-			key := types.NamespacedName{Namespace: rule.Ingress.Namespace, Name: common.RouteName(rg.Name, rg.Host)}
-			httpRouteContext, ok := ir.HTTPRoutes[key]
-			if !ok {
+			keys := common.HTTPRouteKeys(ir, rule.Ingress.Namespace, rg.IngressClass, rg.Host)
+			if len(keys) == 0 {
 				return field.ErrorList{field.InternalError(nil, fmt.Errorf("HTTPRoute does not exist - this should never happen"))}
 			}
 			methods, errs := parseMethodsAnnotation(rule.Ingress.ObjectMeta.Namespace, rule.Ingress.ObjectMeta.Name, rule.Ingress.Annotations)
 			if len(errs) != 0 {
 				return errs
 			}
-			patchHTTPRouteMethodMatching(&httpRouteContext.HTTPRoute, methods)
+			for _, key := range keys {
+				httpRouteContext := ir.HTTPRoutes[key]
+				patchHTTPRouteMethodMatching(conf, &httpRouteContext.HTTPRoute, methods)
+			}
 		}
 	}
 	return nil
 }
 
-func patchHTTPRouteMethodMatching(httpRoute *gatewayv1.HTTPRoute, methods []gatewayv1.HTTPMethod) {
+func patchHTTPRouteMethodMatching(conf *i2gw.ProviderConf, httpRoute *gatewayv1.HTTPRoute, methods []gatewayv1.HTTPMethod) {
 	for i, rule := range httpRoute.Spec.Rules {
 		matches := []gatewayv1.HTTPRouteMatch{}
 		for _, match := range rule.Matches {
@@ -69,7 +71,7 @@ func patchHTTPRouteMethodMatching(httpRoute *gatewayv1.HTTPRoute, methods []gate
 		}
 		if len(matches) > 0 {
 			httpRoute.Spec.Rules[i].Matches = matches
-			notify(notifications.InfoNotification, fmt.Sprintf("parsed \"%v\" annotation of ingress and patched %v fields", kongAnnotation(methodsKey), field.NewPath("httproute", "spec", "rules").Key("").Child("matches").Key("").Child("method")), httpRoute)
+			notify(conf, notifications.InfoNotification, fmt.Sprintf("parsed \"%v\" annotation of ingress and patched %v fields", kongAnnotation(methodsKey), field.NewPath("httproute", "spec", "rules").Key("").Child("matches").Key("").Child("method")), httpRoute)
 		}
 	}
 }
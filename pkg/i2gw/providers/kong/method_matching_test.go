@@ -32,6 +32,31 @@ import (
 func TestMethodMatchingFeature(t *testing.T) {
 	iPrefix := networkingv1.PathTypePrefix
 
+	// splitPaths has a path more than an HTTPRoute can have rules.
+	var splitPaths []networkingv1.HTTPIngressPath
+	splitMatches := map[string][][]gatewayv1.HTTPRouteMatch{}
+	for i := 0; i <= common.MaxHTTPRouteRules; i++ {
+		splitPaths = append(splitPaths, networkingv1.HTTPIngressPath{
+			Path:     fmt.Sprintf("/%d", i),
+			PathType: &iPrefix,
+			Backend: networkingv1.IngressBackend{
+				Service: &networkingv1.IngressServiceBackend{
+					Name: "test",
+					Port: networkingv1.ServiceBackendPort{
+						Number: 80,
+					},
+				},
+			},
+		})
+		key := "default/split-routes-test-mydomain-com"
+		if i == common.MaxHTTPRouteRules {
+			key += "-2"
+		}
+		splitMatches[key] = append(splitMatches[key], []gatewayv1.HTTPRouteMatch{{
+			Method: ptrTo(gatewayv1.HTTPMethodGet),
+		}})
+	}
+
 	testCases := []struct {
 		name                     string
 		ingresses                []networkingv1.Ingress
@@ -136,6 +161,33 @@ func TestMethodMatchingFeature(t *testing.T) {
 			},
 			expectedErrors: field.ErrorList{},
 		},
+		{
+			name: "method matching - split routes",
+			ingresses: []networkingv1.Ingress{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "split-routes",
+						Namespace: "default",
+						Annotations: map[string]string{
+							"konghq.com/methods": "GET",
+						},
+					},
+					Spec: networkingv1.IngressSpec{
+						IngressClassName: ptrTo("ingress-kong"),
+						Rules: []networkingv1.IngressRule{{
+							Host: "test.mydomain.com",
+							IngressRuleValue: networkingv1.IngressRuleValue{
+								HTTP: &networkingv1.HTTPIngressRuleValue{
+									Paths: splitPaths,
+								},
+							},
+						}},
+					},
+				},
+			},
+			expectedHTTPRouteMatches: splitMatches,
+			expectedErrors:           field.ErrorList{},
+		},
 		{
 			name: "method matching - wrong method",
 			ingresses: []networkingv1.Ingress{
@@ -190,7 +242,7 @@ func TestMethodMatchingFeature(t *testing.T) {
 				t.Errorf("Expected no errors, got %d: %+v", len(errs), errs)
 			}
 
-			errs = methodMatchingFeature(tc.ingresses, &gatewayResources)
+			errs = methodMatchingFeature(nil, tc.ingresses, &gatewayResources)
 			if len(errs) != len(tc.expectedErrors) {
 				t.Errorf("Expected %d errors, got %d: %+v", len(tc.expectedErrors), len(errs), errs)
 			} else {
@@ -21,11 +21,11 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/intermediate"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/providers/common"
 	networkingv1 "k8s.io/api/networking/v1"
-	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
@@ -36,17 +36,19 @@ import (
 // a comma-separated list.
 //
 // Example: konghq.com/plugins: "plugin1,plugin2"
-func pluginsFeature(ingresses []networkingv1.Ingress, ir *intermediate.IR) field.ErrorList {
+func pluginsFeature(conf *i2gw.ProviderConf, ingresses []networkingv1.Ingress, ir *intermediate.IR) field.ErrorList {
 	ruleGroups := common.GetRuleGroups(ingresses)
 	for _, rg := range ruleGroups {
 		for _, rule := range rg.Rules {
-			key := types.NamespacedName{Namespace: rule.Ingress.Namespace, Name: common.RouteName(rg.Name, rg.Host)}
-			httpRouteContext, ok := ir.HTTPRoutes[key]
-			if !ok {
+			keys := common.HTTPRouteKeys(ir, rule.Ingress.Namespace, rg.IngressClass, rg.Host)
+			if len(keys) == 0 {
 				return field.ErrorList{field.InternalError(nil, errors.New("HTTPRoute does not exist - this should never happen"))}
 			}
 			filters := parsePluginsAnnotation(rule.Ingress.Annotations)
-			patchHTTPRoutePlugins(&httpRouteContext.HTTPRoute, filters)
+			for _, key := range keys {
+				httpRouteContext := ir.HTTPRoutes[key]
+				patchHTTPRoutePlugins(conf, &httpRouteContext.HTTPRoute, filters)
+			}
 		}
 	}
 	return nil
@@ -76,7 +78,7 @@ func parsePluginsAnnotation(annotations map[string]string) []gatewayv1.HTTPRoute
 	return filters
 }
 
-func patchHTTPRoutePlugins(httpRoute *gatewayv1.HTTPRoute, extensionRefs []gatewayv1.HTTPRouteFilter) {
+func patchHTTPRoutePlugins(conf *i2gw.ProviderConf, httpRoute *gatewayv1.HTTPRoute, extensionRefs []gatewayv1.HTTPRouteFilter) {
 	for i := range httpRoute.Spec.Rules {
 		if httpRoute.Spec.Rules[i].Filters == nil {
 			httpRoute.Spec.Rules[i].Filters = make([]gatewayv1.HTTPRouteFilter, 0)
@@ -84,6 +86,6 @@ func patchHTTPRoutePlugins(httpRoute *gatewayv1.HTTPRoute, extensionRefs []gatew
 		httpRoute.Spec.Rules[i].Filters = append(httpRoute.Spec.Rules[i].Filters, extensionRefs...)
 	}
 	if len(extensionRefs) != 0 {
-		notify(notifications.InfoNotification, fmt.Sprintf("parsed \"%v\" annotation of ingress and patched %v fields", kongAnnotation(pluginsKey), field.NewPath("httproute", "spec", "rules").Key("").Child("filters")), httpRoute)
+		notify(conf, notifications.InfoNotification, fmt.Sprintf("parsed \"%v\" annotation of ingress and patched %v fields", kongAnnotation(pluginsKey), field.NewPath("httproute", "spec", "rules").Key("").Child("filters")), httpRoute)
 	}
 }
@@ -17,10 +17,8 @@ limitations under the License.
 package kong
 
 import (
-	"bytes"
 	"context"
 	"fmt"
-	"os"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -56,6 +54,12 @@ func (r *resourceReader) readResourcesFromCluster(ctx context.Context) (*storage
 	}
 	storage.Ingresses = ingresses
 
+	services, err := common.ReadServicesFromCluster(ctx, r.conf.Client, ingresses)
+	if err != nil {
+		return nil, err
+	}
+	storage.Services = services
+
 	tcpIngresses, err := r.readTCPIngressesFromCluster(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read TCPIngresses: %w", err)
@@ -65,16 +69,22 @@ func (r *resourceReader) readResourcesFromCluster(ctx context.Context) (*storage
 	return storage, nil
 }
 
-func (r *resourceReader) readResourcesFromFile(filename string) (*storage, error) {
+func (r *resourceReader) readResourcesFromFiles(filenames []string) (*storage, error) {
 	storage := newResourceStorage()
 
-	ingresses, err := common.ReadIngressesFromFile(filename, r.conf.Namespace, sets.New(KongIngressClass))
+	ingresses, err := common.ReadIngressesFromFiles(r.conf, filenames, sets.New(KongIngressClass))
 	if err != nil {
 		return nil, err
 	}
 	storage.Ingresses = ingresses
 
-	tcpIngresses, err := r.readTCPIngressesFromFile(filename)
+	services, err := common.ReadServicesFromFiles(r.conf, filenames)
+	if err != nil {
+		return nil, err
+	}
+	storage.Services = services
+
+	tcpIngresses, err := r.readTCPIngressesFromFiles(filenames)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read TCPIngresses: %w", err)
 	}
@@ -109,14 +119,8 @@ func (r *resourceReader) readTCPIngressesFromCluster(ctx context.Context) ([]kon
 	return tcpIngresses, nil
 }
 
-func (r *resourceReader) readTCPIngressesFromFile(filename string) ([]kongv1beta1.TCPIngress, error) {
-	stream, err := os.ReadFile(filename)
-	if err != nil {
-		return nil, err
-	}
-
-	reader := bytes.NewReader(stream)
-	objs, err := common.ExtractObjectsFromReader(reader, r.conf.Namespace)
+func (r *resourceReader) readTCPIngressesFromFiles(filenames []string) ([]kongv1beta1.TCPIngress, error) {
+	objs, err := common.ReadObjectsFromFiles(r.conf, filenames)
 	if err != nil {
 		return nil, err
 	}
@@ -132,7 +136,7 @@ func (r *resourceReader) readTCPIngressesFromFile(filename string) ([]kongv1beta
 			err = runtime.DefaultUnstructuredConverter.
 				FromUnstructured(f.UnstructuredContent(), tcpIngress)
 			if err != nil {
-				return nil, err
+				return nil, fmt.Errorf("failed to parse Kong TCPIngress from %s: %w", common.SourceFile(f), err)
 			}
 			tcpIngresses = append(tcpIngresses, *tcpIngress)
 		}
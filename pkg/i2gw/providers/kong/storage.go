@@ -18,18 +18,21 @@ package kong
 
 import (
 	kongv1beta1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1beta1"
+	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
 
 type storage struct {
 	Ingresses    map[types.NamespacedName]*networkingv1.Ingress
+	Services     map[types.NamespacedName]*corev1.Service
 	TCPIngresses []kongv1beta1.TCPIngress
 }
 
 func newResourceStorage() *storage {
 	return &storage{
 		Ingresses:    map[types.NamespacedName]*networkingv1.Ingress{},
+		Services:     map[types.NamespacedName]*corev1.Service{},
 		TCPIngresses: []kongv1beta1.TCPIngress{},
 	}
 }
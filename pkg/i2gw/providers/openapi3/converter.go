@@ -36,6 +36,7 @@ import (
 
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/intermediate"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/naming"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/providers/common"
 )
 
@@ -114,7 +115,7 @@ func (c *resourcesToIRConverter) Convert(storage Storage) (intermediate.IR, fiel
 		}
 		resourcesNamePrefixes[resourcesNamePrefix]++
 		if resourcesNamePrefixes[resourcesNamePrefix] > 1 {
-			resourcesNamePrefix = fmt.Sprintf("%s-%d", resourcesNamePrefix, resourcesNamePrefixes[resourcesNamePrefix]+1)
+			resourcesNamePrefix = naming.Name(resourcesNamePrefix, strconv.Itoa(resourcesNamePrefixes[resourcesNamePrefix]+1))
 		}
 
 		// convert the spec to Gateway API resources
@@ -177,7 +178,7 @@ func (c *resourcesToIRConverter) toHTTPRoutesAndGateways(spec *openapi3.T, resou
 	sort.Strings(listenerGroups)
 
 	// build the gateway object
-	gatewayName := fmt.Sprintf("%s-gateway", resourcesNamePrefix)
+	gatewayName := naming.Name(resourcesNamePrefix, "gateway")
 	gateway := gatewayv1.Gateway{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: gatewayName,
@@ -193,15 +194,31 @@ func (c *resourcesToIRConverter) toHTTPRoutesAndGateways(spec *openapi3.T, resou
 
 	// declare unique listeners in the gateway for each hostname in the listener groups
 	uniqueListeners := make(map[string]struct{})
+	var gatewayListeners []string
 	for _, group := range listenerGroups {
-		listeners := lo.Filter(strings.Split(group, HostSeparator), func(listener string, _ int) bool {
+		gatewayListeners = append(gatewayListeners, lo.Filter(strings.Split(group, HostSeparator), func(listener string, _ int) bool {
 			_, exists := uniqueListeners[listener]
 			if !exists {
 				uniqueListeners[listener] = struct{}{}
 			}
 			return !exists
-		})
-		gateway.Spec.Listeners = append(gateway.Spec.Listeners, lo.Map(listeners, c.toListener)...) // TODO: gateways cannot have more than 64 listeners
+		})...)
+	}
+
+	// different hostnames may have the same listener name, e.g. a.b.com and a-b.com
+	listenerNames := naming.NewScope()
+	for _, listener := range gatewayListeners {
+		name, _, _ := toListenerName(listener)
+		listenerNames.Add(string(name), listener)
+	}
+	sectionName := func(listener string) gatewayv1.SectionName {
+		name, _, _ := toListenerName(listener)
+		return gatewayv1.SectionName(listenerNames.Name(string(name), listener))
+	}
+	for i, listener := range gatewayListeners {
+		gatewayListener := c.toListener(listener, i)
+		gatewayListener.Name = sectionName(listener)
+		gateway.Spec.Listeners = append(gateway.Spec.Listeners, gatewayListener)
 	}
 
 	var routes []gatewayv1.HTTPRoute
@@ -232,7 +249,7 @@ func (c *resourcesToIRConverter) toHTTPRoutesAndGateways(spec *openapi3.T, resou
 
 		var listenerName gatewayv1.SectionName
 		if len(uniqueListeners) > 1 && len(listeners) == 1 {
-			listenerName, _, _ = toListenerName(listeners[0])
+			listenerName = sectionName(listeners[0])
 		}
 
 		// sort hostnames and matchers for deterministic output inside each route object
@@ -248,12 +265,12 @@ func (c *resourcesToIRConverter) toHTTPRoutesAndGateways(spec *openapi3.T, resou
 		}
 		for j := 0; j < nRoutes; j++ {
 			// generate a unique name for the route object
-			routeName := fmt.Sprintf("%s-route", resourcesNamePrefix)
+			routeName := naming.Name(resourcesNamePrefix, "route")
 			if len(listenerGroups) > 1 {
-				routeName = fmt.Sprintf("%s-%d", routeName, i+1) // appends a grouping counter to the route name, starting at 1, if there are multiple listener groups, to avoid conflicts
+				routeName = naming.Name(routeName, strconv.Itoa(i+1)) // appends a grouping counter to the route name, starting at 1, if there are multiple listener groups, to avoid conflicts
 			}
 			if nRoutes > 1 {
-				routeName = fmt.Sprintf("%s-%d", routeName, j+1) // appends a counter to the route name, starting at 1, if there are more multiple routes, to avoid conflicts
+				routeName = naming.Name(routeName, strconv.Itoa(j+1)) // appends a counter to the route name, starting at 1, if there are more multiple routes, to avoid conflicts
 			}
 			last := (j + 1) * HTTPRouteMatchesMaxMax
 			if last > nMatchers {
@@ -320,10 +337,10 @@ func toListenerName(protocolAndHostname string) (listenerName gatewayv1.SectionN
 
 	var listenerNamePrefix string
 	if hostname != HostWildcard {
-		listenerNamePrefix = fmt.Sprintf("%s-", common.NameFromHost(hostname))
+		listenerNamePrefix = common.NameFromHost(hostname)
 	}
 
-	return gatewayv1.SectionName(listenerNamePrefix + protocol), protocol, hostname
+	return gatewayv1.SectionName(naming.Name(listenerNamePrefix, protocol)), protocol, hostname
 }
 
 // toHTTPRoute builds a Gateway API HTTPRoute object with a given name, for a given gateway parent, set of hostnames,
@@ -381,7 +398,7 @@ func (c *resourcesToIRConverter) buildReferenceGrant(fromGVK schema.GroupVersion
 	}
 	rg := &gatewayv1beta1.ReferenceGrant{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("from-%s-to-%s-%s", c.namespace, strings.ToLower(string(toKind)), toRef.Name),
+			Name:      naming.Name("from", c.namespace, "to", string(toKind), toRef.Name),
 			Namespace: toRef.Namespace,
 		},
 		Spec: gatewayv1beta1.ReferenceGrantSpec{
@@ -650,7 +667,7 @@ func toGatewayAPIHostname(hostname string, _ int) gatewayv1.Hostname {
 
 // toResourcesNamePrefix returns a base common prefix for the names of the resources, from the title of a spec.
 func toResourcesNamePrefix(spec *openapi3.T) string {
-	return strings.ToLower(common.NameFromHost(spec.Info.Title))
+	return common.NameFromHost(spec.Info.Title)
 }
 
 // toNamespacedName converts a string in the format "namespace/name" to a types.NamespacedName object.
@@ -102,9 +102,15 @@ func TestFileConvertion(t *testing.T) {
 			}
 		}
 
-		provider := NewProvider(providerConf)
+		inputFiles, err := i2gw.ReadInputFiles([]string{path}, nil)
+		if err != nil {
+			t.Fatalf("failed to read test file %v: %v", d.Name(), err)
+		}
+		conf := *providerConf
+		conf.InputFiles = inputFiles
+		provider := NewProvider(&conf)
 
-		if readFileErr := provider.ReadResourcesFromFile(ctx, path); readFileErr != nil {
+		if readFileErr := provider.ReadResourcesFromFiles(ctx, []string{path}); readFileErr != nil {
 			if expectedReadFileError == nil {
 				t.Fatalf("unexpected error during reading test file %v: %v", d.Name(), readFileErr.Error())
 			} else if !strings.Contains(readFileErr.Error(), expectedReadFileError.Error()) {
@@ -19,6 +19,8 @@ package openapi3
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"path/filepath"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -57,6 +59,7 @@ func init() {
 }
 
 type Provider struct {
+	conf                   *i2gw.ProviderConf
 	storage                Storage
 	resourcesToIRConverter ResourcesToIRConverter
 }
@@ -66,6 +69,7 @@ var _ i2gw.Provider = &Provider{}
 // NewProvider returns an implementation of i2gw.Provider that converts OpenAPI specs to Gateway API resources.
 func NewProvider(conf *i2gw.ProviderConf) i2gw.Provider {
 	return &Provider{
+		conf:                   conf,
 		storage:                NewResourceStorage(),
 		resourcesToIRConverter: NewResourcesToIRConverter(conf),
 	}
@@ -76,16 +80,18 @@ func (p *Provider) ReadResourcesFromCluster(_ context.Context) error {
 	return nil
 }
 
-// ReadResourcesFromFile reads OpenAPI specs from a JSON or YAML file.
-func (p *Provider) ReadResourcesFromFile(ctx context.Context, filename string) error {
-	spec, err := readSpecFromFile(ctx, filename)
-	if err != nil {
-		return fmt.Errorf("failed to read resources from file: %w", err)
-	}
-
+// ReadResourcesFromFiles reads OpenAPI specs from JSON or YAML files, one spec
+// per file.
+func (p *Provider) ReadResourcesFromFiles(ctx context.Context, filenames []string) error {
 	p.storage.Clear()
-	if spec != nil {
-		p.storage.AddResource(spec)
+	for _, filename := range filenames {
+		spec, err := readSpecFromFile(ctx, p.conf, filename)
+		if err != nil {
+			return fmt.Errorf("failed to read resources from file: %w", err)
+		}
+		if spec != nil {
+			p.storage.AddResource(spec)
+		}
 	}
 
 	return nil
@@ -100,15 +106,20 @@ func (p *Provider) ToGatewayResources(ir intermediate.IR) (i2gw.GatewayResources
 	return common.ToGatewayResources(ir)
 }
 
-func readSpecFromFile(ctx context.Context, filename string) (*openapi3.T, error) {
+func readSpecFromFile(ctx context.Context, conf *i2gw.ProviderConf, filename string) (*openapi3.T, error) {
 	loader := openapi3.NewLoader()
-	spec, err := loader.LoadFromFile(filename)
+	data, err := conf.ReadInputFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OpenAPI spec %s: %w", filename, err)
+	}
+	// The path of the file resolves the references relative to it.
+	spec, err := loader.LoadFromDataWithPath(data, &url.URL{Path: filepath.ToSlash(filename)})
 	if err != nil {
-		return nil, fmt.Errorf("failed to load OpenAPI spec: %w", err)
+		return nil, fmt.Errorf("failed to load OpenAPI spec %s: %w", filename, err)
 	}
 
 	if err := spec.Validate(ctx); err != nil {
-		return nil, fmt.Errorf("invalid OpenAPI 3.x spec: %w", err)
+		return nil, fmt.Errorf("invalid OpenAPI 3.x spec %s: %w", filename, err)
 	}
 
 	return spec, nil
@@ -0,0 +1,97 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package i2gw
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/engine"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+// RenderKustomization builds the kustomization in dir, and returns the built
+// manifests as an input file named after dir. Remote bases and plugins are not
+// supported.
+func RenderKustomization(dir string) (map[string][]byte, error) {
+	kustomizer := krusty.MakeKustomizer(krusty.MakeDefaultOptions())
+	resMap, err := kustomizer.Run(filesys.MakeFsOnDisk(), dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build kustomization %s: %w", dir, err)
+	}
+
+	data, err := resMap.AsYaml()
+	if err != nil {
+		return nil, fmt.Errorf("failed to render kustomization %s: %w", dir, err)
+	}
+
+	return map[string][]byte{filepath.Clean(dir): data}, nil
+}
+
+// RenderHelmChart renders the templates of the chart in chartDir with the
+// values of valuesFiles, later files taking precedence, as a release named
+// after the chart in namespace. Each rendered template is returned as an input
+// file named after the path of the template, so that the converted objects can
+// be traced back to it. Lookup functions and hooks are not
+// supported, as the chart is rendered without a cluster.
+func RenderHelmChart(chartDir string, valuesFiles []string, namespace string) (map[string][]byte, error) {
+	chart, err := loader.Load(chartDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Helm chart %s: %w", chartDir, err)
+	}
+
+	values := map[string]interface{}{}
+	for _, valuesFile := range valuesFiles {
+		fileValues, err := chartutil.ReadValuesFile(valuesFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read Helm values %s: %w", valuesFile, err)
+		}
+		values = chartutil.CoalesceTables(fileValues.AsMap(), values)
+	}
+
+	if namespace == "" {
+		namespace = "default"
+	}
+	renderValues, err := chartutil.ToRenderValues(chart, values, chartutil.ReleaseOptions{
+		Name:      chart.Name(),
+		Namespace: namespace,
+		IsInstall: true,
+	}, chartutil.DefaultCapabilities)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute values of Helm chart %s: %w", chartDir, err)
+	}
+
+	templates, err := engine.Render(chart, renderValues)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render Helm chart %s: %w", chartDir, err)
+	}
+
+	files := make(map[string][]byte)
+	for name, data := range templates {
+		if !slices.Contains(inputFileExtensions, strings.ToLower(filepath.Ext(name))) {
+			continue
+		}
+		// Templates are named after the chart, e.g. mychart/templates/a.yaml.
+		files[filepath.Join(chartDir, strings.TrimPrefix(name, chart.Name()+"/"))] = []byte(data)
+	}
+	return files, nil
+}
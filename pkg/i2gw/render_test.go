@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package i2gw
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	networkingv1 "k8s.io/api/networking/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// readRenderedIngress reads the Ingress rendered as the input file filename.
+func readRenderedIngress(t *testing.T, files map[string][]byte, filename string) networkingv1.Ingress {
+	t.Helper()
+	data, ok := files[filename]
+	if !ok {
+		t.Fatalf("Expected %s to be a rendered input file", filename)
+	}
+	var ingress networkingv1.Ingress
+	if err := yaml.Unmarshal(data, &ingress); err != nil {
+		t.Fatalf("Failed to parse the rendered Ingress: %v", err)
+	}
+	return ingress
+}
+
+func Test_RenderKustomization(t *testing.T) {
+	dir := filepath.Join("testdata", "kustomize", "overlay")
+	files, err := RenderKustomization(dir)
+	if err != nil {
+		t.Fatalf("Expected no error but got %v", err)
+	}
+	if diff := cmp.Diff([]string{dir}, InputFileNames(files)); diff != "" {
+		t.Fatalf("Unexpected rendered files (-want +got):\n%s", diff)
+	}
+
+	ingress := readRenderedIngress(t, files, dir)
+	if ingress.Namespace != "prod" || ingress.Name != "prod-web" {
+		t.Errorf("Expected the overlay to be applied, got Ingress %s/%s", ingress.Namespace, ingress.Name)
+	}
+}
+
+func Test_RenderHelmChart(t *testing.T) {
+	chartDir := filepath.Join("testdata", "helm", "web")
+	ingressFilename := filepath.Join(chartDir, "templates", "ingress.yaml")
+
+	testCases := []struct {
+		name         string
+		valuesFiles  []string
+		namespace    string
+		expectedHost string
+		expectedNs   string
+	}{
+		{
+			name:         "default values",
+			expectedHost: "example.com",
+			expectedNs:   "default",
+		},
+		{
+			name:         "values file and namespace",
+			valuesFiles:  []string{filepath.Join("testdata", "helm", "prod-values.yaml")},
+			namespace:    "prod",
+			expectedHost: "prod.example.com",
+			expectedNs:   "prod",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			files, err := RenderHelmChart(chartDir, tc.valuesFiles, tc.namespace)
+			if err != nil {
+				t.Fatalf("Expected no error but got %v", err)
+			}
+			if diff := cmp.Diff([]string{ingressFilename}, InputFileNames(files)); diff != "" {
+				t.Fatalf("Unexpected rendered files (-want +got):\n%s", diff)
+			}
+
+			ingress := readRenderedIngress(t, files, ingressFilename)
+			if ingress.Namespace != tc.expectedNs || ingress.Name != "web" {
+				t.Errorf("Expected Ingress %s/web, got %s/%s", tc.expectedNs, ingress.Namespace, ingress.Name)
+			}
+			if host := ingress.Spec.Rules[0].Host; host != tc.expectedHost {
+				t.Errorf("Expected host %s, got %s", tc.expectedHost, host)
+			}
+		})
+	}
+}
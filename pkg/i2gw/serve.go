@@ -0,0 +1,176 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package i2gw
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	// ConvertPath is the path of the conversion endpoint served by
+	// NewConversionHandler.
+	ConvertPath = "/v1/convert"
+	// MaxManifestBytes is the maximum size of the manifests sent to the
+	// conversion endpoint.
+	MaxManifestBytes = 10 << 20
+)
+
+// ConvertResponse is the JSON body returned by the conversion endpoint.
+type ConvertResponse struct {
+	// Objects holds the generated objects, in the order of ToObjects.
+	Objects []*unstructured.Unstructured `json:"objects"`
+	// Notifications holds the notifications raised during the conversion,
+	// followed by the conversion errors, as in a notifications.Report.
+	Notifications []notifications.ReportEntry `json:"notifications"`
+	// Error is set when the request could not be converted.
+	Error string `json:"error,omitempty"`
+}
+
+// NewConversionHandler returns a handler converting the manifests POSTed to
+// ConvertPath. The body is a multi-document YAML or JSON manifest, and the
+// query selects the providers, e.g.
+// ?providers=ingress-nginx,kong&namespace=default&kong-<flag>=<value>. The
+// other query parameters are the provider-specific flags, named as on the
+// command line.
+//
+// Every request is converted in memory with its own notifications, so
+// concurrent requests do not affect each other. The response is a
+// ConvertResponse, with status 422 if the resources could not be converted.
+// With ?best-effort=true, the resources which could not be converted are
+// skipped, and the others are returned with status 200.
+func NewConversionHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST "+ConvertPath, handleConvert)
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	return mux
+}
+
+func handleConvert(w http.ResponseWriter, r *http.Request) {
+	req, err := parseConvertQuery(r)
+	if err != nil {
+		writeConvertResponse(w, http.StatusBadRequest, ConvertResponse{Error: err.Error()})
+		return
+	}
+	req.Manifests = []Manifest{{Name: "request", Reader: http.MaxBytesReader(w, r.Body, MaxManifestBytes)}}
+	result, err := Convert(r.Context(), req)
+	var conversionErr *ConversionError
+	if err != nil && !errors.As(err, &conversionErr) {
+		writeConvertResponse(w, http.StatusBadRequest, ConvertResponse{Error: err.Error()})
+		return
+	}
+
+	errsByProvider := make(map[string]field.ErrorList)
+	if conversionErr != nil {
+		for provider, errs := range conversionErr.Errors {
+			errsByProvider[string(provider)] = errs
+		}
+	}
+	response := ConvertResponse{
+		Objects:       []*unstructured.Unstructured{},
+		Notifications: notifications.NewReport(result.Notifications, errsByProvider).Entries,
+	}
+	if conversionErr != nil {
+		if !req.Options.BestEffort {
+			response.Error = "failed to convert the resources, see the notifications"
+			writeConvertResponse(w, http.StatusUnprocessableEntity, response)
+			return
+		}
+		response.Error = "skipped the resources which could not be converted, see the notifications"
+	}
+	for _, obj := range ToObjects(result.GatewayResources) {
+		u, err := CastToUnstructured(obj)
+		if err != nil {
+			writeConvertResponse(w, http.StatusInternalServerError, ConvertResponse{Error: err.Error()})
+			return
+		}
+		response.Objects = append(response.Objects, u)
+	}
+	writeConvertResponse(w, http.StatusOK, response)
+}
+
+// parseConvertQuery returns the conversion request of the query, without the
+// manifests. The flags which are not set take their default value.
+func parseConvertQuery(r *http.Request) (ConvertRequest, error) {
+	query := r.URL.Query()
+	var providers []string
+	for _, value := range query["providers"] {
+		for _, provider := range strings.Split(value, ",") {
+			if provider = strings.TrimSpace(provider); provider != "" {
+				providers = append(providers, provider)
+			}
+		}
+	}
+	if len(providers) == 0 {
+		return ConvertRequest{}, fmt.Errorf("no providers specified, set the providers query parameter")
+	}
+
+	flagDefinitions := GetProviderSpecificFlagDefinitions()
+	providerSpecificFlags := make(map[string]map[string]string)
+	knownParams := map[string]bool{"providers": true, "namespace": true, "best-effort": true}
+	for _, provider := range providers {
+		if _, ok := ProviderConstructorByName[ProviderName(provider)]; !ok {
+			return ConvertRequest{}, fmt.Errorf("%s is not a supported provider", provider)
+		}
+		for name, flag := range flagDefinitions[ProviderName(provider)] {
+			param := fmt.Sprintf("%s-%s", provider, name)
+			knownParams[param] = true
+			if providerSpecificFlags[provider] == nil {
+				providerSpecificFlags[provider] = make(map[string]string)
+			}
+			providerSpecificFlags[provider][name] = flag.DefaultValue
+			if query.Has(param) {
+				providerSpecificFlags[provider][name] = query.Get(param)
+			}
+		}
+	}
+	for param := range query {
+		if !knownParams[param] {
+			return ConvertRequest{}, fmt.Errorf("unknown query parameter %s", param)
+		}
+	}
+
+	var bestEffort bool
+	if query.Has("best-effort") {
+		var err error
+		if bestEffort, err = strconv.ParseBool(query.Get("best-effort")); err != nil {
+			return ConvertRequest{}, fmt.Errorf("invalid best-effort query parameter: %w", err)
+		}
+	}
+	return ConvertRequest{
+		Providers:             providers,
+		Namespace:             query.Get("namespace"),
+		ProviderSpecificFlags: providerSpecificFlags,
+		Options:               ConversionOptions{BestEffort: bestEffort},
+	}, nil
+}
+
+func writeConvertResponse(w http.ResponseWriter, status int, response ConvertResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,266 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package i2gw
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/intermediate"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	kubeyaml "k8s.io/apimachinery/pkg/util/yaml"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// serveProvider converts every Ingress read to an HTTPRoute
+// named after it and the suffix flag, with a notification per Ingress. It
+// fails to convert the Ingresses named invalid, which are skipped in
+// best-effort mode.
+type serveProvider struct {
+	conf      *ProviderConf
+	ingresses []networkingv1.Ingress
+}
+
+func (p *serveProvider) ReadResourcesFromCluster(ctx context.Context) error {
+	var ingressList networkingv1.IngressList
+	if err := p.conf.Client.List(ctx, &ingressList); err != nil {
+		return err
+	}
+	p.ingresses = append(p.ingresses, ingressList.Items...)
+	return nil
+}
+
+func (p *serveProvider) ReadResourcesFromFiles(_ context.Context, filenames []string) error {
+	for _, filename := range filenames {
+		data, err := p.conf.ReadInputFile(filename)
+		if err != nil {
+			return err
+		}
+		decoder := kubeyaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+		for {
+			var obj unstructured.Unstructured
+			if err := decoder.Decode(&obj.Object); err != nil {
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				return err
+			}
+			objs := []unstructured.Unstructured{obj}
+			if obj.IsList() {
+				list, err := obj.ToList()
+				if err != nil {
+					return err
+				}
+				objs = list.Items
+			}
+			for _, obj := range objs {
+				if p.conf.Namespace != "" && obj.GetNamespace() != p.conf.Namespace {
+					continue
+				}
+				var ingress networkingv1.Ingress
+				if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &ingress); err != nil {
+					return err
+				}
+				ingress.Annotations = map[string]string{notifications.SourceFileAnnotationKey: filename}
+				p.ingresses = append(p.ingresses, ingress)
+			}
+		}
+	}
+	return nil
+}
+
+func (p *serveProvider) ToIR() (intermediate.IR, field.ErrorList) {
+	return intermediate.IR{}, nil
+}
+
+func (p *serveProvider) ToGatewayResources(intermediate.IR) (GatewayResources, field.ErrorList) {
+	gatewayResources := GatewayResources{HTTPRoutes: make(map[types.NamespacedName]gatewayv1.HTTPRoute)}
+	var errs field.ErrorList
+	for i, ingress := range p.ingresses {
+		if ingress.Name == "invalid" {
+			errs = append(errs, field.Invalid(field.NewPath("metadata", "name"), ingress.Name, "invalid Ingress"))
+			continue
+		}
+		p.conf.Notify("serve-provider", notifications.NewNotification(notifications.InfoNotification, "converted "+ingress.Name, &p.ingresses[i]))
+		name := ingress.Name + p.conf.ProviderSpecificFlags["serve-provider"]["suffix"]
+		route := gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: ingress.Namespace, Name: name}}
+		route.SetGroupVersionKind(gatewayv1.SchemeGroupVersion.WithKind("HTTPRoute"))
+		gatewayResources.HTTPRoutes[types.NamespacedName{Namespace: ingress.Namespace, Name: name}] = route
+	}
+	if len(errs) > 0 && !p.conf.BestEffort {
+		return GatewayResources{}, errs
+	}
+	return gatewayResources, errs
+}
+
+func ingressManifest(namespace, name string) string {
+	return fmt.Sprintf("apiVersion: networking.k8s.io/v1\nkind: Ingress\nmetadata:\n  namespace: %s\n  name: %s\n", namespace, name)
+}
+
+func postManifests(server *httptest.Server, query, manifests string) (int, ConvertResponse, error) {
+	resp, err := http.Post(server.URL+ConvertPath+"?"+query, "application/yaml", strings.NewReader(manifests))
+	if err != nil {
+		return 0, ConvertResponse{}, err
+	}
+	defer resp.Body.Close()
+	var response ConvertResponse
+	err = json.NewDecoder(resp.Body).Decode(&response)
+	return resp.StatusCode, response, err
+}
+
+func objectNames(objects []*unstructured.Unstructured) []string {
+	names := []string{}
+	for _, obj := range objects {
+		names = append(names, fmt.Sprintf("%s/%s/%s", obj.GetKind(), obj.GetNamespace(), obj.GetName()))
+	}
+	return names
+}
+
+func Test_ConversionHandler(t *testing.T) {
+	ProviderConstructorByName["serve-provider"] = func(conf *ProviderConf) Provider { return &serveProvider{conf: conf} }
+	t.Cleanup(func() { delete(ProviderConstructorByName, "serve-provider") })
+	RegisterProviderSpecificFlag("serve-provider", ProviderSpecificFlag{Name: "suffix"})
+
+	server := httptest.NewServer(NewConversionHandler())
+	defer server.Close()
+
+	testCases := []struct {
+		name                  string
+		query                 string
+		manifests             string
+		expectedStatus        int
+		expectedObjects       []string
+		expectedNotifications []string
+		expectedErr           string
+	}{
+		{
+			name:                  "convert",
+			query:                 "providers=serve-provider",
+			manifests:             ingressManifest("default", "foo") + "---\n" + ingressManifest("other", "bar"),
+			expectedStatus:        http.StatusOK,
+			expectedObjects:       []string{"HTTPRoute/default/foo", "HTTPRoute/other/bar"},
+			expectedNotifications: []string{"converted foo", "converted bar"},
+		},
+		{
+			name:                  "namespace and provider-specific flag",
+			query:                 "providers=serve-provider&namespace=other&serve-provider-suffix=-route",
+			manifests:             ingressManifest("default", "foo") + "---\n" + ingressManifest("other", "bar"),
+			expectedStatus:        http.StatusOK,
+			expectedObjects:       []string{"HTTPRoute/other/bar-route"},
+			expectedNotifications: []string{"converted bar"},
+		},
+		{
+			name:                  "conversion error",
+			query:                 "providers=serve-provider",
+			manifests:             ingressManifest("default", "invalid"),
+			expectedStatus:        http.StatusUnprocessableEntity,
+			expectedObjects:       []string{},
+			expectedNotifications: []string{"Invalid value: \"invalid\": invalid Ingress"},
+			expectedErr:           "failed to convert the resources, see the notifications",
+		},
+		{
+			name:                  "conversion error with best effort",
+			query:                 "providers=serve-provider&best-effort=true",
+			manifests:             ingressManifest("default", "foo") + "---\n" + ingressManifest("default", "invalid"),
+			expectedStatus:        http.StatusOK,
+			expectedObjects:       []string{"HTTPRoute/default/foo"},
+			expectedNotifications: []string{"converted foo", "Invalid value: \"invalid\": invalid Ingress"},
+			expectedErr:           "skipped the resources which could not be converted, see the notifications",
+		},
+		{
+			name:           "no providers",
+			manifests:      ingressManifest("default", "foo"),
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    "no providers specified, set the providers query parameter",
+		},
+		{
+			name:           "unsupported provider",
+			query:          "providers=serve-provider,unknown",
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    "unknown is not a supported provider",
+		},
+		{
+			name:           "unknown query parameter",
+			query:          "providers=serve-provider&serve-provider-prefix=route-",
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    "unknown query parameter serve-provider-prefix",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			status, response, err := postManifests(server, tc.query, tc.manifests)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if status != tc.expectedStatus {
+				t.Errorf("Expected status %d, got %d: %+v", tc.expectedStatus, status, response)
+			}
+			if response.Error != tc.expectedErr {
+				t.Errorf("Expected error %q, got %q", tc.expectedErr, response.Error)
+			}
+			if diff := cmp.Diff(tc.expectedObjects, objectNames(response.Objects)); tc.expectedObjects != nil && diff != "" {
+				t.Errorf("Unexpected objects (-want +got):\n%s", diff)
+			}
+			var gotNotifications []string
+			for _, n := range response.Notifications {
+				gotNotifications = append(gotNotifications, n.Message)
+			}
+			if diff := cmp.Diff(tc.expectedNotifications, gotNotifications); diff != "" {
+				t.Errorf("Unexpected notifications (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("concurrent requests", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func(name string) {
+				defer wg.Done()
+				status, response, err := postManifests(server, "providers=serve-provider", ingressManifest("default", name))
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if status != http.StatusOK {
+					t.Errorf("Expected status %d for %s, got %d: %+v", http.StatusOK, name, status, response)
+				}
+				if len(response.Notifications) != 1 || response.Notifications[0].Message != "converted "+name {
+					t.Errorf("Expected the notification of %s only, got %+v", name, response.Notifications)
+				}
+			}(fmt.Sprintf("ingress-%d", i))
+		}
+		wg.Wait()
+	})
+}
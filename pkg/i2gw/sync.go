@@ -0,0 +1,199 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package i2gw
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+// syncRequest is the single request reconciled by the SyncReconciler. All the
+// resources are converted together, so every event is mapped to it, and the
+// events received during a sync are coalesced into the next one.
+var syncRequest = reconcile.Request{NamespacedName: types.NamespacedName{Name: "ingress2gateway"}}
+
+// SyncReconciler keeps the Gateway API objects generated from the resources of
+// the providers up to date. Every change to one of the resources converts all
+// of them again, and applies the result with ApplyGatewayResources.
+type SyncReconciler struct {
+	// Client reads the resources of the providers and applies the generated
+	// objects. It is usually the client of the manager: its cache is updated
+	// before the events are received, so every sync reads the changes which
+	// triggered it.
+	Client                client.Client
+	Namespace             string
+	Providers             []string
+	ProviderSpecificFlags map[string]map[string]string
+	ConversionOptions     ConversionOptions
+	ApplyOptions          ApplyOptions
+	// ProvenanceAnnotations adds the provenance annotations to the generated
+	// objects. See AddProvenanceAnnotations.
+	ProvenanceAnnotations bool
+	// OnSync, if not nil, is called with the results of every sync which
+	// converted the resources.
+	OnSync func(results []ApplyResult)
+}
+
+// Reconcile converts the resources and applies the generated objects. The
+// conversion errors are logged without retrying, as the resources must change
+// to be converted, while the objects which failed to apply are retried. With
+// ConversionOptions.BestEffort, the objects converted from the other
+// resources are still applied.
+func (r *SyncReconciler) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+	opts := r.ConversionOptions
+	opts.Client = r.Client
+	gatewayResources, _, err := ToGatewayAPIResourcesWithOptions(ctx, r.Namespace, nil, r.Providers, r.ProviderSpecificFlags, opts)
+	if err != nil {
+		var conversionErr *ConversionError
+		if !errors.As(err, &conversionErr) {
+			return reconcile.Result{}, err
+		}
+		if !opts.BestEffort {
+			klog.Errorf("Failed to convert the resources, waiting for them to change: %v", err)
+			return reconcile.Result{}, nil
+		}
+		klog.Errorf("Skipped the resources which could not be converted, waiting for them to change: %v", err)
+	}
+	if r.ProvenanceAnnotations {
+		if err := AddProvenanceAnnotations(gatewayResources); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to add provenance annotations: %w", err)
+		}
+	}
+
+	results := ApplyGatewayResources(ctx, r.Client, gatewayResources, r.ApplyOptions)
+	if r.OnSync != nil {
+		r.OnSync(results)
+	}
+	var failed int
+	for _, result := range results {
+		if result.Type == ApplyResultFailed {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return reconcile.Result{}, fmt.Errorf("failed to apply %d of %d objects", failed, len(results))
+	}
+	return reconcile.Result{}, nil
+}
+
+// SetupWithManager registers the reconciler with mgr, watching the resource
+// kinds registered by the providers with RegisterProviderResourceKinds, and
+// the generated Gateway API kinds, so that the generated objects changed or
+// deleted by someone else are applied again. Only the objects applied by the
+// FieldManager field manager are watched among the latter, and only the
+// changes of their spec, labels and annotations. The kinds which are not
+// served by the cluster, e.g. the CRDs of another implementation, are not
+// watched. The manager should be configured to cache the namespace of the
+// reconciler only.
+func (r *SyncReconciler) SetupWithManager(mgr manager.Manager) error {
+	b := builder.ControllerManagedBy(mgr).Named("ingress2gateway-sync")
+	watched := make(map[schema.GroupVersionKind]bool)
+	for _, gvk := range r.watchedKinds() {
+		watched[gvk] = true
+		if err := watchKind(mgr, b, gvk); err != nil {
+			return err
+		}
+	}
+	for _, gvk := range generatedKinds {
+		if watched[gvk] {
+			continue
+		}
+		err := watchKind(mgr, b, gvk,
+			predicate.NewPredicateFuncs(isAppliedByFieldManager),
+			predicate.Or(predicate.GenerationChangedPredicate{}, predicate.LabelChangedPredicate{}, predicate.AnnotationChangedPredicate{}),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return b.Complete(r)
+}
+
+// watchKind makes b watch the objects of kind gvk which pass the predicates,
+// if the kind is served by the cluster.
+func watchKind(mgr manager.Manager, b *builder.Builder, gvk schema.GroupVersionKind, predicates ...predicate.Predicate) error {
+	_, err := mgr.GetRESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+	if meta.IsNoMatchError(err) {
+		klog.Infof("%s is not served by the cluster, it is not watched", gvk.GroupKind())
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get the REST mapping of %s: %w", gvk.GroupKind(), err)
+	}
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	b.Watches(obj, handler.EnqueueRequestsFromMapFunc(func(context.Context, client.Object) []reconcile.Request {
+		return []reconcile.Request{syncRequest}
+	}), builder.WithPredicates(predicates...))
+	return nil
+}
+
+// generatedKinds are the kinds of the GatewayResources. The kinds of the
+// GatewayExtensions are not known in advance, so they are not watched.
+var generatedKinds = []schema.GroupVersionKind{
+	gatewayv1.SchemeGroupVersion.WithKind("GatewayClass"),
+	gatewayv1.SchemeGroupVersion.WithKind("Gateway"),
+	gatewayv1.SchemeGroupVersion.WithKind("HTTPRoute"),
+	gatewayv1alpha2.SchemeGroupVersion.WithKind("TLSRoute"),
+	gatewayv1alpha2.SchemeGroupVersion.WithKind("TCPRoute"),
+	gatewayv1alpha2.SchemeGroupVersion.WithKind("UDPRoute"),
+	gatewayv1beta1.SchemeGroupVersion.WithKind("ReferenceGrant"),
+}
+
+// isAppliedByFieldManager returns whether fields of obj are managed by the
+// FieldManager field manager, i.e. whether obj was applied by
+// ApplyGatewayResources.
+func isAppliedByFieldManager(obj client.Object) bool {
+	for _, entry := range obj.GetManagedFields() {
+		if entry.Manager == FieldManager {
+			return true
+		}
+	}
+	return false
+}
+
+// watchedKinds returns the resource kinds of the providers of the reconciler,
+// without duplicates.
+func (r *SyncReconciler) watchedKinds() []schema.GroupVersionKind {
+	var kinds []schema.GroupVersionKind
+	seen := make(map[schema.GroupVersionKind]bool)
+	for _, provider := range r.Providers {
+		for _, gvk := range GetProviderResourceKinds(ProviderName(provider)) {
+			if !seen[gvk] {
+				seen[gvk] = true
+				kinds = append(kinds, gvk)
+			}
+		}
+	}
+	return kinds
+}
@@ -0,0 +1,219 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package i2gw
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/intermediate"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// syncProvider converts every Ingress read from the cluster to an HTTPRoute
+// of the same name, and fails to convert the Ingresses named invalid, which
+// are skipped in best-effort mode.
+type syncProvider struct {
+	conf      *ProviderConf
+	ingresses []networkingv1.Ingress
+}
+
+func (p *syncProvider) ReadResourcesFromCluster(ctx context.Context) error {
+	ingressList := &networkingv1.IngressList{}
+	if err := p.conf.Client.List(ctx, ingressList); err != nil {
+		return err
+	}
+	p.ingresses = ingressList.Items
+	return nil
+}
+
+func (p *syncProvider) ReadResourcesFromFiles(context.Context, []string) error {
+	return nil
+}
+
+func (p *syncProvider) ToIR() (intermediate.IR, field.ErrorList) {
+	return intermediate.IR{}, nil
+}
+
+func (p *syncProvider) ToGatewayResources(intermediate.IR) (GatewayResources, field.ErrorList) {
+	gatewayResources := GatewayResources{HTTPRoutes: make(map[types.NamespacedName]gatewayv1.HTTPRoute)}
+	var errs field.ErrorList
+	for _, ingress := range p.ingresses {
+		if ingress.Name == "invalid" {
+			errs = append(errs, field.Invalid(field.NewPath("metadata", "name"), ingress.Name, "invalid Ingress"))
+			continue
+		}
+		route := gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: ingress.Namespace, Name: ingress.Name}}
+		route.SetGroupVersionKind(gatewayv1.SchemeGroupVersion.WithKind("HTTPRoute"))
+		gatewayResources.HTTPRoutes[types.NamespacedName{Namespace: ingress.Namespace, Name: ingress.Name}] = route
+	}
+	if len(errs) > 0 && !p.conf.BestEffort {
+		return GatewayResources{}, errs
+	}
+	return gatewayResources, errs
+}
+
+func Test_SyncReconciler(t *testing.T) {
+	ProviderConstructorByName["sync-provider"] = func(conf *ProviderConf) Provider { return &syncProvider{conf: conf} }
+	t.Cleanup(func() { delete(ProviderConstructorByName, "sync-provider") })
+
+	ingress := func(namespace, name string) *networkingv1.Ingress {
+		return &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	}
+	unmanagedRoute := &gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{
+		Namespace:   "default",
+		Name:        "manual",
+		Annotations: map[string]string{UnmanagedAnnotationKey: "true"},
+	}}
+
+	testCases := []struct {
+		name            string
+		namespace       string
+		bestEffort      bool
+		objects         []client.Object
+		expectedResults map[string]ApplyResultType
+		expectedRoutes  []string
+	}{
+		{
+			name:            "sync",
+			objects:         []client.Object{ingress("default", "foo"), ingress("other", "bar"), ingress("default", "manual"), unmanagedRoute},
+			expectedResults: map[string]ApplyResultType{"default/foo": ApplyResultCreated, "other/bar": ApplyResultCreated, "default/manual": ApplyResultSkipped},
+			expectedRoutes:  []string{"default/foo", "default/manual", "other/bar"},
+		},
+		{
+			name:            "sync a namespace",
+			namespace:       "other",
+			objects:         []client.Object{ingress("default", "foo"), ingress("other", "bar")},
+			expectedResults: map[string]ApplyResultType{"other/bar": ApplyResultCreated},
+			expectedRoutes:  []string{"other/bar"},
+		},
+		{
+			name:           "conversion error",
+			objects:        []client.Object{ingress("default", "foo"), ingress("default", "invalid")},
+			expectedRoutes: []string{},
+		},
+		{
+			name:            "conversion error with best effort",
+			bestEffort:      true,
+			objects:         []client.Object{ingress("default", "foo"), ingress("default", "invalid")},
+			expectedResults: map[string]ApplyResultType{"default/foo": ApplyResultCreated},
+			expectedRoutes:  []string{"default/foo"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			if err := networkingv1.AddToScheme(scheme); err != nil {
+				t.Fatal(err)
+			}
+			if err := gatewayv1.Install(scheme); err != nil {
+				t.Fatal(err)
+			}
+			cl := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(tc.objects...).
+				WithInterceptorFuncs(applyPatchInterceptor("")).
+				Build()
+
+			var gotResults map[string]ApplyResultType
+			r := &SyncReconciler{
+				Client:    cl,
+				Namespace: tc.namespace,
+				Providers: []string{"sync-provider"},
+				ConversionOptions: ConversionOptions{
+					BestEffort: tc.bestEffort,
+				},
+				OnSync: func(results []ApplyResult) {
+					gotResults = make(map[string]ApplyResultType)
+					for _, result := range results {
+						gotResults[client.ObjectKeyFromObject(result.Object).String()] = result.Type
+					}
+				},
+			}
+			if _, err := r.Reconcile(context.Background(), syncRequest); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expectedResults, gotResults); diff != "" {
+				t.Errorf("Unexpected sync results (-want +got):\n%s", diff)
+			}
+
+			routes := &gatewayv1.HTTPRouteList{}
+			if err := cl.List(context.Background(), routes); err != nil {
+				t.Fatal(err)
+			}
+			gotRoutes := []string{}
+			for _, route := range routes.Items {
+				gotRoutes = append(gotRoutes, client.ObjectKeyFromObject(&route).String())
+			}
+			if diff := cmp.Diff(tc.expectedRoutes, gotRoutes); diff != "" {
+				t.Errorf("Unexpected HTTPRoutes in the cluster (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_SyncReconciler_watchedKinds(t *testing.T) {
+	ingressGVK := networkingv1.SchemeGroupVersion.WithKind("Ingress")
+	crdGVK := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Route"}
+	RegisterProviderResourceKinds("sync-watch-a", ingressGVK)
+	RegisterProviderResourceKinds("sync-watch-b", ingressGVK, crdGVK)
+
+	r := &SyncReconciler{Providers: []string{"sync-watch-a", "sync-watch-b"}}
+	if diff := cmp.Diff([]schema.GroupVersionKind{ingressGVK, crdGVK}, r.watchedKinds()); diff != "" {
+		t.Errorf("Unexpected watched kinds (-want +got):\n%s", diff)
+	}
+}
+
+func Test_isAppliedByFieldManager(t *testing.T) {
+	testCases := []struct {
+		name          string
+		managedFields []metav1.ManagedFieldsEntry
+		expected      bool
+	}{
+		{
+			name:          "applied by ingress2gateway",
+			managedFields: []metav1.ManagedFieldsEntry{{Manager: "kubectl"}, {Manager: FieldManager, Operation: metav1.ManagedFieldsOperationApply}},
+			expected:      true,
+		},
+		{
+			name:          "managed by others",
+			managedFields: []metav1.ManagedFieldsEntry{{Manager: "kubectl"}},
+		},
+		{
+			name: "no managed fields",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			route := &gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Name: "foo", ManagedFields: tc.managedFields}}
+			if got := isAppliedByFieldManager(route); got != tc.expected {
+				t.Errorf("Expected %t, got %t", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package i2gw
+
+import (
+	"reflect"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/intermediate"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	// TraceStageCommonToIR is the stage converting the resources to the IR
+	// without any provider-specific feature.
+	TraceStageCommonToIR = "common.ToIR"
+	// TraceStageToIR is the stage returning the IR of a provider, with all
+	// its features.
+	TraceStageToIR = "ToIR"
+	// TraceStageToGatewayResources is the stage converting the IR of a
+	// provider to Gateway API resources.
+	TraceStageToGatewayResources = "ToGatewayResources"
+)
+
+// Tracer is called after every stage of the conversion of a provider. The IR
+// and the resources are modified by the next stages, so a Tracer must copy
+// what it keeps.
+type Tracer interface {
+	// TraceIR is called with the IR of provider after stage, e.g. after a
+	// FeatureParser.
+	TraceIR(provider ProviderName, stage string, ir intermediate.IR)
+	// TraceGatewayResources is called with the resources generated by
+	// provider.
+	TraceGatewayResources(provider ProviderName, gatewayResources GatewayResources)
+}
+
+// TraceIR calls the Tracer of the conf, if any, with the IR of provider
+// after stage.
+func (c *ProviderConf) TraceIR(provider ProviderName, stage string, ir intermediate.IR) {
+	if c == nil || c.Tracer == nil {
+		return
+	}
+	c.Tracer.TraceIR(provider, stage, ir)
+}
+
+// ParseFeatures applies the feature parsers to the IR one by one, and returns
+// all their errors. The IR is traced after every parser, as a stage named
+// after the parser function.
+//
+// The parsers listed in the DisabledFeatures of the conf for provider are
+// skipped. They are listed by function name without the package, e.g.
+// canaryFeature, and unknown names are reported as errors.
+func ParseFeatures(conf *ProviderConf, provider ProviderName, featureParsers []FeatureParser, ingresses []networkingv1.Ingress, ir *intermediate.IR) field.ErrorList {
+	var disabledFeatures []string
+	if conf != nil {
+		disabledFeatures = conf.DisabledFeatures[provider]
+	}
+
+	var errs field.ErrorList
+	features := make([]string, 0, len(featureParsers))
+	for _, parseFeatureFunc := range featureParsers {
+		name := featureParserName(parseFeatureFunc)
+		features = append(features, name[strings.LastIndex(name, ".")+1:])
+		if slices.Contains(disabledFeatures, features[len(features)-1]) {
+			continue
+		}
+		errs = append(errs, parseFeatureFunc(conf, ingresses, ir)...)
+		conf.TraceIR(provider, name, *ir)
+	}
+	for i, feature := range disabledFeatures {
+		if !slices.Contains(features, feature) {
+			errs = append(errs, field.NotSupported(field.NewPath("providerSettings").Key(string(provider)).Child("disabledFeatures").Index(i), feature, features))
+		}
+	}
+	return errs
+}
+
+// featureParserName returns the name of the function of the parser, prefixed
+// by its package, e.g. ingressnginx.canaryFeature.
+func featureParserName(parser FeatureParser) string {
+	name := runtime.FuncForPC(reflect.ValueOf(parser).Pointer()).Name()
+	return name[strings.LastIndex(name, "/")+1:]
+}